@@ -25,6 +25,10 @@ import (
 //     for Iterative Methods (2nd ed.) (pp. 24-25). Philadelphia, PA: SIAM.
 //     Retrieved from http://www.netlib.org/templates/templates.pdf
 type BiCGStab struct {
+	// Workers is the number of goroutines that perform the vector
+	// operations. See the CG documentation for more information.
+	Workers int
+
 	x     mat.VecDense
 	r, rt mat.VecDense
 	p     mat.VecDense
@@ -37,6 +41,8 @@ type BiCGStab struct {
 	alpha        float64
 	omega        float64
 
+	kern kernels
+
 	resume int
 }
 
@@ -47,6 +53,8 @@ func (b *BiCGStab) Init(x, residual *mat.VecDense) {
 		panic("bicgstab: vector length mismatch")
 	}
 
+	b.kern.init(b.Workers)
+
 	b.x.CloneFromVec(x)
 	b.r.CloneFromVec(residual)
 	b.rt.CloneFromVec(&b.r)
@@ -81,15 +89,15 @@ func (b *BiCGStab) Init(x, residual *mat.VecDense) {
 func (b *BiCGStab) Iterate(ctx *Context) (Operation, error) {
 	switch b.resume {
 	case 1:
-		b.rho = mat.Dot(&b.rt, &b.r)
+		b.rho = b.kern.dot(&b.rt, &b.r)
 		if math.Abs(b.rho) < breakdownTol {
 			b.resume = 0
 			return NoOperation, &BreakdownError{math.Abs(b.rho), breakdownTol}
 		}
 		// p_i = r_{i-1} + beta*(p_{i-1} - omega * v_{i-1})
 		beta := (b.rho / b.rhoPrev) * (b.alpha / b.omega)
-		b.p.AddScaledVec(&b.p, -b.omega, &b.v)
-		b.p.AddScaledVec(&b.r, beta, &b.p)
+		b.kern.addScaled(&b.p, &b.p, -b.omega, &b.v)
+		b.kern.addScaled(&b.p, &b.r, beta, &b.p)
 		// Solve M^{-1} * p_i.
 		ctx.Src.CopyVec(&b.p)
 		b.resume = 2
@@ -102,16 +110,16 @@ func (b *BiCGStab) Iterate(ctx *Context) (Operation, error) {
 		return MulVec, nil
 	case 3:
 		b.v.CopyVec(ctx.Dst)
-		rtv := mat.Dot(&b.rt, &b.v)
+		rtv := b.kern.dot(&b.rt, &b.v)
 		if rtv == 0 {
 			b.resume = 0
 			return NoOperation, &BreakdownError{}
 		}
 		b.alpha = b.rho / rtv
 		// Form the residual and X so that we can check for tolerance early.
-		ctx.X.AddScaledVec(ctx.X, b.alpha, &b.phat)
-		b.r.AddScaledVec(&b.r, -b.alpha, &b.v)
-		ctx.ResidualNorm = mat.Norm(&b.r, 2)
+		b.kern.addScaled(ctx.X, ctx.X, b.alpha, &b.phat)
+		b.kern.addScaled(&b.r, &b.r, -b.alpha, &b.v)
+		ctx.ResidualNorm = b.kern.norm(&b.r)
 		b.resume = 4
 		return CheckResidualNorm, nil
 	case 4:
@@ -131,10 +139,10 @@ func (b *BiCGStab) Iterate(ctx *Context) (Operation, error) {
 		return MulVec, nil
 	case 6:
 		b.t.CopyVec(ctx.Dst)
-		b.omega = mat.Dot(&b.t, &b.r) / mat.Dot(&b.t, &b.t)
-		ctx.X.AddScaledVec(ctx.X, b.omega, &b.shat)
-		b.r.AddScaledVec(&b.r, -b.omega, &b.t)
-		ctx.ResidualNorm = mat.Norm(&b.r, 2)
+		b.omega = b.kern.dot(&b.t, &b.r) / b.kern.dot(&b.t, &b.t)
+		b.kern.addScaled(ctx.X, ctx.X, b.omega, &b.shat)
+		b.kern.addScaled(&b.r, &b.r, -b.omega, &b.t)
+		ctx.ResidualNorm = b.kern.norm(&b.r)
 		b.resume = 7
 		return CheckResidualNorm, nil
 	case 7:
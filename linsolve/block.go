@@ -0,0 +1,351 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// MulMatToer represents a square matrix A by means of a matrix-matrix
+// multiplication. Implementing MulMatToer allows block methods to multiply
+// A with several vectors while reading the representation of A only once.
+type MulMatToer interface {
+	// MulMatTo computes A*X or Aᵀ*X and stores the result into dst.
+	MulMatTo(dst *mat.Dense, trans bool, x mat.Matrix)
+}
+
+// BlockMethod is an iterative method that produces a sequence of matrices that
+// converge to the solution of the system of linear equations with multiple
+// right-hand sides
+//
+//	A * X = B,
+//
+// where A is non-singular n×n matrix, and X and B are n×k matrices.
+//
+// BlockMethod uses the same reverse-communication interface as Method, except
+// that the operations act on matrices instead of vectors. See the
+// documentation for Method and BlockContext for more information.
+type BlockMethod interface {
+	// Init initializes the method for solving an n×n
+	// linear system with k right-hand sides with an
+	// initial estimate x and the corresponding n×k
+	// residual matrix.
+	//
+	// BlockMethod will not retain x or residual.
+	Init(x, residual *mat.Dense)
+
+	// Iterate performs a step in converging to the
+	// solution of a linear system.
+	//
+	// Iterate retrieves data from BlockContext, updates
+	// it, and returns the next operation. The caller
+	// must perform the Operation using data in
+	// BlockContext, and depending on the state call
+	// Iterate again.
+	Iterate(*BlockContext) (Operation, error)
+}
+
+// BlockContext mediates the communication between the BlockMethod and the
+// caller. The caller must not modify BlockContext apart from the commanded
+// Operations.
+type BlockContext struct {
+	// X will be set by BlockMethod to the current
+	// approximate solution when it commands
+	// ComputeResidual and MajorIteration.
+	X *mat.Dense
+
+	// ResidualNorms holds (an estimate of) a norm of the
+	// residual for each right-hand side. BlockMethod will
+	// set it to the current values when it commands
+	// CheckResidualNorm.
+	ResidualNorms []float64
+
+	// Converged indicates to BlockMethod whether
+	// ResidualNorms satisfy a stopping criterion for all
+	// right-hand sides as a result of CheckResidualNorm
+	// operation.
+	Converged bool
+
+	// Src and Dst are the source and destination matrices
+	// for various Operations. Src will be set by
+	// BlockMethod and the caller must store the result in
+	// Dst. BlockMethod will ensure that Dst has the same
+	// dimensions as Src for MulVec and PreconSolve, and
+	// the dimensions of X for ComputeResidual. The number
+	// of columns of Src may be smaller than the number of
+	// right-hand sides.
+	Src, Dst *mat.Dense
+}
+
+// BlockSettings holds settings for solving a linear system with multiple
+// right-hand sides.
+type BlockSettings struct {
+	// InitX holds the initial guess. If it is nil or empty,
+	// the zero matrix will be used, otherwise its dimensions
+	// must be equal to the dimensions of B.
+	InitX *mat.Dense
+
+	// Dst, if not nil, will be used for storing the
+	// approximate solution, otherwise a new matrix will be
+	// allocated. In both cases the matrix will also be
+	// returned in BlockResult. If Dst is not empty, its
+	// dimensions must be equal to the dimensions of B.
+	Dst *mat.Dense
+
+	// Tolerance specifies error tolerance for the final
+	// (approximate) solution. The iteration will be stopped
+	// when for all columns j
+	//  |r_j| < Tolerance * |b_j|
+	// where r_j is the j-th column of the residual.
+	//
+	// If Tolerance is zero, a default value of 1e-8 will be
+	// used, otherwise it must be positive and less than 1.
+	Tolerance float64
+
+	// MaxIterations is the limit on the number of
+	// iterations. If it is zero, a default value of four
+	// times the dimension of the system will be used.
+	MaxIterations int
+
+	// PreconSolve describes a preconditioner solve as in
+	// Settings.PreconSolve. It will be applied to each
+	// column of the source matrix. If PreconSolve is nil,
+	// no preconditioning will be used.
+	PreconSolve func(dst *mat.VecDense, trans bool, rhs mat.Vector) error
+}
+
+// BlockResult holds the result of an iterative solve with multiple right-hand
+// sides.
+type BlockResult struct {
+	// X is the approximate solution.
+	X *mat.Dense
+
+	// ResidualNorms holds an approximation to the norm of
+	// the final residual for each right-hand side.
+	ResidualNorms []float64
+
+	// Stats holds statistics about the iterative solve.
+	// MulVec and PreconSolve count the operations
+	// commanded by BlockMethod, each of which acts on
+	// several vectors at once.
+	Stats Stats
+}
+
+func defaultBlockSettings(s *BlockSettings, n, k int) {
+	if s.InitX != nil && s.InitX.IsEmpty() {
+		s.InitX.ReuseAs(n, k)
+	}
+	if s.Dst == nil {
+		s.Dst = mat.NewDense(n, k, nil)
+	} else if s.Dst.IsEmpty() {
+		s.Dst.ReuseAs(n, k)
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = defaultTolerance
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 4 * n
+	}
+	if s.PreconSolve == nil {
+		s.PreconSolve = NoPreconditioner
+	}
+}
+
+func checkBlockSettings(s *BlockSettings, n, k int) {
+	if s.InitX != nil {
+		if r, c := s.InitX.Dims(); r != n || c != k {
+			panic("linsolve: mismatched dimensions of initial guess")
+		}
+	}
+	if r, c := s.Dst.Dims(); r != n || c != k {
+		panic("linsolve: mismatched destination dimensions")
+	}
+	if s.Tolerance <= 0 || 1 <= s.Tolerance {
+		panic("linsolve: invalid tolerance")
+	}
+	if s.MaxIterations <= 0 {
+		panic("linsolve: negative iteration limit")
+	}
+}
+
+// IterativeBlock finds an approximate solution of the system of n linear
+// equations with k right-hand sides
+//
+//	A*X = B,
+//
+// where A is a nonsingular square matrix of order n and B is an n×k matrix,
+// using the block iterative method m. Block methods build a single Krylov
+// subspace shared by all right-hand sides and usually need fewer iterations
+// than solving for each right-hand side separately. If a also implements
+// MulMatToer, it will be used for multiplying A with several vectors at once,
+// otherwise A will be multiplied with each vector using MulVecTo.
+//
+// settings provide means for adjusting parameters of the iterative process. See
+// the BlockSettings documentation for more information. IterativeBlock will not
+// modify the fields of BlockSettings. If settings is nil, default settings will
+// be used.
+//
+// IterativeBlock will panic if m is nil.
+func IterativeBlock(a MulVecToer, b *mat.Dense, m BlockMethod, settings *BlockSettings) (*BlockResult, error) {
+	if m == nil {
+		panic("linsolve: nil block method")
+	}
+	n, k := b.Dims()
+
+	var s BlockSettings
+	if settings != nil {
+		s = *settings
+	}
+	defaultBlockSettings(&s, n, k)
+	checkBlockSettings(&s, n, k)
+
+	bNorms := make([]float64, k)
+	colNorms(bNorms, b)
+	for j, v := range bNorms {
+		if v == 0 {
+			bNorms[j] = 1
+		}
+	}
+
+	op := newBlockOperator(a, s.PreconSolve, n)
+	var stats Stats
+	ctx := &BlockContext{
+		X:             mat.NewDense(n, k, nil),
+		ResidualNorms: make([]float64, k),
+		Src:           &mat.Dense{},
+		Dst:           &mat.Dense{},
+	}
+	rInit := mat.NewDense(n, k, nil)
+	if s.InitX != nil {
+		ctx.X.Copy(s.InitX)
+		op.computeResidual(rInit, b, ctx.X, &stats)
+	} else {
+		rInit.Copy(b)
+	}
+	colNorms(ctx.ResidualNorms, rInit)
+
+	var err error
+	if !blockConverged(ctx.ResidualNorms, bNorms, s.Tolerance) {
+		err = iterateBlock(op, b, bNorms, rInit, s, m, ctx, &stats)
+	} else {
+		s.Dst.Copy(ctx.X)
+	}
+
+	return &BlockResult{
+		X:             s.Dst,
+		ResidualNorms: ctx.ResidualNorms,
+		Stats:         stats,
+	}, err
+}
+
+func iterateBlock(op *blockOperator, b *mat.Dense, bNorms []float64, initRes *mat.Dense, settings BlockSettings, method BlockMethod, ctx *BlockContext, stats *Stats) error {
+	settings.Dst.Copy(ctx.X)
+
+	method.Init(ctx.X, initRes)
+	for {
+		o, err := method.Iterate(ctx)
+		if err != nil {
+			return err
+		}
+		switch o {
+		case NoOperation:
+		case MulVec, MulVec | Trans:
+			stats.MulVec++
+			op.mulMat(ctx.Dst, o&Trans == Trans, ctx.Src)
+		case PreconSolve, PreconSolve | Trans:
+			stats.PreconSolve++
+			err = op.preconSolve(ctx.Dst, o&Trans == Trans, ctx.Src)
+			if err != nil {
+				return err
+			}
+		case CheckResidualNorm:
+			ctx.Converged = blockConverged(ctx.ResidualNorms, bNorms, settings.Tolerance)
+		case ComputeResidual:
+			op.computeResidual(ctx.Dst, b, ctx.X, stats)
+		case MajorIteration:
+			stats.Iterations++
+			if ctx.Converged {
+				settings.Dst.Copy(ctx.X)
+				return nil
+			}
+			if stats.Iterations == settings.MaxIterations {
+				settings.Dst.Copy(ctx.X)
+				return ErrIterationLimit
+			}
+		default:
+			panic("linsolve: invalid operation")
+		}
+	}
+}
+
+// blockConverged returns whether the residual norm of each right-hand side is
+// less than tol times the norm of the right-hand side.
+func blockConverged(resNorms, bNorms []float64, tol float64) bool {
+	for j, v := range resNorms {
+		if !(v < tol*bNorms[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// colNorms stores the 2-norm of the columns of a into dst.
+func colNorms(dst []float64, a *mat.Dense) {
+	for j := range dst {
+		dst[j] = mat.Norm(a.ColView(j), 2)
+	}
+}
+
+// blockOperator performs the operations commanded by a BlockMethod with
+// operators that possibly act only on vectors.
+type blockOperator struct {
+	a      MulVecToer
+	precon func(dst *mat.VecDense, trans bool, rhs mat.Vector) error
+
+	// src and dst are used for applying vector
+	// operators to the columns of a matrix.
+	src, dst *mat.VecDense
+}
+
+func newBlockOperator(a MulVecToer, preconSolve func(dst *mat.VecDense, trans bool, rhs mat.Vector) error, n int) *blockOperator {
+	return &blockOperator{
+		a:      a,
+		precon: preconSolve,
+		src:    mat.NewVecDense(n, nil),
+		dst:    mat.NewVecDense(n, nil),
+	}
+}
+
+func (op *blockOperator) mulMat(dst *mat.Dense, trans bool, x *mat.Dense) {
+	if a, ok := op.a.(MulMatToer); ok {
+		a.MulMatTo(dst, trans, x)
+		return
+	}
+	_, c := x.Dims()
+	for j := 0; j < c; j++ {
+		op.src.CopyVec(x.ColView(j))
+		op.a.MulVecTo(op.dst, trans, op.src)
+		dst.SetCol(j, op.dst.RawVector().Data)
+	}
+}
+
+func (op *blockOperator) preconSolve(dst *mat.Dense, trans bool, rhs *mat.Dense) error {
+	_, c := rhs.Dims()
+	for j := 0; j < c; j++ {
+		op.src.CopyVec(rhs.ColView(j))
+		err := op.precon(op.dst, trans, op.src)
+		if err != nil {
+			return err
+		}
+		dst.SetCol(j, op.dst.RawVector().Data)
+	}
+	return nil
+}
+
+func (op *blockOperator) computeResidual(dst, b, x *mat.Dense, stats *Stats) {
+	stats.MulVec++
+	op.mulMat(dst, false, x)
+	dst.Sub(b, dst)
+}
@@ -0,0 +1,260 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// deflationTol is the relative tolerance below which orthonormalize considers a
+// vector linearly dependent on the other vectors. It is approximately the
+// square root of machine epsilon.
+const deflationTol = 1.0 / (1 << 26)
+
+// BlockCG implements the block Conjugate Gradient iterative method with
+// preconditioning for solving systems of linear equations with multiple
+// right-hand sides
+//
+//	A * X = B,
+//
+// where A is a symmetric positive definite matrix. The block of search
+// directions is shared by all right-hand sides, so in each iteration the
+// approximate solution for every right-hand side is improved using
+// information from all of them.
+//
+// The search directions are orthonormalized in every iteration and directions
+// that become linearly dependent, for example when some right-hand sides are
+// equal or when the residual of some right-hand sides is already much smaller
+// than the others, are removed from the block. This avoids the breakdown of the
+// original method of O'Leary.
+//
+// References:
+//   - O'Leary, D. (1980). The block conjugate gradient algorithm and related
+//     methods. Linear Algebra and its Applications, 29, 293-322.
+//     doi:10.1016/0024-3795(80)90247-5
+//   - Dubrulle, A. (2001). Retooling the method of block conjugate gradients.
+//     Electronic Transactions on Numerical Analysis, 12, 216-233.
+type BlockCG struct {
+	x mat.Dense
+	r mat.Dense
+	z mat.Dense
+
+	// pbuf holds the storage for the block of search
+	// directions p and ap holds A*p. The number of
+	// columns of p can be smaller than the number of
+	// right-hand sides.
+	pbuf mat.Dense
+	p    *mat.Dense
+	ap   mat.Dense
+
+	// pap holds the Cholesky factorization of pᵀ*A*p.
+	pap  mat.Cholesky
+	w    mat.Dense
+	tmp  mat.Dense
+	norm []float64
+
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the BlockMethod interface for more details.
+func (bcg *BlockCG) Init(x, residual *mat.Dense) {
+	n, k := x.Dims()
+	if r, c := residual.Dims(); r != n || c != k {
+		panic("blockcg: matrix dimension mismatch")
+	}
+
+	bcg.x.CloneFrom(x)
+	bcg.r.CloneFrom(residual)
+
+	reuseAs(&bcg.z, n, k)
+	reuseAs(&bcg.pbuf, n, k)
+	bcg.p = nil
+	if cap(bcg.norm) < k {
+		bcg.norm = make([]float64, k)
+	}
+	bcg.norm = bcg.norm[:k]
+
+	bcg.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the BlockMethod interface for more details.
+//
+// BlockCG will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+func (bcg *BlockCG) Iterate(ctx *BlockContext) (Operation, error) {
+	n, k := bcg.r.Dims()
+	switch bcg.resume {
+	case 1:
+		ctx.Src.CloneFrom(&bcg.r)
+		reuseAs(ctx.Dst, n, k)
+		bcg.resume = 2
+		// Compute Z_{i-1} = M^{-1} * R_{i-1}.
+		return PreconSolve, nil
+	case 2:
+		bcg.z.Copy(ctx.Dst)
+		if bcg.p != nil {
+			// Make Z_{i-1} A-orthogonal to the previous search
+			// directions:
+			//  Z_{i-1} -= P_{i-1} (P_{i-1}ᵀ A P_{i-1})^{-1} (A P_{i-1})ᵀ Z_{i-1}.
+			_, nc := bcg.p.Dims()
+			reuseAs(&bcg.w, nc, k)
+			bcg.w.Mul(bcg.ap.T(), &bcg.z)
+			// SolveTo returns only mat.Condition errors which
+			// are ignored.
+			_ = bcg.pap.SolveTo(&bcg.w, &bcg.w)
+			reuseAs(&bcg.tmp, n, k)
+			bcg.tmp.Mul(bcg.p, &bcg.w)
+			bcg.z.Sub(&bcg.z, &bcg.tmp)
+		}
+		// The new search directions P_i are an orthonormal
+		// basis of the span of Z_{i-1}.
+		bcg.p = orthonormalize(&bcg.pbuf, &bcg.z, nil, nil)
+		if bcg.p == nil {
+			// All search directions are linearly dependent,
+			// the residual cannot be reduced further.
+			colNorms(bcg.norm, &bcg.z)
+			bcg.resume = 0
+			return NoOperation, &BreakdownError{Value: floats.Max(bcg.norm), Tolerance: deflationTol}
+		}
+		_, nc := bcg.p.Dims()
+		ctx.Src.CloneFrom(bcg.p)
+		reuseAs(ctx.Dst, n, nc)
+		bcg.resume = 3
+		// Compute A * P_i.
+		return MulVec, nil
+	case 3:
+		bcg.ap.CloneFrom(ctx.Dst)
+		// Factorize P_iᵀ A P_i.
+		_, nc := bcg.p.Dims()
+		reuseAs(&bcg.w, nc, nc)
+		bcg.w.Mul(bcg.p.T(), &bcg.ap)
+		err := factorizeSym(&bcg.pap, &bcg.w)
+		if err != nil {
+			bcg.resume = 0
+			return NoOperation, err
+		}
+		// α_i = (P_iᵀ A P_i)^{-1} P_iᵀ R_{i-1}.
+		reuseAs(&bcg.w, nc, k)
+		bcg.w.Mul(bcg.p.T(), &bcg.r)
+		_ = bcg.pap.SolveTo(&bcg.w, &bcg.w)
+		reuseAs(&bcg.tmp, n, k)
+		bcg.tmp.Mul(bcg.p, &bcg.w)
+		bcg.x.Add(&bcg.x, &bcg.tmp) // X_i = X_{i-1} + P_i α_i
+		bcg.tmp.Mul(&bcg.ap, &bcg.w)
+		bcg.r.Sub(&bcg.r, &bcg.tmp) // R_i = R_{i-1} - A P_i α_i
+		colNorms(ctx.ResidualNorms, &bcg.r)
+		bcg.resume = 4
+		return CheckResidualNorm, nil
+	case 4:
+		ctx.X.Copy(&bcg.x)
+		if ctx.Converged {
+			bcg.resume = 0
+			return MajorIteration, nil
+		}
+		bcg.resume = 1
+		return MajorIteration, nil
+
+	default:
+		panic("blockcg: Init not called")
+	}
+}
+
+// orthonormalize stores into the leading columns of dst an orthonormal basis
+// of the span of the columns of z and returns the view of dst that holds the
+// basis. Columns of z that are linearly dependent on the preceding columns
+// within deflationTol are skipped. If no column remains, orthonormalize
+// returns nil. z is used as workspace.
+//
+// If az is not nil, it must hold the product of a matrix A with z and adst
+// will be set to the product of A with the returned basis.
+func orthonormalize(dst, z, adst, az *mat.Dense) *mat.Dense {
+	n, k := z.Dims()
+	var nc int
+	for j := 0; j < k; j++ {
+		v := z.ColView(j).(*mat.VecDense)
+		var av *mat.VecDense
+		if az != nil {
+			av = az.ColView(j).(*mat.VecDense)
+		}
+		norm0 := mat.Norm(v, 2)
+		if norm0 == 0 {
+			continue
+		}
+		// Orthogonalize twice against the accepted columns for
+		// numerical stability.
+		for pass := 0; pass < 2; pass++ {
+			for l := 0; l < nc; l++ {
+				q := dst.ColView(l)
+				c := mat.Dot(q, v)
+				v.AddScaledVec(v, -c, q)
+				if av != nil {
+					av.AddScaledVec(av, -c, adst.ColView(l))
+				}
+			}
+		}
+		norm := mat.Norm(v, 2)
+		if norm <= deflationTol*norm0 {
+			continue
+		}
+		v.ScaleVec(1/norm, v)
+		dst.ColView(nc).(*mat.VecDense).CopyVec(v)
+		if av != nil {
+			av.ScaleVec(1/norm, av)
+			adst.ColView(nc).(*mat.VecDense).CopyVec(av)
+		}
+		nc++
+	}
+	if nc == 0 {
+		return nil
+	}
+	return dst.Slice(0, n, 0, nc).(*mat.Dense)
+}
+
+// symPart returns the symmetric part (a+aᵀ)/2 of the square matrix a.
+func symPart(a *mat.Dense) *mat.SymDense {
+	n, _ := a.Dims()
+	sym := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			sym.SetSym(i, j, (a.At(i, j)+a.At(j, i))/2)
+		}
+	}
+	return sym
+}
+
+// factorizeSym computes the Cholesky factorization of the symmetric part of
+// the square matrix g which is a projection of A onto a subspace. If the
+// factorization fails, A is not positive definite and factorizeSym returns a
+// *BreakdownError.
+func factorizeSym(chol *mat.Cholesky, g *mat.Dense) error {
+	sym := symPart(g)
+	if chol.Factorize(sym) {
+		return nil
+	}
+	n := sym.SymmetricDim()
+	minDiag := math.Inf(1)
+	for i := 0; i < n; i++ {
+		minDiag = math.Min(minDiag, sym.At(i, i))
+	}
+	return &BreakdownError{Value: minDiag, Tolerance: 0}
+}
+
+// reuseAs resizes m to r×c reusing its storage if possible.
+func reuseAs(m *mat.Dense, r, c int) {
+	if !m.IsEmpty() {
+		if mr, mc := m.Dims(); mr == r && mc == c {
+			return
+		}
+		m.Reset()
+	}
+	m.ReuseAs(r, c)
+}
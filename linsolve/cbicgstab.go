@@ -0,0 +1,163 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// CBiCGStab implements the BiConjugate Gradient Stabilized method with
+// preconditioning for solving complex systems of linear equations
+//
+//	A * x = b,
+//
+// where A is a general nonsingular complex matrix. It is the complex
+// counterpart of BiCGStab and does not require multiplication with Aᴴ.
+//
+// References:
+//   - Barrett, R. et al. (1994). Section 2.3.8 BiConjugate Gradient Stabilized (Bi-CGSTAB).
+//     In Templates for the Solution of Linear Systems: Building Blocks
+//     for Iterative Methods (2nd ed.) (pp. 24-25). Philadelphia, PA: SIAM.
+//     Retrieved from http://www.netlib.org/templates/templates.pdf
+//   - van der Vorst, H. (1992). Bi-CGSTAB: A fast and smoothly converging
+//     variant of Bi-CG for the solution of nonsymmetric linear systems. SIAM J.
+//     Sci. Stat. Comput., 13(2), 631-644. doi:10.1137/0913035
+type CBiCGStab struct {
+	x     []complex128
+	r, rt []complex128
+	p     []complex128
+	phat  []complex128
+	shat  []complex128
+	t     []complex128
+	v     []complex128
+
+	rho, rhoPrev complex128
+	alpha        complex128
+	omega        complex128
+
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the CMethod interface for more details.
+func (b *CBiCGStab) Init(x, residual *mat.CDense) {
+	dim, _ := x.Dims()
+	if !isCVec(residual, dim) {
+		panic("cbicgstab: vector length mismatch")
+	}
+
+	b.x = cresize(b.x, dim)
+	for i := range b.x {
+		b.x[i] = x.At(i, 0)
+	}
+	b.r = cresize(b.r, dim)
+	for i := range b.r {
+		b.r[i] = residual.At(i, 0)
+	}
+	b.rt = cresize(b.rt, dim)
+	copy(b.rt, b.r)
+
+	b.p = cresize(b.p, dim)
+	b.phat = cresize(b.phat, dim)
+	b.shat = cresize(b.shat, dim)
+	b.t = cresize(b.t, dim)
+	b.v = cresize(b.v, dim)
+
+	b.rhoPrev = 1
+	b.alpha = 0
+	b.omega = 1
+
+	b.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the CMethod interface for more details.
+//
+// CBiCGStab will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+//	NoOperation
+func (b *CBiCGStab) Iterate(ctx *CContext) (Operation, error) {
+	switch b.resume {
+	case 1:
+		b.rho = cdotc(b.rt, b.r)
+		if cmplx.Abs(b.rho) < breakdownTol {
+			b.resume = 0
+			return NoOperation, &BreakdownError{cmplx.Abs(b.rho), breakdownTol}
+		}
+		// p_i = r_{i-1} + beta*(p_{i-1} - omega * v_{i-1})
+		beta := (b.rho / b.rhoPrev) * (b.alpha / b.omega)
+		caxpy(-b.omega, b.v, b.p)
+		cscal(beta, b.p)
+		caxpy(1, b.r, b.p)
+		// Solve M^{-1} * p_i.
+		copy(cvec(ctx.Src), b.p)
+		b.resume = 2
+		return PreconSolve, nil
+	case 2:
+		copy(b.phat, cvec(ctx.Dst))
+		// Compute A * \hat{p}_i.
+		copy(cvec(ctx.Src), b.phat)
+		b.resume = 3
+		return MulVec, nil
+	case 3:
+		copy(b.v, cvec(ctx.Dst))
+		rtv := cdotc(b.rt, b.v)
+		if rtv == 0 {
+			b.resume = 0
+			return NoOperation, &BreakdownError{}
+		}
+		b.alpha = b.rho / rtv
+		// Form the residual and X so that we can check for tolerance early.
+		caxpy(b.alpha, b.phat, b.x)
+		caxpy(-b.alpha, b.v, b.r)
+		ctx.ResidualNorm = cnrm2(b.r)
+		b.resume = 4
+		return CheckResidualNorm, nil
+	case 4:
+		if ctx.Converged {
+			copy(cvec(ctx.X), b.x)
+			b.resume = 0
+			return MajorIteration, nil
+		}
+		// Solve M^{-1} * r_i.
+		copy(cvec(ctx.Src), b.r)
+		b.resume = 5
+		return PreconSolve, nil
+	case 5:
+		copy(b.shat, cvec(ctx.Dst))
+		// Compute A * \hat{s}_i.
+		copy(cvec(ctx.Src), b.shat)
+		b.resume = 6
+		return MulVec, nil
+	case 6:
+		copy(b.t, cvec(ctx.Dst))
+		b.omega = cdotc(b.t, b.r) / cdotc(b.t, b.t)
+		caxpy(b.omega, b.shat, b.x)
+		caxpy(-b.omega, b.t, b.r)
+		ctx.ResidualNorm = cnrm2(b.r)
+		b.resume = 7
+		return CheckResidualNorm, nil
+	case 7:
+		copy(cvec(ctx.X), b.x)
+		if ctx.Converged {
+			b.resume = 0
+			return MajorIteration, nil
+		}
+		if cmplx.Abs(b.omega) < breakdownTol {
+			b.resume = 0
+			return NoOperation, &BreakdownError{cmplx.Abs(b.omega), breakdownTol}
+		}
+		b.rhoPrev = b.rho
+		b.resume = 1
+		return MajorIteration, nil
+
+	default:
+		panic("cbicgstab: Init not called")
+	}
+}
@@ -27,12 +27,26 @@ import (
 //   - Málek, J. and Strakoš, Z. (2015). Preconditioning and the Conjugate Gradient
 //     Method in the Context of Solving PDEs. Philadelphia, PA: SIAM.
 type CG struct {
+	// Workers is the number of goroutines that perform the vector
+	// operations. If Workers is zero, the vector operations are performed
+	// serially. Otherwise the vectors are split into blocks of fixed size,
+	// and the results do not depend on the value of Workers. See the
+	// package documentation for more information. Workers must not be
+	// negative.
+	Workers int
+
 	x mat.VecDense
 	r mat.VecDense
 	p mat.VecDense
 
 	rho, rhoPrev float64
 
+	// alphas and betas hold the coefficients of the iterations, they
+	// define the Lanczos tridiagonal matrix.
+	alphas, betas []float64
+
+	kern kernels
+
 	resume int
 }
 
@@ -43,6 +57,8 @@ func (cg *CG) Init(x, residual *mat.VecDense) {
 		panic("cg: vector length mismatch")
 	}
 
+	cg.kern.init(cg.Workers)
+
 	cg.x.CloneFromVec(x)
 	cg.r.CloneFromVec(residual)
 
@@ -50,6 +66,8 @@ func (cg *CG) Init(x, residual *mat.VecDense) {
 	cg.p.ReuseAsVec(dim)
 
 	cg.rhoPrev = 1
+	cg.alphas = cg.alphas[:0]
+	cg.betas = cg.betas[:0]
 
 	cg.resume = 1
 }
@@ -71,19 +89,24 @@ func (cg *CG) Iterate(ctx *Context) (Operation, error) {
 		return PreconSolve, nil
 	case 2:
 		z := ctx.Dst
-		cg.rho = mat.Dot(&cg.r, z)        // ρ_{i-1} = r_{i-1} · z_{i-1}
-		beta := cg.rho / cg.rhoPrev       // β_{i-1} = ρ_{i-1} / ρ_{i-2}
-		cg.p.AddScaledVec(z, beta, &cg.p) // p_i = z_{i-1} + β p_{i-1}
+		cg.rho = cg.kern.dot(&cg.r, z)           // ρ_{i-1} = r_{i-1} · z_{i-1}
+		beta := cg.rho / cg.rhoPrev              // β_{i-1} = ρ_{i-1} / ρ_{i-2}
+		cg.kern.addScaled(&cg.p, z, beta, &cg.p) // p_i = z_{i-1} + β p_{i-1}
+		if len(cg.alphas) > 0 {
+			cg.betas = append(cg.betas, beta)
+		}
 		ctx.Src.CopyVec(&cg.p)
 		cg.resume = 3
 		// Compute A * p_i.
 		return MulVec, nil
 	case 3:
 		ap := ctx.Dst
-		alpha := cg.rho / mat.Dot(&cg.p, ap)   // α_i = ρ_{i-1} / (p_i · A p_i)
-		cg.x.AddScaledVec(&cg.x, alpha, &cg.p) // x_i = x_{i-1} + α p_i
-		cg.r.AddScaledVec(&cg.r, -alpha, ap)   // r_i = r_{i-1} - α A p_i
-		ctx.ResidualNorm = mat.Norm(&cg.r, 2)
+		alpha := cg.rho / cg.kern.dot(&cg.p, ap) // α_i = ρ_{i-1} / (p_i · A p_i)
+		cg.alphas = append(cg.alphas, alpha)
+		cg.kern.addScaled(&cg.x, &cg.x, alpha, &cg.p) // x_i = x_{i-1} + α p_i
+		cg.kern.addScaled(&cg.r, &cg.r, -alpha, ap)   // r_i = r_{i-1} - α A p_i
+		ctx.ResidualNorm = cg.kern.norm(&cg.r)
+		ctx.EnergyDecrease = alpha * cg.rho // |e_{i-1}|_A^2 - |e_i|_A^2 = α_i ρ_{i-1}
 		cg.resume = 4
 		return CheckResidualNorm, nil
 	case 4:
@@ -0,0 +1,94 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+// CG32 implements the Conjugate Gradient iterative method with preconditioning
+// in single precision for solving systems of linear equations
+//
+//	A * x = b,
+//
+// where A is a symmetric positive definite matrix. It is the single precision
+// counterpart of CG.
+//
+// References:
+//   - Barrett, Richard et al. (1994). Section 2.3.1 Conjugate Gradient Method (CG).
+//     In Templates for the Solution of Linear Systems: Building Blocks for
+//     Iterative Methods (2nd ed.) (pp. 12-15). Philadelphia, PA: SIAM.
+//     Retrieved from http://www.netlib.org/templates/templates.pdf
+type CG32 struct {
+	x []float32
+	r []float32
+	p []float32
+
+	rho, rhoPrev float32
+
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the Method32 interface for more details.
+func (cg *CG32) Init(x, residual []float32) {
+	dim := len(x)
+	if len(residual) != dim {
+		panic("cg32: vector length mismatch")
+	}
+
+	cg.x = sresize(cg.x, dim)
+	copy(cg.x, x)
+	cg.r = sresize(cg.r, dim)
+	copy(cg.r, residual)
+	cg.p = sresize(cg.p, dim)
+
+	cg.rhoPrev = 1
+
+	cg.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method32 interface for more details.
+//
+// CG32 will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+func (cg *CG32) Iterate(ctx *Context32) (Operation, error) {
+	switch cg.resume {
+	case 1:
+		copy(ctx.Src, cg.r)
+		cg.resume = 2
+		// Compute z_{i-1} = M^{-1} * r_{i-1}.
+		return PreconSolve, nil
+	case 2:
+		z := ctx.Dst
+		cg.rho = sdot(cg.r, z)      // ρ_{i-1} = r_{i-1} · z_{i-1}
+		beta := cg.rho / cg.rhoPrev // β_{i-1} = ρ_{i-1} / ρ_{i-2}
+		sscal(beta, cg.p)
+		saxpy(1, z, cg.p) // p_i = z_{i-1} + β p_{i-1}
+		copy(ctx.Src, cg.p)
+		cg.resume = 3
+		// Compute A * p_i.
+		return MulVec, nil
+	case 3:
+		ap := ctx.Dst
+		alpha := cg.rho / sdot(cg.p, ap) // α_i = ρ_{i-1} / (p_i · A p_i)
+		saxpy(alpha, cg.p, cg.x)         // x_i = x_{i-1} + α p_i
+		saxpy(-alpha, ap, cg.r)          // r_i = r_{i-1} - α A p_i
+		ctx.ResidualNorm = snrm2(cg.r)
+		cg.resume = 4
+		return CheckResidualNorm, nil
+	case 4:
+		copy(ctx.X, cg.x)
+		if ctx.Converged {
+			cg.resume = 0
+			return MajorIteration, nil
+		}
+		cg.rhoPrev = cg.rho
+		cg.resume = 1
+		return MajorIteration, nil
+
+	default:
+		panic("cg32: Init not called")
+	}
+}
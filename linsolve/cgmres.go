@@ -0,0 +1,275 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// CGMRES implements the Generalized Minimum Residual method with the modified
+// Gram-Schmidt orthogonalization for solving complex systems of linear
+// equations
+//
+//	A * x = b,
+//
+// where A is a general nonsingular complex matrix. It is the complex
+// counterpart of GMRES and uses restarts in the same way to limit the memory
+// requirements. CGMRES does not need the multiplication with Aᴴ.
+//
+// References:
+//   - Saad, Y., and Schultz, M. (1986). GMRES: A generalized minimal residual
+//     algorithm for solving nonsymmetric linear systems. SIAM J. Sci. Stat.
+//     Comput., 7(3), 856. doi:10.6028/jres.049.044
+//     Retrieved from https://web.stanford.edu/class/cme324/saad-schultz.pdf
+type CGMRES struct {
+	// Restart is the restart parameter which limits the computation and
+	// storage costs. It must hold that
+	//  1 <= Restart <= n
+	// where n is the dimension of the problem. If Restart is 0, n will be
+	// used instead.
+	Restart int
+
+	// m is the used value of Restart.
+	m int
+	// vbuf holds the storage for v.
+	vbuf []complex128
+	// v holds m+1 vectors which form an orthonormal basis of the
+	// Krylov subspace.
+	v [][]complex128
+	// hbuf holds the storage for h.
+	hbuf []complex128
+	// h holds the m columns of the (m+1)×m upper Hessenberg
+	// matrix H.
+	h [][]complex128
+	// givs holds Givens rotations that are used to reduce H to upper
+	// triangular form.
+	givs []cgivens
+
+	x []complex128
+	y []complex128
+	s []complex128
+
+	k      int // Loop variable for inner iterations.
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the CMethod interface for more details.
+func (g *CGMRES) Init(x, residual *mat.CDense) {
+	dim, _ := x.Dims()
+	if !isCVec(residual, dim) {
+		panic("cgmres: vector length mismatch")
+	}
+
+	g.m = g.Restart
+	if g.m == 0 {
+		g.m = dim
+	}
+	if g.m <= 0 || dim < g.m {
+		panic("cgmres: invalid value of Restart")
+	}
+
+	g.vbuf = cresize(g.vbuf, dim*(g.m+1))
+	g.v = g.v[:0]
+	for j := 0; j <= g.m; j++ {
+		g.v = append(g.v, g.vbuf[j*dim:(j+1)*dim])
+	}
+	// Store the residual in the first vector of V.
+	copy(g.v[0], cvec(residual))
+
+	g.hbuf = cresize(g.hbuf, (g.m+1)*g.m)
+	g.h = g.h[:0]
+	for j := 0; j < g.m; j++ {
+		g.h = append(g.h, g.hbuf[j*(g.m+1):(j+1)*(g.m+1)])
+	}
+
+	if cap(g.givs) < g.m {
+		g.givs = make([]cgivens, g.m)
+	} else {
+		g.givs = g.givs[:g.m]
+		clear(g.givs)
+	}
+
+	g.x = cresize(g.x, dim)
+	for i := range g.x {
+		g.x[i] = x.At(i, 0)
+	}
+	g.y = cresize(g.y, g.m+1)
+	g.s = cresize(g.s, g.m+1)
+
+	g.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the CMethod interface for more details.
+//
+// CGMRES will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+//	NoOperation
+func (g *CGMRES) Iterate(ctx *CContext) (Operation, error) {
+	switch g.resume {
+	case 1:
+		// The initial residual is in the first vector of V.
+		copy(cvec(ctx.Src), g.v[0])
+		g.resume = 2
+		// Solve M^{-1} * r_0.
+		return PreconSolve, nil
+	case 2:
+		// v_0 = M^{-1} * r_0
+		v0 := g.v[0]
+		copy(v0, cvec(ctx.Dst))
+		// Normalize v_0.
+		norm := cnrm2(v0)
+		cscal(complex(1/norm, 0), v0)
+		// Initialize s to the elementary vector e_1 scaled by norm.
+		clear(g.s)
+		g.s[0] = complex(norm, 0)
+
+		// Begin the inner for-loop for k going from 0 to m-1.
+		g.k = 0
+		fallthrough
+	case 3:
+		copy(cvec(ctx.Src), g.v[g.k])
+		g.resume = 4
+		// Compute A * v_k.
+		return MulVec, nil
+	case 4:
+		ctx.Src.Copy(ctx.Dst)
+		g.resume = 5
+		// Solve M^{-1} * (A * v_k).
+		return PreconSolve, nil
+	case 5:
+		// v_{k+1} = M^{-1} * (A * v_k)
+		vk1 := g.v[g.k+1]
+		copy(vk1, cvec(ctx.Dst))
+		// Construct the k-th column of the upper Hessenberg matrix H
+		// using the modified Gram-Schmidt process to make v_{k+1}
+		// orthonormal to the first k+1 vectors of V.
+		cmodifiedGS(g.k, g.h[g.k], g.v, vk1)
+		// Reduce H back to upper triangular form and update the vector s.
+		cqr(g.k, g.givs, g.h[g.k], g.s)
+		// Check the approximate residual norm.
+		ctx.ResidualNorm = cmplx.Abs(g.s[g.k+1])
+		g.resume = 6
+		return CheckResidualNorm, nil
+	case 6:
+		g.k++
+		if g.k < g.m && !ctx.Converged {
+			// Continue the inner for-loop.
+			g.resume = 3
+			return NoOperation, nil
+		}
+		// Either restarting or converged, we have to update the solution.
+		// Solve the upper triangular system H*y=s.
+		g.solveLeastSquares()
+		// Compute x as a linear combination of vectors of V.
+		for j, yj := range g.y[:g.k] {
+			caxpy(yj, g.v[j], g.x)
+		}
+		copy(cvec(ctx.X), g.x)
+		if ctx.Converged {
+			g.resume = 0
+			return MajorIteration, nil
+		}
+		// We are restarting, so we have to also compute the residual.
+		g.resume = 7
+		return ComputeResidual, nil
+	case 7:
+		// Store the residual again in the first vector of V.
+		copy(g.v[0], cvec(ctx.Dst))
+		g.resume = 1
+		return MajorIteration, nil
+
+	default:
+		panic("cgmres: Init not called")
+	}
+}
+
+// solveLeastSquares solves the k×k upper triangular linear system
+//
+//	H * y = s
+func (g *CGMRES) solveLeastSquares() {
+	k := g.k
+	copy(g.y, g.s[:k])
+	for i := k - 1; i >= 0; i-- {
+		yi := g.y[i]
+		for j := i + 1; j < k; j++ {
+			yi -= g.h[j][i] * g.y[j]
+		}
+		g.y[i] = yi / g.h[i][i]
+	}
+}
+
+// cmodifiedGS orthonormalizes the vector w with respect to the first k+1
+// vectors of V using the modified Gram-Schmidt algorithm, and stores the
+// computed coefficients in hk.
+func cmodifiedGS(k int, hk []complex128, v [][]complex128, w []complex128) {
+	for j := 0; j <= k; j++ {
+		hkj := cdotc(v[j], w)
+		hk[j] = hkj          // H[j,k] = v_jᴴ * w
+		caxpy(-hkj, v[j], w) // w -= H[j,k] * v_j
+	}
+	norm := cnrm2(w)
+	hk[k+1] = complex(norm, 0)   // H[k+1,k] = |w|
+	cscal(complex(1/norm, 0), w) // Normalize w.
+}
+
+// cqr applies previous Givens rotations to the k-th column of H, computes the
+// next Givens rotation to zero out H[k+1,k] and applies it also to the vector
+// s.
+func cqr(k int, givs []cgivens, hk, s []complex128) {
+	// Apply previous Givens rotations to the k-th column of H.
+	for i, giv := range givs[:k] {
+		hk[i], hk[i+1] = giv.apply(hk[i], hk[i+1])
+	}
+
+	// Compute the k-th Givens rotation that zeros H[k+1,k] and
+	// apply it to (H[k,k], H[k+1,k]).
+	givs[k], hk[k] = newCGivens(hk[k], hk[k+1])
+	hk[k+1] = 0
+
+	// Apply the k-th Givens rotation to (s[k], s[k+1]).
+	s[k], s[k+1] = givs[k].apply(s[k], s[k+1])
+}
+
+// cgivens is a complex Givens rotation
+//
+//	[  c        s ]
+//	[ -conj(s)  c ]
+//
+// with real c.
+type cgivens struct {
+	c float64
+	s complex128
+}
+
+// newCGivens returns the Givens rotation that zeros b in the vector (a, b) and
+// the resulting first element.
+func newCGivens(a, b complex128) (cgivens, complex128) {
+	if b == 0 {
+		return cgivens{c: 1}, a
+	}
+	absB := cmplx.Abs(b)
+	if a == 0 {
+		return cgivens{s: cmplx.Conj(b) / complex(absB, 0)}, complex(absB, 0)
+	}
+	absA := cmplx.Abs(a)
+	norm := math.Hypot(absA, absB)
+	sign := a / complex(absA, 0)
+	return cgivens{
+		c: absA / norm,
+		s: sign * cmplx.Conj(b) / complex(norm, 0),
+	}, sign * complex(norm, 0)
+}
+
+func (giv cgivens) apply(x, y complex128) (complex128, complex128) {
+	c := complex(giv.c, 0)
+	return c*x + giv.s*y, c*y - cmplx.Conj(giv.s)*x
+}
@@ -0,0 +1,133 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// Chebyshev implements the Chebyshev iterative method with preconditioning for
+// solving systems of linear equations
+//
+//	A * x = b,
+//
+// where the eigenvalues of the preconditioned matrix M^{-1}*A are real and lie
+// in the interval [MinEig, MaxEig] with MinEig > 0. This is the case for
+// example if A and M are symmetric positive definite.
+//
+// Chebyshev iteration chooses the iterates so that the residual polynomial is
+// the scaled and shifted Chebyshev polynomial that is minimal on the interval.
+// Unlike CG, it does not compute any inner products except for the residual
+// norm in the convergence check, which makes it attractive for highly parallel
+// computations and as a smoother in multigrid methods. Its convergence
+// depends on the quality of the eigenvalue bounds. If MinEig is overestimated,
+// the convergence is slower, if MaxEig is underestimated, the iteration can
+// diverge. Estimates can be obtained cheaply from a few iterations of CG or
+// GMRES, see EigenvalueEstimator, and it is advisable to increase the estimate
+// of the largest eigenvalue by a few percent.
+//
+// References:
+//   - Barrett, R. et al. (1994). Section 2.3.9 Chebyshev Iteration. In
+//     Templates for the Solution of Linear Systems: Building Blocks for
+//     Iterative Methods (2nd ed.) (pp. 25-27). Philadelphia, PA: SIAM.
+//     Retrieved from http://www.netlib.org/templates/templates.pdf
+//   - Saad, Y. (2003). Section 12.3 Polynomial Preconditioners. In Iterative
+//     Methods for Sparse Linear Systems (2nd ed.) (pp. 397-406). Philadelphia,
+//     PA: SIAM.
+type Chebyshev struct {
+	// MinEig and MaxEig are the bounds of the interval that contains the
+	// eigenvalues of M^{-1}*A. They must satisfy 0 < MinEig < MaxEig.
+	MinEig, MaxEig float64
+
+	x mat.VecDense
+	r mat.VecDense
+	d mat.VecDense
+
+	// theta and delta are the center and the half-width of the
+	// interval, sigma is theta/delta.
+	theta, delta, sigma float64
+	rho                 float64
+
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (c *Chebyshev) Init(x, residual *mat.VecDense) {
+	dim := x.Len()
+	if residual.Len() != dim {
+		panic("chebyshev: vector length mismatch")
+	}
+	if c.MinEig <= 0 || c.MaxEig <= c.MinEig {
+		panic("chebyshev: invalid eigenvalue bounds")
+	}
+
+	c.x.CloneFromVec(x)
+	c.r.CloneFromVec(residual)
+	c.d.Reset()
+	c.d.ReuseAsVec(dim)
+
+	c.theta = (c.MaxEig + c.MinEig) / 2
+	c.delta = (c.MaxEig - c.MinEig) / 2
+	c.sigma = c.theta / c.delta
+
+	c.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// Chebyshev will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+func (c *Chebyshev) Iterate(ctx *Context) (Operation, error) {
+	switch c.resume {
+	case 1:
+		ctx.Src.CopyVec(&c.r)
+		c.resume = 2
+		// Compute z_0 = M^{-1} * r_0.
+		return PreconSolve, nil
+	case 2:
+		// d_0 = z_0 / θ
+		c.d.ScaleVec(1/c.theta, ctx.Dst)
+		c.rho = 1 / c.sigma
+		ctx.Src.CopyVec(&c.d)
+		c.resume = 3
+		// Compute A * d_0.
+		return MulVec, nil
+	case 3:
+		c.x.AddVec(&c.x, &c.d)              // x_{k+1} = x_k + d_k
+		c.r.AddScaledVec(&c.r, -1, ctx.Dst) // r_{k+1} = r_k - A d_k
+		ctx.ResidualNorm = mat.Norm(&c.r, 2)
+		c.resume = 4
+		return CheckResidualNorm, nil
+	case 4:
+		ctx.X.CopyVec(&c.x)
+		if ctx.Converged {
+			c.resume = 0
+			return MajorIteration, nil
+		}
+		c.resume = 5
+		return MajorIteration, nil
+	case 5:
+		ctx.Src.CopyVec(&c.r)
+		c.resume = 6
+		// Compute z_{k+1} = M^{-1} * r_{k+1}.
+		return PreconSolve, nil
+	case 6:
+		rho := 1 / (2*c.sigma - c.rho)                 // ρ_{k+1} = 1 / (2σ - ρ_k)
+		c.d.ScaleVec(rho*c.rho, &c.d)                  // d_{k+1} = ρ_{k+1} ρ_k d_k
+		c.d.AddScaledVec(&c.d, 2*rho/c.delta, ctx.Dst) //         + 2ρ_{k+1}/δ z_{k+1}
+		c.rho = rho
+		ctx.Src.CopyVec(&c.d)
+		c.resume = 3
+		// Compute A * d_{k+1}.
+		return MulVec, nil
+
+	default:
+		panic("chebyshev: Init not called")
+	}
+}
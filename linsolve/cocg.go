@@ -0,0 +1,117 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// COCG implements the Conjugate Orthogonal Conjugate Gradient iterative method
+// with preconditioning for solving complex systems of linear equations
+//
+//	A * x = b,
+//
+// where A is a complex symmetric (A = Aᵀ, but in general A ≠ Aᴴ) nonsingular
+// matrix. Such matrices arise for example in frequency-domain electromagnetics
+// and acoustics. COCG is CG in which the Hermitian inner product is replaced by
+// the bilinear form xᵀ*y. It requires minimal memory storage, however, its
+// convergence is not guaranteed and the method can break down. The
+// preconditioner must be complex symmetric as well.
+//
+// References:
+//   - van der Vorst, H., and Melissen, J. (1990). A Petrov-Galerkin type method
+//     for solving Ax=b, where A is symmetric complex. IEEE Transactions on
+//     Magnetics, 26(2), 706-708. doi:10.1109/20.106415
+type COCG struct {
+	x []complex128
+	r []complex128
+	p []complex128
+
+	rho, rhoPrev complex128
+
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the CMethod interface for more details.
+func (cg *COCG) Init(x, residual *mat.CDense) {
+	dim, _ := x.Dims()
+	if !isCVec(residual, dim) {
+		panic("cocg: vector length mismatch")
+	}
+
+	cg.x = cresize(cg.x, dim)
+	for i := range cg.x {
+		cg.x[i] = x.At(i, 0)
+	}
+	cg.r = cresize(cg.r, dim)
+	for i := range cg.r {
+		cg.r[i] = residual.At(i, 0)
+	}
+	cg.p = cresize(cg.p, dim)
+
+	cg.rhoPrev = 1
+
+	cg.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the CMethod interface for more details.
+//
+// COCG will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+//	NoOperation
+func (cg *COCG) Iterate(ctx *CContext) (Operation, error) {
+	switch cg.resume {
+	case 1:
+		copy(cvec(ctx.Src), cg.r)
+		cg.resume = 2
+		// Compute z_{i-1} = M^{-1} * r_{i-1}.
+		return PreconSolve, nil
+	case 2:
+		z := cvec(ctx.Dst)
+		cg.rho = cdotu(cg.r, z) // ρ_{i-1} = r_{i-1}ᵀ z_{i-1}
+		if cmplx.Abs(cg.rho) < breakdownTol {
+			cg.resume = 0
+			return NoOperation, &BreakdownError{cmplx.Abs(cg.rho), breakdownTol}
+		}
+		beta := cg.rho / cg.rhoPrev // β_{i-1} = ρ_{i-1} / ρ_{i-2}
+		cscal(beta, cg.p)
+		caxpy(1, z, cg.p) // p_i = z_{i-1} + β p_{i-1}
+		copy(cvec(ctx.Src), cg.p)
+		cg.resume = 3
+		// Compute A * p_i.
+		return MulVec, nil
+	case 3:
+		ap := cvec(ctx.Dst)
+		pap := cdotu(cg.p, ap)
+		if pap == 0 {
+			cg.resume = 0
+			return NoOperation, &BreakdownError{}
+		}
+		alpha := cg.rho / pap    // α_i = ρ_{i-1} / (p_iᵀ A p_i)
+		caxpy(alpha, cg.p, cg.x) // x_i = x_{i-1} + α p_i
+		caxpy(-alpha, ap, cg.r)  // r_i = r_{i-1} - α A p_i
+		ctx.ResidualNorm = cnrm2(cg.r)
+		cg.resume = 4
+		return CheckResidualNorm, nil
+	case 4:
+		copy(cvec(ctx.X), cg.x)
+		if ctx.Converged {
+			cg.resume = 0
+			return MajorIteration, nil
+		}
+		cg.rhoPrev = cg.rho
+		cg.resume = 1
+		return MajorIteration, nil
+
+	default:
+		panic("cocg: Init not called")
+	}
+}
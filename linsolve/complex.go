@@ -0,0 +1,381 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/mat"
+)
+
+// CMulVecToer represents a square complex matrix A by means of a matrix-vector
+// multiplication.
+type CMulVecToer interface {
+	// MulVecTo computes A*x or Aᴴ*x and stores the result into dst.
+	// x and dst are n×1 column vectors.
+	MulVecTo(dst *mat.CDense, trans bool, x mat.CMatrix)
+}
+
+// CMethod is an iterative method that produces a sequence of complex vectors
+// that converge to the solution of the system of linear equations
+//
+//	A * x = b,
+//
+// where A is non-singular n×n complex matrix, and x and b are complex vectors
+// of dimension n. Vectors are represented by n×1 column matrices.
+//
+// CMethod uses the same reverse-communication interface as Method. When Trans
+// is combined with MulVec or PreconSolve, the operation must be performed with
+// the conjugate transpose. See the documentation for Method, Operation and
+// CContext for more information.
+type CMethod interface {
+	// Init initializes the method for solving an n×n
+	// linear system with an initial estimate x and
+	// the corresponding residual vector.
+	//
+	// CMethod will not retain x or residual.
+	Init(x, residual *mat.CDense)
+
+	// Iterate performs a step in converging to the
+	// solution of a linear system.
+	//
+	// Iterate retrieves data from CContext, updates it,
+	// and returns the next operation. The caller must
+	// perform the Operation using data in CContext, and
+	// depending on the state call Iterate again.
+	Iterate(*CContext) (Operation, error)
+}
+
+// CContext mediates the communication between the CMethod and the caller. The
+// caller must not modify CContext apart from the commanded Operations.
+type CContext struct {
+	// X will be set by CMethod to the current approximate
+	// solution when it commands ComputeResidual and MajorIteration.
+	X *mat.CDense
+
+	// ResidualNorm is (an estimate of) a norm of
+	// the residual. CMethod will set it to the current
+	// value when it commands CheckResidualNorm.
+	ResidualNorm float64
+
+	// Converged indicates to CMethod whether ResidualNorm
+	// satisfies a stopping criterion as a result of
+	// CheckResidualNorm operation.
+	Converged bool
+
+	// Src and Dst are the source and destination vectors
+	// for various Operations. Src will be set by CMethod
+	// and the caller must store the result in Dst. See
+	// the Operation documentation for more information.
+	Src, Dst *mat.CDense
+}
+
+// NewCContext returns a new CContext for work on problems of dimension n.
+// NewCContext will panic if n is not positive.
+func NewCContext(n int) *CContext {
+	if n <= 0 {
+		panic("linsolve: context size is not positive")
+	}
+	return &CContext{
+		X:   mat.NewCDense(n, 1, nil),
+		Src: mat.NewCDense(n, 1, nil),
+		Dst: mat.NewCDense(n, 1, nil),
+	}
+}
+
+// Reset reinitializes the CContext for work on problems of dimension n.
+// Reset will panic if n is not positive.
+func (ctx *CContext) Reset(n int) {
+	if n <= 0 {
+		panic("linsolve: dimension not positive")
+	}
+	ctx.X.Reset()
+	ctx.X.ReuseAs(n, 1)
+	ctx.Src.Reset()
+	ctx.Src.ReuseAs(n, 1)
+	ctx.Dst.Reset()
+	ctx.Dst.ReuseAs(n, 1)
+}
+
+// CSettings holds settings for solving a complex linear system.
+type CSettings struct {
+	// InitX holds the initial guess. If it is nil, the zero vector
+	// will be used, otherwise it must be an n×1 column vector where n
+	// is the dimension of the system.
+	InitX *mat.CDense
+
+	// Dst, if not nil, will be used for storing the approximate solution,
+	// otherwise a new vector will be allocated. In both cases the vector will
+	// also be returned in CResult. If Dst is not empty, it must be an n×1
+	// column vector.
+	Dst *mat.CDense
+
+	// Tolerance specifies error tolerance for the final (approximate)
+	// solution produced by the iterative method. The iteration will be
+	// stopped when
+	//  |r_i| < Tolerance * |b|
+	// where r_i is the residual at i-th iteration.
+	//
+	// If Tolerance is zero, a default value of 1e-8 will be used, otherwise
+	// it must be positive and less than 1.
+	Tolerance float64
+
+	// MaxIterations is the limit on the number of iterations. If it is
+	// zero, a default value of four times the dimension of the system will
+	// be used.
+	MaxIterations int
+
+	// PreconSolve describes a preconditioner solve that stores into dst the
+	// solution of the system
+	//  M  * dst = rhs, or
+	//  Mᴴ * dst = rhs,
+	// where M is the preconditioning matrix. If PreconSolve is nil, no
+	// preconditioning will be used (M is the identity).
+	PreconSolve func(dst *mat.CDense, trans bool, rhs mat.CMatrix) error
+
+	// Work context can be provided to reduce memory allocation when solving
+	// multiple linear systems. If Work is not nil, its fields must be n×1
+	// column vectors.
+	Work *CContext
+}
+
+// CResult holds the result of an iterative solve of a complex linear system.
+type CResult struct {
+	// X is the approximate solution.
+	X *mat.CDense
+
+	// ResidualNorm is an approximation to the norm of the final residual.
+	ResidualNorm float64
+
+	// Stats holds statistics about the iterative solve.
+	Stats Stats
+}
+
+func defaultCSettings(s *CSettings, dim int) {
+	if s.Dst == nil {
+		s.Dst = mat.NewCDense(dim, 1, nil)
+	} else if s.Dst.IsEmpty() {
+		s.Dst.ReuseAs(dim, 1)
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = defaultTolerance
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 4 * dim
+	}
+	if s.PreconSolve == nil {
+		s.PreconSolve = NoCPreconditioner
+	}
+	if s.Work == nil {
+		s.Work = NewCContext(dim)
+	}
+}
+
+func checkCSettings(s *CSettings, dim int) {
+	if s.InitX != nil && !isCVec(s.InitX, dim) {
+		panic("linsolve: mismatched length of initial guess")
+	}
+	if !isCVec(s.Dst, dim) {
+		panic("linsolve: mismatched destination length")
+	}
+	if s.Tolerance <= 0 || 1 <= s.Tolerance {
+		panic("linsolve: invalid tolerance")
+	}
+	if s.MaxIterations <= 0 {
+		panic("linsolve: negative iteration limit")
+	}
+	w := s.Work
+	if !isContiguousCVec(w.X, dim) || !isContiguousCVec(w.Src, dim) || !isContiguousCVec(w.Dst, dim) {
+		panic("linsolve: mismatched work context length")
+	}
+}
+
+// CIterative finds an approximate solution of the system of n linear equations
+//
+//	A*x = b,
+//
+// where A is a nonsingular complex square matrix of order n and b is the
+// right-hand side n×1 column vector, using an iterative method m. If m is nil,
+// default CGMRES will be used.
+//
+// settings provide means for adjusting parameters of the iterative process. See
+// the CSettings documentation for more information. CIterative will not modify
+// the fields of CSettings. If settings is nil, default settings will be used.
+//
+// CIterative will panic if b is not a column vector.
+func CIterative(a CMulVecToer, b *mat.CDense, m CMethod, settings *CSettings) (*CResult, error) {
+	n, c := b.Dims()
+	if c != 1 {
+		panic("linsolve: right-hand side is not a column vector")
+	}
+
+	var s CSettings
+	if settings != nil {
+		s = *settings
+	}
+	defaultCSettings(&s, n)
+	checkCSettings(&s, n)
+
+	var stats Stats
+	work := s.Work
+	// Keep a contiguous copy of b.
+	bc := mat.NewCDense(n, 1, nil)
+	bc.Copy(b)
+	rInit := mat.NewCDense(n, 1, nil)
+	if s.InitX != nil {
+		// Initial x is provided.
+		work.X.Copy(s.InitX)
+		computeCResidual(rInit, a, bc, work.X, &stats)
+	} else {
+		// Initial x is the zero vector.
+		work.X.Zero()
+		// Residual b-A*x is then equal to b.
+		rInit.Copy(bc)
+	}
+
+	if m == nil {
+		m = &CGMRES{}
+	}
+
+	var err error
+	work.ResidualNorm = cnrm2(cvec(rInit))
+	if work.ResidualNorm >= s.Tolerance {
+		err = citerate(a, bc, rInit, s, m, &stats)
+	} else {
+		s.Dst.Copy(work.X)
+	}
+
+	return &CResult{
+		X:            s.Dst,
+		ResidualNorm: work.ResidualNorm,
+		Stats:        stats,
+	}, err
+}
+
+func citerate(a CMulVecToer, b, initRes *mat.CDense, settings CSettings, method CMethod, stats *Stats) error {
+	bNorm := cnrm2(cvec(b))
+	if bNorm == 0 {
+		bNorm = 1
+	}
+
+	work := settings.Work
+	settings.Dst.Copy(work.X)
+
+	method.Init(work.X, initRes)
+	for {
+		op, err := method.Iterate(work)
+		if err != nil {
+			return err
+		}
+		switch op {
+		case NoOperation:
+		case MulVec, MulVec | Trans:
+			stats.MulVec++
+			a.MulVecTo(work.Dst, op&Trans == Trans, work.Src)
+		case PreconSolve, PreconSolve | Trans:
+			stats.PreconSolve++
+			err = settings.PreconSolve(work.Dst, op&Trans == Trans, work.Src)
+			if err != nil {
+				return err
+			}
+		case CheckResidualNorm:
+			work.Converged = work.ResidualNorm < settings.Tolerance*bNorm
+		case ComputeResidual:
+			computeCResidual(work.Dst, a, b, work.X, stats)
+		case MajorIteration:
+			stats.Iterations++
+			if work.Converged {
+				settings.Dst.Copy(work.X)
+				return nil
+			}
+			if stats.Iterations == settings.MaxIterations {
+				settings.Dst.Copy(work.X)
+				return ErrIterationLimit
+			}
+		default:
+			panic("linsolve: invalid operation")
+		}
+	}
+}
+
+// NoCPreconditioner implements the identity preconditioner for complex
+// systems.
+func NoCPreconditioner(dst *mat.CDense, trans bool, rhs mat.CMatrix) error {
+	if r, _ := rhs.Dims(); !isCVec(dst, r) {
+		panic("linsolve: mismatched vector length")
+	}
+	dst.Copy(rhs)
+	return nil
+}
+
+func computeCResidual(dst *mat.CDense, a CMulVecToer, b, x *mat.CDense, stats *Stats) {
+	stats.MulVec++
+	a.MulVecTo(dst, false, x)
+	d := cvec(dst)
+	for i, v := range cvec(b) {
+		d[i] = v - d[i]
+	}
+}
+
+// isCVec returns whether v is an n×1 column vector.
+func isCVec(v *mat.CDense, n int) bool {
+	r, c := v.Dims()
+	return r == n && c == 1
+}
+
+// isContiguousCVec returns whether v is an n×1 column vector with contiguous
+// storage.
+func isContiguousCVec(v *mat.CDense, n int) bool {
+	return isCVec(v, n) && (n == 1 || v.RawCMatrix().Stride == 1)
+}
+
+// cvec returns the elements of the column vector v which must have contiguous
+// storage.
+func cvec(v *mat.CDense) []complex128 {
+	raw := v.RawCMatrix()
+	if raw.Cols != 1 || (raw.Rows > 1 && raw.Stride != 1) {
+		panic("linsolve: not a contiguous column vector")
+	}
+	return raw.Data[:raw.Rows]
+}
+
+func cblasVec(x []complex128) cblas128.Vector {
+	return cblas128.Vector{N: len(x), Inc: 1, Data: x}
+}
+
+// cdotc returns xᴴ * y.
+func cdotc(x, y []complex128) complex128 {
+	return cblas128.Dotc(cblasVec(x), cblasVec(y))
+}
+
+// cdotu returns xᵀ * y.
+func cdotu(x, y []complex128) complex128 {
+	return cblas128.Dotu(cblasVec(x), cblasVec(y))
+}
+
+// cnrm2 returns the Euclidean norm of x.
+func cnrm2(x []complex128) float64 {
+	return cblas128.Nrm2(cblasVec(x))
+}
+
+// caxpy computes y += alpha * x.
+func caxpy(alpha complex128, x, y []complex128) {
+	cblas128.Axpy(alpha, cblasVec(x), cblasVec(y))
+}
+
+// cresize returns s resized to length n reusing its storage if possible. The
+// elements of the returned slice are zero.
+func cresize(s []complex128, n int) []complex128 {
+	if cap(s) < n {
+		return make([]complex128, n)
+	}
+	s = s[:n]
+	clear(s)
+	return s
+}
+
+// cscal computes x *= alpha.
+func cscal(alpha complex128, x []complex128) {
+	cblas128.Scal(alpha, cblasVec(x))
+}
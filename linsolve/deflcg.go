@@ -0,0 +1,349 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// DeflatedCG implements the deflated Conjugate Gradient iterative method with
+// preconditioning for solving sequences of systems of linear equations
+//
+//	A_i * x_i = b_i,
+//
+// where A_i are symmetric positive definite matrices that change only slightly
+// from one system to the next, as is common in Newton and time-stepping loops.
+//
+// DeflatedCG keeps the search directions A-orthogonal to a deflation subspace
+// that approximates the eigenvectors of the matrix corresponding to its
+// smallest eigenvalues. These eigenvalues usually slow down the convergence of
+// CG the most and removing them from the spectrum seen by CG reduces the number
+// of iterations.
+//
+// The deflation subspace is recycled across solves. During each solve,
+// DeflatedCG maintains a window of vectors that initially holds the basis of
+// the deflation subspace and to which the search directions are appended.
+// Whenever the window is full, it is reduced to the Ritz vectors corresponding
+// to the smallest Ritz values of A with respect to the window. When DeflatedCG
+// is initialized for the next system, the deflation subspace is replaced by
+// the Ritz vectors computed from the final window. The first solve performed
+// with a DeflatedCG value is therefore a plain CG solve. The same DeflatedCG
+// value must be passed to consecutive calls to Iterative to benefit from
+// recycling. The products of the new matrix with the basis of the deflation
+// subspace are computed at the beginning of each solve.
+//
+// References:
+//   - Saad, Y., Yeung, M., Erhel, J., and Guyomarc'h, F. (2000). A deflated
+//     version of the conjugate gradient algorithm. SIAM J. Sci. Comput.,
+//     21(5), 1909-1926. doi:10.1137/S1064829598339761
+//   - Parks, M., de Sturler, E., Mackey, G., Johnson, D., and Maiti, S. (2006).
+//     Recycling Krylov subspaces for sequences of linear systems. SIAM J. Sci.
+//     Comput., 28(5), 1651-1674. doi:10.1137/040607277
+type DeflatedCG struct {
+	// Deflation is the maximum dimension of the deflation subspace. It must
+	// not be negative. If Deflation is 0, a default value of 8 will be used.
+	Deflation int
+
+	// Collect is the number of search directions that are appended to the
+	// window of Ritz vectors before it is reduced again. It must not be
+	// negative. If Collect is 0, twice the value of Deflation will be used.
+	Collect int
+
+	// k and m are the used values of Deflation and Collect.
+	k, m int
+
+	// w is an n×nw matrix whose orthonormal columns span the deflation
+	// subspace. It is retained between solves.
+	w  mat.Dense
+	nw int
+	// aw holds the products of the current matrix with the columns of w.
+	aw mat.Dense
+	// waw holds the Cholesky factorization of wᵀ*A*w.
+	waw mat.Cholesky
+
+	// v is an n×(k+m) matrix whose first nv columns hold the window of
+	// vectors from which the Ritz vectors are computed and av holds the
+	// products of A with them.
+	v  mat.Dense
+	av mat.Dense
+	nv int
+	// q and aq are used for computing the Ritz vectors.
+	q  mat.Dense
+	aq mat.Dense
+
+	x   mat.VecDense
+	r   mat.VecDense
+	p   mat.VecDense
+	c   mat.VecDense
+	tmp mat.VecDense
+
+	rho, rhoPrev float64
+
+	// j is the index of the column of w whose product with A is computed.
+	j int
+
+	resume int
+}
+
+// Reset discards the deflation subspace and the window of vectors so that the
+// next solve starts without deflation.
+func (dcg *DeflatedCG) Reset() {
+	dcg.w.Reset()
+	dcg.nw = 0
+	dcg.v.Reset()
+	dcg.av.Reset()
+	dcg.nv = 0
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+//
+// Init replaces the deflation subspace with the Ritz vectors computed during
+// the previous solve. If the dimension of the problem differs from the
+// previous solve, the deflation subspace is discarded.
+func (dcg *DeflatedCG) Init(x, residual *mat.VecDense) {
+	dim := x.Len()
+	if residual.Len() != dim {
+		panic("deflcg: vector length mismatch")
+	}
+	if dcg.Deflation < 0 {
+		panic("deflcg: negative deflation subspace dimension")
+	}
+	if dcg.Collect < 0 {
+		panic("deflcg: negative number of collected directions")
+	}
+
+	if !dcg.v.IsEmpty() {
+		if r, _ := dcg.v.Dims(); r != dim {
+			dcg.Reset()
+		}
+	}
+	if dcg.nv > 0 {
+		// Replace the deflation subspace before the window is
+		// resized.
+		dcg.nv = dcg.ritz(dcg.k)
+		dcg.w.Reset()
+		dcg.w.CloneFrom(dcg.v.Slice(0, dim, 0, dcg.nv))
+		dcg.nw = dcg.nv
+	}
+
+	dcg.k = dcg.Deflation
+	if dcg.k == 0 {
+		dcg.k = 8
+	}
+	dcg.m = dcg.Collect
+	if dcg.m == 0 {
+		dcg.m = 2 * dcg.k
+	}
+	if dcg.nw > dcg.k {
+		dcg.w = *mat.DenseCopyOf(dcg.w.Slice(0, dim, 0, dcg.k))
+		dcg.nw = dcg.k
+	}
+	reuseAs(&dcg.v, dim, dcg.k+dcg.m)
+	reuseAs(&dcg.av, dim, dcg.k+dcg.m)
+	dcg.nv = 0
+
+	dcg.x.CloneFromVec(x)
+	dcg.r.CloneFromVec(residual)
+
+	dcg.p.Reset()
+	dcg.p.ReuseAsVec(dim)
+	dcg.tmp.Reset()
+	dcg.tmp.ReuseAsVec(dim)
+
+	dcg.rhoPrev = 1
+	dcg.j = 0
+
+	dcg.resume = 1
+}
+
+// ritz replaces the window of vectors with at most k Ritz vectors
+// corresponding to the smallest Ritz values of A with respect to the span of
+// the window, and updates the products of A with them. It returns the number
+// of computed Ritz vectors.
+func (dcg *DeflatedCG) ritz(k int) int {
+	n, _ := dcg.v.Dims()
+	v := dcg.v.Slice(0, n, 0, dcg.nv).(*mat.Dense)
+	av := dcg.av.Slice(0, n, 0, dcg.nv).(*mat.Dense)
+
+	// Compute an orthonormal basis Q of the span of the window together
+	// with A*Q.
+	reuseAs(&dcg.q, n, dcg.nv)
+	reuseAs(&dcg.aq, n, dcg.nv)
+	q := orthonormalize(&dcg.q, v, &dcg.aq, av)
+	if q == nil {
+		return 0
+	}
+	_, nq := q.Dims()
+	aq := dcg.aq.Slice(0, n, 0, nq).(*mat.Dense)
+
+	// Solve the projected eigenvalue problem Qᵀ*A*Q*y = θ*y.
+	var g mat.Dense
+	g.Mul(q.T(), aq)
+	var eig mat.EigenSym
+	if !eig.Factorize(symPart(&g), true) {
+		return 0
+	}
+	vals := eig.Values(nil)
+	var vecs mat.Dense
+	eig.VectorsTo(&vecs)
+
+	// Select the eigenvectors of the smallest eigenvalues.
+	idx := make([]int, nq)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(a, b int) bool { return vals[idx[a]] < vals[idx[b]] })
+	nk := min(k, nq)
+	y := mat.NewDense(nq, nk, nil)
+	for l := 0; l < nk; l++ {
+		y.SetCol(l, mat.Col(nil, idx[l], &vecs))
+	}
+
+	dcg.v.Slice(0, n, 0, nk).(*mat.Dense).Mul(q, y)
+	dcg.av.Slice(0, n, 0, nk).(*mat.Dense).Mul(aq, y)
+	return nk
+}
+
+// appendWindow appends the vector x and the product of A with x to the window
+// of vectors and reduces the window when it is full.
+func (dcg *DeflatedCG) appendWindow(x, ax mat.Vector) {
+	dcg.v.ColView(dcg.nv).(*mat.VecDense).CopyVec(x)
+	dcg.av.ColView(dcg.nv).(*mat.VecDense).CopyVec(ax)
+	dcg.nv++
+	if dcg.nv == dcg.k+dcg.m {
+		dcg.nv = dcg.ritz(dcg.k)
+	}
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// DeflatedCG will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+func (dcg *DeflatedCG) Iterate(ctx *Context) (Operation, error) {
+	switch dcg.resume {
+	case 1:
+		if dcg.nw == 0 {
+			return dcg.preconSolve(ctx), nil
+		}
+		n := dcg.x.Len()
+		reuseAs(&dcg.aw, n, dcg.nw)
+		ctx.Src.CopyVec(dcg.w.ColView(0))
+		dcg.resume = 2
+		// Compute A * w_0.
+		return MulVec, nil
+	case 2:
+		dcg.aw.ColView(dcg.j).(*mat.VecDense).CopyVec(ctx.Dst)
+		dcg.j++
+		if dcg.j < dcg.nw {
+			ctx.Src.CopyVec(dcg.w.ColView(dcg.j))
+			// Compute A * w_j.
+			return MulVec, nil
+		}
+		decrease, err := dcg.project()
+		if err != nil {
+			dcg.resume = 0
+			return NoOperation, err
+		}
+		for j := 0; j < dcg.nw; j++ {
+			dcg.appendWindow(dcg.w.ColView(j), dcg.aw.ColView(j))
+		}
+		ctx.ResidualNorm = mat.Norm(&dcg.r, 2)
+		ctx.EnergyDecrease = decrease
+		dcg.resume = 3
+		return CheckResidualNorm, nil
+	case 3:
+		if ctx.Converged {
+			// The projection onto the deflation subspace
+			// solved the system.
+			ctx.X.CopyVec(&dcg.x)
+			dcg.resume = 0
+			return MajorIteration, nil
+		}
+		return dcg.preconSolve(ctx), nil
+	case 4:
+		z := ctx.Dst
+		dcg.rho = mat.Dot(&dcg.r, z)        // ρ_{i-1} = r_{i-1} · z_{i-1}
+		beta := dcg.rho / dcg.rhoPrev       // β_{i-1} = ρ_{i-1} / ρ_{i-2}
+		dcg.p.AddScaledVec(z, beta, &dcg.p) // p_i = z_{i-1} + β p_{i-1}
+		if dcg.nw > 0 {
+			// Make p_i A-orthogonal to the deflation subspace:
+			//  p_i -= W (Wᵀ A W)^{-1} (A W)ᵀ z_{i-1}.
+			dcg.c.MulVec(dcg.aw.T(), z)
+			// SolveVecTo returns only mat.Condition errors
+			// which are ignored.
+			_ = dcg.waw.SolveVecTo(&dcg.c, &dcg.c)
+			dcg.tmp.MulVec(&dcg.w, &dcg.c)
+			dcg.p.SubVec(&dcg.p, &dcg.tmp)
+		}
+		ctx.Src.CopyVec(&dcg.p)
+		dcg.resume = 5
+		// Compute A * p_i.
+		return MulVec, nil
+	case 5:
+		ap := ctx.Dst
+		dcg.appendWindow(&dcg.p, ap)
+		alpha := dcg.rho / mat.Dot(&dcg.p, ap)    // α_i = ρ_{i-1} / (p_i · A p_i)
+		dcg.x.AddScaledVec(&dcg.x, alpha, &dcg.p) // x_i = x_{i-1} + α p_i
+		dcg.r.AddScaledVec(&dcg.r, -alpha, ap)    // r_i = r_{i-1} - α A p_i
+		ctx.ResidualNorm = mat.Norm(&dcg.r, 2)
+		ctx.EnergyDecrease = alpha * dcg.rho // |e_{i-1}|_A^2 - |e_i|_A^2 = α_i ρ_{i-1}
+		dcg.resume = 6
+		return CheckResidualNorm, nil
+	case 6:
+		ctx.X.CopyVec(&dcg.x)
+		if ctx.Converged {
+			dcg.resume = 0
+			return MajorIteration, nil
+		}
+		dcg.rhoPrev = dcg.rho
+		dcg.resume = 7
+		return MajorIteration, nil
+	case 7:
+		return dcg.preconSolve(ctx), nil
+
+	default:
+		panic("deflcg: Init not called")
+	}
+}
+
+// preconSolve commands the preconditioner solve with the current residual.
+func (dcg *DeflatedCG) preconSolve(ctx *Context) Operation {
+	ctx.Src.CopyVec(&dcg.r)
+	dcg.resume = 4
+	// Compute z_{i-1} = M^{-1} * r_{i-1}.
+	return PreconSolve
+}
+
+// project factorizes Wᵀ*A*W and removes the component of the residual in the
+// deflation subspace by updating the initial solution as
+//
+//	x_0 += W (Wᵀ A W)^{-1} Wᵀ r_0,
+//	r_0 -= A W (Wᵀ A W)^{-1} Wᵀ r_0.
+//
+// It returns the resulting decrease of the squared A-norm of the error.
+func (dcg *DeflatedCG) project() (float64, error) {
+	var g mat.Dense
+	g.Mul(dcg.w.T(), &dcg.aw)
+	err := factorizeSym(&dcg.waw, &g)
+	if err != nil {
+		return 0, err
+	}
+	dcg.c.Reset()
+	dcg.c.ReuseAsVec(dcg.nw)
+	dcg.c.MulVec(dcg.w.T(), &dcg.r)
+	_ = dcg.waw.SolveVecTo(&dcg.c, &dcg.c)
+	dcg.tmp.MulVec(&dcg.w, &dcg.c)
+	decrease := mat.Dot(&dcg.tmp, &dcg.r)
+	dcg.x.AddVec(&dcg.x, &dcg.tmp)
+	dcg.tmp.MulVec(&dcg.aw, &dcg.c)
+	dcg.r.SubVec(&dcg.r, &dcg.tmp)
+	return decrease, nil
+}
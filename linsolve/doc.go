@@ -54,8 +54,9 @@ Iterative function.
 
 The MulVecToer interface represents the system matrix A. This abstracts the
 details of any particular matrix storage, and allows the user to exploit the
-properties of their particular matrix. Matrix types provided by gonum/mat and
-github.com/james-bowman/sparse packages implement this interface.
+properties of their particular matrix. Matrix types provided by gonum/mat, the
+linsolve/sparse subpackage and github.com/james-bowman/sparse packages
+implement this interface.
 
 Note that methods in this package have only limited means for checking whether
 the provided MulVecToer represents a matrix that satisfies all assumptions made
@@ -68,7 +69,54 @@ The Iterative function is the entry point to the functionality provided by this
 package. It takes as parameters the matrix A (via the MulVecToer interface as
 discussed above), the right-hand side vector b, the iterative method and
 settings that control the iterative process and provide a way for reusing
-memory.
+memory. The IterativeContext variant additionally stops the iterative process
+when a context.Context is canceled or its deadline expires.
+
+By default the iterative process is stopped when the norm of the residual
+relative to the norm of the right-hand side is smaller than a tolerance. Other
+stopping criteria such as the absolute residual norm, the backward error or an
+estimate of the A-norm of the error for CG can be set by implementations of the
+StoppingCriterion interface.
+
+The Refine function implements mixed-precision iterative refinement. It solves
+for corrections in single precision with the Iterative32 function and a
+Method32 such as CG32 or GMRES32, using a single precision matrix like
+sparse.CSR32, and computes the residual and updates the solution in double
+precision. The single precision API mirrors the real one with vectors
+represented by float32 slices.
+
+# Multiple right-hand sides
+
+Systems with several right-hand sides that are known at the same time can be
+solved with the IterativeBlock function and a BlockMethod such as BlockCG. Block
+methods share one Krylov subspace among all right-hand sides. If the matrix
+implements the MulMatToer interface, it is multiplied with all vectors of a
+block at once.
+
+# Complex systems
+
+Complex linear systems are solved with the CIterative function and a CMethod
+such as CGMRES, CBiCGStab or COCG. The complex API mirrors the real one with
+complex vectors represented by n×1 mat.CDense column vectors. COCG is a
+CG-like method with short recurrences for complex symmetric matrices that
+arise for example in frequency-domain electromagnetics.
+
+# Least-squares problems
+
+Overdetermined, underdetermined and rank-deficient systems are solved in the
+least-squares sense with the LeastSquares function and a LeastSquaresMethod
+such as LSQR or LSMR. They minimize |A*x - b|^2 + λ^2 |x|^2 for an m×n matrix A
+and an optional damping parameter λ using only the products with A and Aᵀ, and
+report estimates of the residual norm, the norm and the condition number of A.
+
+# Nonlinear systems
+
+The NewtonKrylov function solves systems of nonlinear equations F(x) = 0 by the
+Jacobian-free Newton–Krylov method. The Newton steps are computed with Iterative
+and any Method that does not need the transpose, using finite-difference
+approximations of the products of the Jacobian with vectors (see JacobianFree).
+The tolerances of the linear solves are chosen by Eisenstat–Walker forcing terms
+and the steps are globalized by a backtracking line search.
 
 # Choosing an iterative method
 
@@ -88,7 +136,34 @@ transformation matrix is called a preconditioner. A good preconditioner will
 reduce the number of iterations needed to find a good approximate solution
 (hopefully enough to overcome the cost of applying the preconditioning!), and in
 some cases preconditioning is necessary to get any kind of convergence. In
-linsolve a preconditioner is specified by Settings.PreconSolve.
+linsolve a preconditioner is specified by Settings.PreconSolve. The precond
+package provides common preconditioners, and the sparse direct factorizations
+sparse.Cholesky and sparse.LU can solve systems exactly or precondition systems
+with a nearby matrix.
+
+Chebyshev iteration and the polynomial preconditioner precond.Chebyshev do not
+compute inner products but need bounds of the eigenvalues of the preconditioned
+matrix. CG and GMRES implement the EigenvalueEstimator interface and provide
+estimates of the extreme eigenvalues and of the condition number after a solve.
+If Settings.Spectrum is true, Iterative reports them in Result.Spectrum, which
+helps with tuning preconditioners without a separate eigenvalue computation.
+
+# Parallel execution
+
+For very large problems the vector operations of the iterative methods become
+significant next to the matrix-vector product. CG, PipelinedCG, BiCGStab,
+GMRES, FGMRES and LGMRES can perform them with several goroutines when their
+Workers field is positive, and the sparse.ParallelCSR type computes the products
+with a sparse matrix in parallel. The vectors are split into blocks of fixed
+size and partial sums are added in the order of the blocks, so the results are
+deterministic and do not depend on the number of workers.
+
+When the matrix-vector product is distributed across processes, every inner
+product becomes a global reduction with the latency of a round trip.
+PipelinedCG computes all inner products of an iteration in one reduction that
+it starts before the preconditioner solve and the matrix-vector product, and a
+distributed implementation of the Reducer interface can overlap the reduction
+with them.
 
 # Implementing Method interface
 
@@ -0,0 +1,158 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// EigenvalueEstimator is a Method that estimates the extreme eigenvalues of the
+// preconditioned matrix M^{-1}*A from the recurrences of its last linear solve.
+// A few iterations are often enough for useful estimates, which can be passed
+// for example to Chebyshev or to the polynomial preconditioner in the precond
+// package.
+//
+// The estimates are Ritz values, that is, eigenvalues of the projection of
+// M^{-1}*A onto the Krylov subspace. For a symmetric matrix they lie inside
+// the interval spanned by the extreme eigenvalues and approach its end points
+// from the inside, so the largest eigenvalue is usually slightly
+// underestimated.
+type EigenvalueEstimator interface {
+	Method
+
+	// EigenvalueBounds returns estimates of the smallest and the largest
+	// eigenvalue of M^{-1}*A. For non-symmetric matrices, the smallest and
+	// the largest real part of the estimates are returned.
+	// EigenvalueBounds panics if the last linear solve did not perform any
+	// iteration.
+	EigenvalueBounds() (min, max float64)
+
+	// ConditionEstimate returns an estimate of the 2-norm condition number
+	// of M^{-1}*A. ConditionEstimate panics if the last linear solve did
+	// not perform any iteration.
+	ConditionEstimate() float64
+}
+
+// SpectrumEstimate holds estimates of spectral properties of the
+// preconditioned matrix M^{-1}*A that are obtained from an EigenvalueEstimator
+// as a by-product of a linear solve.
+type SpectrumEstimate struct {
+	// MinEig and MaxEig are the estimates of the smallest and the largest
+	// eigenvalue returned by EigenvalueBounds.
+	MinEig, MaxEig float64
+
+	// Condition is the estimate of the condition number returned by
+	// ConditionEstimate.
+	Condition float64
+}
+
+// estimateSpectrum returns the spectral estimates of m after a linear solve.
+// If m is not an EigenvalueEstimator, estimateSpectrum returns nil.
+func estimateSpectrum(m Method) *SpectrumEstimate {
+	e, ok := m.(EigenvalueEstimator)
+	if !ok {
+		return nil
+	}
+	min, max := e.EigenvalueBounds()
+	return &SpectrumEstimate{
+		MinEig:    min,
+		MaxEig:    max,
+		Condition: e.ConditionEstimate(),
+	}
+}
+
+var (
+	_ EigenvalueEstimator = (*CG)(nil)
+	_ EigenvalueEstimator = (*GMRES)(nil)
+)
+
+// EigenvalueBounds returns the extreme eigenvalues of the Lanczos tridiagonal
+// matrix whose elements are computed from the coefficients of all CG iterations
+// of the last solve. See the EigenvalueEstimator interface for more details.
+func (cg *CG) EigenvalueBounds() (min, max float64) {
+	if len(cg.alphas) == 0 {
+		panic("cg: no iterations performed")
+	}
+	vals := lanczosRitzValues(cg.alphas, cg.betas)
+	return vals[0], vals[len(vals)-1]
+}
+
+// ConditionEstimate returns the ratio of the extreme eigenvalues of the Lanczos
+// tridiagonal matrix. For a symmetric positive definite M^{-1}*A it is a lower
+// bound on the condition number that is usually accurate once the extreme
+// eigenvalues have converged. See the EigenvalueEstimator interface for more
+// details.
+func (cg *CG) ConditionEstimate() float64 {
+	min, max := cg.EigenvalueBounds()
+	return max / min
+}
+
+// lanczosRitzValues returns the eigenvalues in ascending order of the Lanczos
+// tridiagonal matrix T given by the CG coefficients α_i and β_i,
+//
+//	T[0,0]   = 1/α_0,
+//	T[i,i]   = 1/α_i + β_i/α_{i-1},
+//	T[i-1,i] = sqrt(β_i)/α_{i-1},
+//
+// where betas[i-1] holds β_i.
+//
+// References:
+//   - Saad, Y. (2003). Section 6.7.3 Eigenvalue Estimates from the CG
+//     Coefficients. In Iterative Methods for Sparse Linear Systems (2nd ed.)
+//     (pp. 201-203). Philadelphia, PA: SIAM.
+func lanczosRitzValues(alphas, betas []float64) []float64 {
+	k := len(alphas)
+	t := mat.NewSymDense(k, nil)
+	t.SetSym(0, 0, 1/alphas[0])
+	for i := 1; i < k; i++ {
+		beta := betas[i-1]
+		t.SetSym(i, i, 1/alphas[i]+beta/alphas[i-1])
+		t.SetSym(i-1, i, math.Sqrt(beta)/alphas[i-1])
+	}
+	var eig mat.EigenSym
+	if !eig.Factorize(t, false) {
+		panic("linsolve: eigenvalue decomposition failed")
+	}
+	return eig.Values(nil)
+}
+
+// EigenvalueBounds returns the extreme real parts of the eigenvalues of the
+// Hessenberg matrix computed by the Arnoldi process in the last restart cycle
+// of the last solve. See the EigenvalueEstimator interface for more details.
+func (g *GMRES) EigenvalueBounds() (min, max float64) {
+	k := g.nhess
+	if k == 0 {
+		panic("gmres: no iterations performed")
+	}
+	var eig mat.Eigen
+	if !eig.Factorize(g.hess.Slice(0, k, 0, k), mat.EigenNone) {
+		panic("linsolve: eigenvalue decomposition failed")
+	}
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, v := range eig.Values(nil) {
+		min = math.Min(min, real(v))
+		max = math.Max(max, real(v))
+	}
+	return min, max
+}
+
+// ConditionEstimate returns the ratio of the extreme singular values of the
+// (k+1)×k Hessenberg matrix computed by the Arnoldi process in the last restart
+// cycle of the last solve. Unlike the ratio of the eigenvalue estimates, this is
+// also meaningful for non-normal matrices. See the EigenvalueEstimator interface
+// for more details.
+func (g *GMRES) ConditionEstimate() float64 {
+	k := g.nhess
+	if k == 0 {
+		panic("gmres: no iterations performed")
+	}
+	var svd mat.SVD
+	if !svd.Factorize(g.hess.Slice(0, k+1, 0, k), mat.SVDNone) {
+		panic("linsolve: singular value decomposition failed")
+	}
+	return svd.Cond()
+}
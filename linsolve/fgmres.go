@@ -0,0 +1,200 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// FGMRES implements the Flexible Generalized Minimum Residual method with the
+// modified Gram-Schmidt orthogonalization for solving systems of linear
+// equations
+//
+//	A * x = b,
+//
+// where A is a nonsymmetric, nonsingular matrix. FGMRES is a variant of GMRES
+// with right preconditioning that allows the preconditioner to change at every
+// iteration. It can therefore be used with preconditioners that are not fixed
+// linear operators, for example an inner iterative solve or a multigrid cycle.
+// The price is that FGMRES stores also the preconditioned vectors, so it needs
+// about twice as much memory as GMRES.
+//
+// References:
+//   - Saad, Y. (1993). A flexible inner-outer preconditioned GMRES algorithm.
+//     SIAM J. Sci. Comput., 14(2), 461-469. doi:10.1137/0914028
+//   - Saad, Y. (2003). Section 9.4.1 Flexible GMRES. In Iterative Methods for
+//     Sparse Linear Systems (2nd ed.) (pp. 287-291). Philadelphia, PA: SIAM.
+type FGMRES struct {
+	// Restart is the restart parameter which limits the computation and
+	// storage costs. It must hold that
+	//  1 <= Restart <= n
+	// where n is the dimension of the problem. If Restart is 0, n will be
+	// used instead.
+	Restart int
+
+	// Workers is the number of goroutines that perform the vector
+	// operations including the Gram-Schmidt orthogonalization. See the CG
+	// documentation for more information.
+	Workers int
+
+	// m is the used value of Restart.
+	m int
+	// v is an n×(m+1) matrix V whose columns form an orthonormal basis of the
+	// Krylov subspace.
+	v mat.Dense
+	// z is an n×m matrix Z whose columns are the preconditioned columns of V.
+	z mat.Dense
+	// h is an (m+1)×m upper Hessenberg matrix H.
+	h mat.Dense
+	// givs holds Givens rotations that are used to reduce H to upper triangular
+	// form.
+	givs []givens
+
+	x mat.VecDense
+	y mat.VecDense
+	s mat.VecDense
+
+	kern kernels
+
+	k      int // Loop variable for inner iterations.
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (g *FGMRES) Init(x, residual *mat.VecDense) {
+	dim := x.Len()
+	if residual.Len() != dim {
+		panic("fgmres: vector length mismatch")
+	}
+
+	g.m = g.Restart
+	if g.m == 0 {
+		g.m = dim
+	}
+	if g.m <= 0 || dim < g.m {
+		panic("fgmres: invalid value of Restart")
+	}
+
+	g.v.Reset()
+	g.v.ReuseAs(dim, g.m+1)
+	// Store the residual in the first column of V.
+	g.vcol(0).CopyVec(residual)
+
+	g.z.Reset()
+	g.z.ReuseAs(dim, g.m)
+
+	g.h.Reset()
+	g.h.ReuseAs(g.m+1, g.m)
+
+	if cap(g.givs) < g.m {
+		g.givs = make([]givens, g.m)
+	} else {
+		g.givs = g.givs[:g.m]
+		for i := range g.givs {
+			g.givs[i].c = 0
+			g.givs[i].s = 0
+		}
+	}
+
+	g.kern.init(g.Workers)
+
+	g.x.CloneFromVec(x)
+	g.y.Reset()
+	g.y.ReuseAsVec(g.m + 1)
+	g.s.Reset()
+	g.s.ReuseAsVec(g.m + 1)
+
+	g.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// FGMRES will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	ComputeResidual
+//	MajorIteration
+//	NoOperation
+func (g *FGMRES) Iterate(ctx *Context) (Operation, error) {
+	switch g.resume {
+	case 1:
+		// The residual is in the first column of V.
+		v0 := g.vcol(0)
+		// Normalize v_0.
+		norm := g.kern.norm(v0)
+		g.kern.scale(1/norm, v0)
+		// Initialize s to the elementary vector e_1 scaled by norm.
+		g.s.Zero()
+		g.s.SetVec(0, norm)
+
+		// Begin the inner for-loop for k going from 0 to m-1.
+		g.k = 0
+		fallthrough
+	case 2:
+		ctx.Src.CopyVec(g.vcol(g.k))
+		g.resume = 3
+		// Solve M_k^{-1} * v_k.
+		return PreconSolve, nil
+	case 3:
+		// z_k = M_k^{-1} * v_k
+		zk := g.z.ColView(g.k).(*mat.VecDense)
+		zk.CopyVec(ctx.Dst)
+		ctx.Src.CopyVec(zk)
+		g.resume = 4
+		// Compute A * z_k.
+		return MulVec, nil
+	case 4:
+		// v_{k+1} = A * z_k
+		vk1 := g.vcol(g.k + 1)
+		vk1.CopyVec(ctx.Dst)
+		// Construct the k-th column of the upper Hessenberg matrix H
+		// using the modified Gram-Schmidt process to make v_{k+1}
+		// orthonormal to the first k+1 columns of V.
+		modifiedGS(&g.kern, g.k, &g.h, &g.v, vk1)
+		// Reduce H back to upper triangular form and update the vector s.
+		qr(g.k, g.givs, &g.h, &g.s)
+		// Check the residual norm.
+		ctx.ResidualNorm = math.Abs(g.s.AtVec(g.k + 1))
+		g.resume = 5
+		return CheckResidualNorm, nil
+	case 5:
+		g.k++
+		if g.k < g.m && !ctx.Converged {
+			// Continue the inner for-loop.
+			g.resume = 2
+			return NoOperation, nil
+		}
+		// Either restarting or converged, we have to update the solution.
+		// Solve the upper triangular system H*y=s.
+		solveLeastSquares(g.k, &g.y, &g.h, &g.s)
+		// Compute x as a linear combination of columns of Z.
+		updateSolution(&g.kern, g.k, &g.x, &g.z, &g.y)
+		ctx.X.CopyVec(&g.x)
+		if ctx.Converged {
+			g.resume = 0
+			return MajorIteration, nil
+		}
+		// We are restarting, so we have to also compute the residual.
+		g.resume = 6
+		return ComputeResidual, nil
+	case 6:
+		// Store the residual again in the first column of V.
+		g.vcol(0).CopyVec(ctx.Dst)
+		g.resume = 1
+		return MajorIteration, nil
+
+	default:
+		panic("fgmres: Init not called")
+	}
+}
+
+// vcol returns a view of the j-th column of the matrix V.
+func (g *FGMRES) vcol(j int) *mat.VecDense {
+	return g.v.ColView(j).(*mat.VecDense)
+}
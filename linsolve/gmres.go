@@ -43,6 +43,11 @@ type GMRES struct {
 	// a problem-dependent value less than n.
 	Restart int
 
+	// Workers is the number of goroutines that perform the vector
+	// operations including the Gram-Schmidt orthogonalization. See the CG
+	// documentation for more information.
+	Workers int
+
 	// m is the used value of Restart.
 	m int
 	// v is an n×(m+1) matrix V whose columns form an orthonormal basis of the
@@ -50,6 +55,11 @@ type GMRES struct {
 	v mat.Dense
 	// h is an (m+1)×m upper Hessenberg matrix H.
 	h mat.Dense
+	// hess is a copy of H before its reduction to upper triangular form
+	// and nhess is the number of its columns computed in the current
+	// restart cycle.
+	hess  mat.Dense
+	nhess int
 	// givs holds Givens rotations that are used to reduce H to upper triangular
 	// form.
 	givs []givens
@@ -58,6 +68,8 @@ type GMRES struct {
 	y mat.VecDense
 	s mat.VecDense
 
+	kern kernels
+
 	k      int // Loop variable for inner iterations.
 	resume int
 }
@@ -84,6 +96,9 @@ func (g *GMRES) Init(x, residual *mat.VecDense) {
 
 	g.h.Reset()
 	g.h.ReuseAs(g.m+1, g.m)
+	g.hess.Reset()
+	g.hess.ReuseAs(g.m+1, g.m)
+	g.nhess = 0
 
 	if cap(g.givs) < g.m {
 		g.givs = make([]givens, g.m)
@@ -95,6 +110,8 @@ func (g *GMRES) Init(x, residual *mat.VecDense) {
 		}
 	}
 
+	g.kern.init(g.Workers)
+
 	g.x.CloneFromVec(x)
 	g.y.Reset()
 	g.y.ReuseAsVec(g.m + 1)
@@ -126,14 +143,15 @@ func (g *GMRES) Iterate(ctx *Context) (Operation, error) {
 		v0 := g.vcol(0)
 		v0.CopyVec(ctx.Dst)
 		// Normalize v_0.
-		norm := mat.Norm(v0, 2)
-		v0.ScaleVec(1/norm, v0)
+		norm := g.kern.norm(v0)
+		g.kern.scale(1/norm, v0)
 		// Initialize s to the elementary vector e_1 scaled by norm.
 		g.s.Zero()
 		g.s.SetVec(0, norm)
 
 		// Begin the inner for-loop for k going from 0 to m-1.
 		g.k = 0
+		g.nhess = 0
 		fallthrough
 	case 3:
 		ctx.Src.CopyVec(g.vcol(g.k))
@@ -152,9 +170,11 @@ func (g *GMRES) Iterate(ctx *Context) (Operation, error) {
 		// Construct the k-th column of the upper Hessenberg matrix H
 		// using the modified Gram-Schmidt process to make v_{k+1}
 		// orthonormal to the first k+1 columns of V.
-		g.modifiedGS(g.k, &g.h, &g.v, vk1)
+		modifiedGS(&g.kern, g.k, &g.h, &g.v, vk1)
+		g.hess.ColView(g.k).(*mat.VecDense).CopyVec(g.h.ColView(g.k))
+		g.nhess = g.k + 1
 		// Reduce H back to upper triangular form and update the vector s.
-		g.qr(g.k, g.givs, &g.h, &g.s)
+		qr(g.k, g.givs, &g.h, &g.s)
 		// Check the approximate residual norm.
 		ctx.ResidualNorm = math.Abs(g.s.AtVec(g.k + 1))
 		g.resume = 6
@@ -168,9 +188,9 @@ func (g *GMRES) Iterate(ctx *Context) (Operation, error) {
 		}
 		// Either restarting or converged, we have to update the solution.
 		// Solve the upper triangular system H*y=s.
-		g.solveLeastSquares(g.k, &g.y, &g.h, &g.s)
+		solveLeastSquares(g.k, &g.y, &g.h, &g.s)
 		// Compute x as a linear combination of columns of V.
-		g.updateSolution(g.k, &g.x, &g.v, &g.y)
+		updateSolution(&g.kern, g.k, &g.x, &g.v, &g.y)
 		ctx.X.CopyVec(&g.x)
 		if ctx.Converged {
 			g.resume = 0
@@ -193,22 +213,22 @@ func (g *GMRES) Iterate(ctx *Context) (Operation, error) {
 // modifiedGS orthonormalizes the vector w with respect to the first k+1 columns
 // of V using the modified Gram-Schmidt algorithm, and stores the computed
 // coefficients in the k-th column of H.
-func (g *GMRES) modifiedGS(k int, h, v *mat.Dense, w *mat.VecDense) {
+func modifiedGS(kern *kernels, k int, h, v *mat.Dense, w *mat.VecDense) {
 	hk := h.ColView(k).(*mat.VecDense)
 	for j := 0; j <= k; j++ {
 		vj := v.ColView(j).(*mat.VecDense)
-		hkj := mat.Dot(vj, w)
-		hk.SetVec(j, hkj)           // H[j,k] = v_j · w
-		w.AddScaledVec(w, -hkj, vj) // w -= H[j,k] * v_j
+		hkj := kern.dot(vj, w)
+		hk.SetVec(j, hkj)              // H[j,k] = v_j · w
+		kern.addScaled(w, w, -hkj, vj) // w -= H[j,k] * v_j
 	}
-	norm := mat.Norm(w, 2)
+	norm := kern.norm(w)
 	hk.SetVec(k+1, norm)  // H[k+1,k] = |w|
-	w.ScaleVec(1/norm, w) // Normalize w.
+	kern.scale(1/norm, w) // Normalize w.
 }
 
 // qr applies previous Givens rotations to the k-th column of H, computes the
 // next Givens rotation to zero out H[k+1,k] and applies it also to the vector s.
-func (g *GMRES) qr(k int, givs []givens, h *mat.Dense, s *mat.VecDense) {
+func qr(k int, givs []givens, h *mat.Dense, s *mat.VecDense) {
 	// Apply previous Givens rotations to the k-th column of H.
 	hk := h.ColView(k).(*mat.VecDense)
 	for i, giv := range givs[:k] {
@@ -234,7 +254,7 @@ func (g *GMRES) qr(k int, givs []givens, h *mat.Dense, s *mat.VecDense) {
 // solveLeastSquares solves the upper triangular linear system
 //
 //	H * y = s
-func (g *GMRES) solveLeastSquares(k int, y *mat.VecDense, h *mat.Dense, s *mat.VecDense) {
+func solveLeastSquares(k int, y *mat.VecDense, h *mat.Dense, s *mat.VecDense) {
 	// Copy the first k elements of s into y.
 	y.CopyVec(s.SliceVec(0, k))
 	// Convert H into an upper triangular matrix.
@@ -254,10 +274,10 @@ func (g *GMRES) solveLeastSquares(k int, y *mat.VecDense, h *mat.Dense, s *mat.V
 // combination of the first k columns of V:
 //
 //	x = x + V * y = x + \sum y_j * v_j
-func (g *GMRES) updateSolution(k int, x *mat.VecDense, v *mat.Dense, y *mat.VecDense) {
+func updateSolution(kern *kernels, k int, x *mat.VecDense, v *mat.Dense, y *mat.VecDense) {
 	for j := 0; j < k; j++ {
-		vj := v.ColView(j)
-		x.AddScaledVec(x, y.AtVec(j), vj)
+		vj := v.ColView(j).(*mat.VecDense)
+		kern.addScaled(x, x, y.AtVec(j), vj)
 	}
 }
 
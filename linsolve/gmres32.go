@@ -0,0 +1,248 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+// GMRES32 implements the Generalized Minimum Residual method with the modified
+// Gram-Schmidt orthogonalization in single precision for solving systems of
+// linear equations
+//
+//	A * x = b,
+//
+// where A is a general nonsingular matrix. It is the single precision
+// counterpart of GMRES and uses restarts in the same way to limit the memory
+// requirements.
+//
+// References:
+//   - Saad, Y., and Schultz, M. (1986). GMRES: A generalized minimal residual
+//     algorithm for solving nonsymmetric linear systems. SIAM J. Sci. Stat.
+//     Comput., 7(3), 856. doi:10.6028/jres.049.044
+//     Retrieved from https://web.stanford.edu/class/cme324/saad-schultz.pdf
+type GMRES32 struct {
+	// Restart is the restart parameter which limits the computation and
+	// storage costs. It must hold that
+	//  1 <= Restart <= n
+	// where n is the dimension of the problem. If Restart is 0, n will be
+	// used instead.
+	Restart int
+
+	// m is the used value of Restart.
+	m int
+	// vbuf holds the storage for v.
+	vbuf []float32
+	// v holds m+1 vectors which form an orthonormal basis of the
+	// Krylov subspace.
+	v [][]float32
+	// hbuf holds the storage for h.
+	hbuf []float32
+	// h holds the m columns of the (m+1)×m upper Hessenberg
+	// matrix H.
+	h [][]float32
+	// givs holds Givens rotations that are used to reduce H to upper
+	// triangular form.
+	givs []givens32
+
+	x []float32
+	y []float32
+	s []float32
+
+	k      int // Loop variable for inner iterations.
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the Method32 interface for more details.
+func (g *GMRES32) Init(x, residual []float32) {
+	dim := len(x)
+	if len(residual) != dim {
+		panic("gmres32: vector length mismatch")
+	}
+
+	g.m = g.Restart
+	if g.m == 0 {
+		g.m = dim
+	}
+	if g.m <= 0 || dim < g.m {
+		panic("gmres32: invalid value of Restart")
+	}
+
+	g.vbuf = sresize(g.vbuf, dim*(g.m+1))
+	g.v = g.v[:0]
+	for j := 0; j <= g.m; j++ {
+		g.v = append(g.v, g.vbuf[j*dim:(j+1)*dim])
+	}
+	// Store the residual in the first vector of V.
+	copy(g.v[0], residual)
+
+	g.hbuf = sresize(g.hbuf, (g.m+1)*g.m)
+	g.h = g.h[:0]
+	for j := 0; j < g.m; j++ {
+		g.h = append(g.h, g.hbuf[j*(g.m+1):(j+1)*(g.m+1)])
+	}
+
+	if cap(g.givs) < g.m {
+		g.givs = make([]givens32, g.m)
+	} else {
+		g.givs = g.givs[:g.m]
+		clear(g.givs)
+	}
+
+	g.x = sresize(g.x, dim)
+	copy(g.x, x)
+	g.y = sresize(g.y, g.m+1)
+	g.s = sresize(g.s, g.m+1)
+
+	g.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method32 interface for more details.
+//
+// GMRES32 will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	ComputeResidual
+//	MajorIteration
+//	NoOperation
+func (g *GMRES32) Iterate(ctx *Context32) (Operation, error) {
+	switch g.resume {
+	case 1:
+		// The initial residual is in the first vector of V.
+		copy(ctx.Src, g.v[0])
+		g.resume = 2
+		// Solve M^{-1} * r_0.
+		return PreconSolve, nil
+	case 2:
+		// v_0 = M^{-1} * r_0
+		v0 := g.v[0]
+		copy(v0, ctx.Dst)
+		// Normalize v_0.
+		norm := float32(snrm2(v0))
+		sscal(1/norm, v0)
+		// Initialize s to the elementary vector e_1 scaled by norm.
+		clear(g.s)
+		g.s[0] = norm
+
+		// Begin the inner for-loop for k going from 0 to m-1.
+		g.k = 0
+		fallthrough
+	case 3:
+		copy(ctx.Src, g.v[g.k])
+		g.resume = 4
+		// Compute A * v_k.
+		return MulVec, nil
+	case 4:
+		copy(ctx.Src, ctx.Dst)
+		g.resume = 5
+		// Solve M^{-1} * (A * v_k).
+		return PreconSolve, nil
+	case 5:
+		// v_{k+1} = M^{-1} * (A * v_k)
+		vk1 := g.v[g.k+1]
+		copy(vk1, ctx.Dst)
+		// Construct the k-th column of the upper Hessenberg matrix H
+		// using the modified Gram-Schmidt process to make v_{k+1}
+		// orthonormal to the first k+1 vectors of V.
+		modifiedGS32(g.k, g.h[g.k], g.v, vk1)
+		// Reduce H back to upper triangular form and update the vector s.
+		qr32(g.k, g.givs, g.h[g.k], g.s)
+		// Check the approximate residual norm.
+		ctx.ResidualNorm = math.Abs(float64(g.s[g.k+1]))
+		g.resume = 6
+		return CheckResidualNorm, nil
+	case 6:
+		g.k++
+		if g.k < g.m && !ctx.Converged {
+			// Continue the inner for-loop.
+			g.resume = 3
+			return NoOperation, nil
+		}
+		// Either restarting or converged, we have to update the solution.
+		// Solve the upper triangular system H*y=s.
+		g.solveLeastSquares()
+		// Compute x as a linear combination of vectors of V.
+		for j, yj := range g.y[:g.k] {
+			saxpy(yj, g.v[j], g.x)
+		}
+		copy(ctx.X, g.x)
+		if ctx.Converged {
+			g.resume = 0
+			return MajorIteration, nil
+		}
+		// We are restarting, so we have to also compute the residual.
+		g.resume = 7
+		return ComputeResidual, nil
+	case 7:
+		// Store the residual again in the first vector of V.
+		copy(g.v[0], ctx.Dst)
+		g.resume = 1
+		return MajorIteration, nil
+
+	default:
+		panic("gmres32: Init not called")
+	}
+}
+
+// solveLeastSquares solves the k×k upper triangular linear system
+//
+//	H * y = s
+func (g *GMRES32) solveLeastSquares() {
+	k := g.k
+	copy(g.y, g.s[:k])
+	for i := k - 1; i >= 0; i-- {
+		yi := g.y[i]
+		for j := i + 1; j < k; j++ {
+			yi -= g.h[j][i] * g.y[j]
+		}
+		g.y[i] = yi / g.h[i][i]
+	}
+}
+
+// modifiedGS32 orthonormalizes the vector w with respect to the first k+1
+// vectors of V using the modified Gram-Schmidt algorithm, and stores the
+// computed coefficients in hk.
+func modifiedGS32(k int, hk []float32, v [][]float32, w []float32) {
+	for j := 0; j <= k; j++ {
+		hkj := sdot(v[j], w)
+		hk[j] = hkj          // H[j,k] = v_j · w
+		saxpy(-hkj, v[j], w) // w -= H[j,k] * v_j
+	}
+	norm := float32(snrm2(w))
+	hk[k+1] = norm   // H[k+1,k] = |w|
+	sscal(1/norm, w) // Normalize w.
+}
+
+// qr32 applies previous Givens rotations to the k-th column of H, computes the
+// next Givens rotation to zero out H[k+1,k] and applies it also to the vector
+// s.
+func qr32(k int, givs []givens32, hk, s []float32) {
+	// Apply previous Givens rotations to the k-th column of H.
+	for i, giv := range givs[:k] {
+		hk[i], hk[i+1] = giv.apply(hk[i], hk[i+1])
+	}
+
+	// Compute the k-th Givens rotation that zeros H[k+1,k] and
+	// apply it to (H[k,k], H[k+1,k]).
+	givs[k].c, givs[k].s, _, _ = blas32.Rotg(hk[k], hk[k+1])
+	hk[k], _ = givs[k].apply(hk[k], hk[k+1])
+	hk[k+1] = 0
+
+	// Apply the k-th Givens rotation to (s[k], s[k+1]).
+	s[k], s[k+1] = givs[k].apply(s[k], s[k+1])
+}
+
+// givens32 is a Givens rotation in single precision.
+type givens32 struct {
+	c, s float32
+}
+
+func (giv givens32) apply(x, y float32) (float32, float32) {
+	return giv.c*x + giv.s*y, giv.c*y - giv.s*x
+}
@@ -0,0 +1,278 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// IDRs implements the Induced Dimension Reduction method IDR(s) with
+// biorthogonalization and right preconditioning for solving systems of linear
+// equations
+//
+//	A * x = b,
+//
+// where A is a nonsymmetric, nonsingular matrix. IDR(s) uses short recurrences
+// and its memory requirements are bounded by roughly 3*s+5 vectors of length
+// n. For s=1 it is mathematically equivalent to BiCGStab, larger values of s
+// often lead to faster convergence with fewer matrix-vector products. IDRs
+// does not need the multiplication with Aᵀ.
+//
+// One iteration of IDRs consists of s+1 steps, each of which requires one
+// MulVec and one PreconSolve. Convergence is checked after each step.
+//
+// References:
+//   - Sonneveld, P., and van Gijzen, M. (2008). IDR(s): A family of simple and
+//     fast algorithms for solving large nonsymmetric systems of linear
+//     equations. SIAM J. Sci. Comput., 31(2), 1035-1062.
+//     doi:10.1137/070685804
+//   - van Gijzen, M., and Sonneveld, P. (2011). Algorithm 913: An elegant IDR(s)
+//     variant that efficiently exploits biorthogonality properties. ACM Trans.
+//     Math. Softw., 38(1), 5:1-5:19. doi:10.1145/2049662.2049667
+type IDRs struct {
+	// S is the dimension of the shadow space. It must hold that
+	//  0 <= S <= n
+	// where n is the dimension of the problem. If S is 0, the value
+	// min(4,n) will be used.
+	S int
+
+	// s is the used value of S.
+	s int
+	// p is an n×s matrix whose orthonormal columns span the shadow space.
+	p mat.Dense
+	// g is an n×s matrix with the vectors in the subspace G_j.
+	g mat.Dense
+	// u is an n×s matrix such that G = A * M^{-1} * U.
+	u mat.Dense
+	// m is an s×s lower triangular matrix M = Pᵀ * G.
+	m mat.Dense
+
+	x mat.VecDense
+	r mat.VecDense
+	v mat.VecDense
+	f mat.VecDense
+	c mat.VecDense
+
+	omega float64
+	beta  float64
+
+	k      int // Loop variable for inner iterations.
+	resume int
+}
+
+// idrsAngle is the threshold on the angle between the residual and A*M^{-1}*r
+// used for maintaining convergence when computing omega.
+const idrsAngle = 0.7
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (idr *IDRs) Init(x, residual *mat.VecDense) {
+	dim := x.Len()
+	if residual.Len() != dim {
+		panic("idrs: vector length mismatch")
+	}
+
+	idr.s = idr.S
+	if idr.s == 0 {
+		idr.s = min(4, dim)
+	}
+	if idr.s <= 0 || dim < idr.s {
+		panic("idrs: invalid value of S")
+	}
+	s := idr.s
+
+	// Generate a random shadow space with orthonormal basis. A fixed seed is
+	// used so that the method is deterministic.
+	idr.p.Reset()
+	idr.p.ReuseAs(dim, s)
+	rnd := rand.New(rand.NewSource(1))
+	for j := 0; j < s; j++ {
+		pj := idr.p.ColView(j).(*mat.VecDense)
+		for i := 0; i < dim; i++ {
+			pj.SetVec(i, rnd.NormFloat64())
+		}
+		for i := 0; i < j; i++ {
+			pi := idr.p.ColView(i)
+			pj.AddScaledVec(pj, -mat.Dot(pi, pj), pi)
+		}
+		pj.ScaleVec(1/mat.Norm(pj, 2), pj)
+	}
+
+	idr.g.Reset()
+	idr.g.ReuseAs(dim, s)
+	idr.g.Zero()
+	idr.u.Reset()
+	idr.u.ReuseAs(dim, s)
+	idr.u.Zero()
+	idr.m.Reset()
+	idr.m.ReuseAs(s, s)
+	idr.m.Zero()
+	for i := 0; i < s; i++ {
+		idr.m.Set(i, i, 1)
+	}
+
+	idr.x.CloneFromVec(x)
+	idr.r.CloneFromVec(residual)
+	idr.v.Reset()
+	idr.v.ReuseAsVec(dim)
+	idr.f.Reset()
+	idr.f.ReuseAsVec(s)
+	idr.c.Reset()
+	idr.c.ReuseAsVec(s)
+
+	idr.omega = 1
+
+	idr.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// IDRs will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+//	NoOperation
+func (idr *IDRs) Iterate(ctx *Context) (Operation, error) {
+	s := idr.s
+	switch idr.resume {
+	case 1:
+		// f = Pᵀ * r
+		idr.f.MulVec(idr.p.T(), &idr.r)
+		// Begin the inner for-loop for k going from 0 to s-1.
+		idr.k = 0
+		fallthrough
+	case 2:
+		k := idr.k
+		// Solve the lower triangular system M[k:s,k:s] * c = f[k:s].
+		c := idr.c.SliceVec(0, s-k).(*mat.VecDense)
+		for i := 0; i < s-k; i++ {
+			ci := idr.f.AtVec(k + i)
+			for j := 0; j < i; j++ {
+				ci -= idr.m.At(k+i, k+j) * c.AtVec(j)
+			}
+			c.SetVec(i, ci/idr.m.At(k+i, k+i))
+		}
+		// v = r - G[:,k:s] * c
+		idr.v.MulVec(idr.g.Slice(0, idr.v.Len(), k, s), c)
+		idr.v.SubVec(&idr.r, &idr.v)
+		// Solve M^{-1} * v.
+		ctx.Src.CopyVec(&idr.v)
+		idr.resume = 3
+		return PreconSolve, nil
+	case 3:
+		k := idr.k
+		n := idr.v.Len()
+		c := idr.c.SliceVec(0, s-k)
+		// u_k = U[:,k:s] * c + ω * M^{-1} * v
+		idr.v.MulVec(idr.u.Slice(0, n, k, s), c)
+		idr.v.AddScaledVec(&idr.v, idr.omega, ctx.Dst)
+		uk := idr.u.ColView(k).(*mat.VecDense)
+		uk.CopyVec(&idr.v)
+		// Compute g_k = A * u_k.
+		ctx.Src.CopyVec(uk)
+		idr.resume = 4
+		return MulVec, nil
+	case 4:
+		k := idr.k
+		uk := idr.u.ColView(k).(*mat.VecDense)
+		gk := idr.g.ColView(k).(*mat.VecDense)
+		gk.CopyVec(ctx.Dst)
+		// Make g_k orthogonal to the first k columns of P.
+		for i := 0; i < k; i++ {
+			alpha := mat.Dot(idr.p.ColView(i), gk) / idr.m.At(i, i)
+			gk.AddScaledVec(gk, -alpha, idr.g.ColView(i))
+			uk.AddScaledVec(uk, -alpha, idr.u.ColView(i))
+		}
+		// M[k:s,k] = P[:,k:s]ᵀ * g_k
+		for i := k; i < s; i++ {
+			idr.m.Set(i, k, mat.Dot(idr.p.ColView(i), gk))
+		}
+		mkk := idr.m.At(k, k)
+		if math.Abs(mkk) < breakdownTol {
+			idr.resume = 0
+			return NoOperation, &BreakdownError{math.Abs(mkk), breakdownTol}
+		}
+		// Make the residual orthogonal to the first k+1 columns of P.
+		idr.beta = idr.f.AtVec(k) / mkk
+		idr.r.AddScaledVec(&idr.r, -idr.beta, gk)
+		idr.x.AddScaledVec(&idr.x, idr.beta, uk)
+		ctx.ResidualNorm = mat.Norm(&idr.r, 2)
+		idr.resume = 5
+		return CheckResidualNorm, nil
+	case 5:
+		if ctx.Converged {
+			ctx.X.CopyVec(&idr.x)
+			idr.resume = 0
+			return MajorIteration, nil
+		}
+		// f[k+1:s] -= β * M[k+1:s,k]
+		for i := idr.k + 1; i < s; i++ {
+			idr.f.SetVec(i, idr.f.AtVec(i)-idr.beta*idr.m.At(i, idr.k))
+		}
+		idr.k++
+		if idr.k < s {
+			// Continue the inner for-loop.
+			idr.resume = 2
+		} else {
+			// Enter the dimension reduction step.
+			idr.resume = 6
+		}
+		return NoOperation, nil
+	case 6:
+		// Solve M^{-1} * r.
+		ctx.Src.CopyVec(&idr.r)
+		idr.resume = 7
+		return PreconSolve, nil
+	case 7:
+		idr.v.CopyVec(ctx.Dst)
+		// Compute A * M^{-1} * r.
+		ctx.Src.CopyVec(&idr.v)
+		idr.resume = 8
+		return MulVec, nil
+	case 8:
+		t := ctx.Dst
+		idr.omega = idrsOmega(t, &idr.r)
+		if math.Abs(idr.omega) < breakdownTol {
+			idr.resume = 0
+			return NoOperation, &BreakdownError{math.Abs(idr.omega), breakdownTol}
+		}
+		idr.r.AddScaledVec(&idr.r, -idr.omega, t)
+		idr.x.AddScaledVec(&idr.x, idr.omega, &idr.v)
+		ctx.ResidualNorm = mat.Norm(&idr.r, 2)
+		idr.resume = 9
+		return CheckResidualNorm, nil
+	case 9:
+		ctx.X.CopyVec(&idr.x)
+		if ctx.Converged {
+			idr.resume = 0
+			return MajorIteration, nil
+		}
+		idr.resume = 1
+		return MajorIteration, nil
+
+	default:
+		panic("idrs: Init not called")
+	}
+}
+
+// idrsOmega returns the parameter ω that minimizes the norm of r - ω*t, with
+// a correction that prevents ω from becoming too small when t and r are
+// nearly orthogonal.
+func idrsOmega(t, r *mat.VecDense) float64 {
+	tNorm := mat.Norm(t, 2)
+	rNorm := mat.Norm(r, 2)
+	tr := mat.Dot(t, r)
+	omega := tr / (tNorm * tNorm)
+	rho := math.Abs(tr / (tNorm * rNorm))
+	if 0 < rho && rho < idrsAngle {
+		omega *= idrsAngle / rho
+	}
+	return omega
+}
@@ -5,7 +5,9 @@
 package linsolve
 
 import (
+	"context"
 	"errors"
+	"math"
 
 	"gonum.org/v1/gonum/mat"
 )
@@ -37,8 +39,8 @@ type Settings struct {
 	Dst *mat.VecDense
 
 	// Tolerance specifies error tolerance for the final (approximate)
-	// solution produced by the iterative method. The iteration will be
-	// stopped when
+	// solution produced by the iterative method. If Stop is nil, the
+	// iteration will be stopped when
 	//  |r_i| < Tolerance * |b|
 	// where r_i is the residual at i-th iteration.
 	//
@@ -46,6 +48,13 @@ type Settings struct {
 	// it must be positive and less than 1.
 	Tolerance float64
 
+	// Stop, if not nil, is the stopping criterion that is checked when
+	// Method commands CheckResidualNorm. It will be initialized by
+	// Iterative before the iterative process starts. If the initial guess
+	// satisfies Stop or its residual is zero, no iterations are done. If
+	// Stop is nil, RelativeResidual with Tolerance will be used.
+	Stop StoppingCriterion
+
 	// MaxIterations is the limit on the number of iterations. If it is
 	// zero, a default value of twice the dimension of the system will be
 	// used.
@@ -64,6 +73,24 @@ type Settings struct {
 	// either empty or their length must be equal to the dimension of the
 	// system.
 	Work *Context
+
+	// Monitor, if not nil, is called at the end of each iteration with the
+	// number of completed iterations, (an estimate of) the residual norm
+	// and the current approximate solution x. Monitor must not modify or
+	// retain x. If Monitor returns a non-nil error, the iterative process
+	// is stopped and the error is returned together with the current
+	// approximate solution.
+	Monitor func(iter int, resNorm float64, x mat.Vector) error
+
+	// ResidualHistory specifies whether the residual norm after each
+	// iteration will be recorded in Result.ResidualHistory.
+	ResidualHistory bool
+
+	// Spectrum specifies whether estimates of the extreme eigenvalues and
+	// the condition number of the preconditioned matrix M^{-1}*A will be
+	// reported in Result.Spectrum. The estimates are available only if the
+	// Method implements the EigenvalueEstimator interface.
+	Spectrum bool
 }
 
 // defaultSettings fills zero fields of s with default values.
@@ -85,6 +112,9 @@ func defaultSettings(s *Settings, dim int) {
 	if s.PreconSolve == nil {
 		s.PreconSolve = NoPreconditioner
 	}
+	if s.Stop == nil {
+		s.Stop = &RelativeResidual{Tolerance: s.Tolerance}
+	}
 	if s.Work == nil {
 		s.Work = NewContext(dim)
 	} else {
@@ -126,6 +156,17 @@ type Result struct {
 	// ResidualNorm is an approximation to the norm of the final residual.
 	ResidualNorm float64
 
+	// ResidualHistory holds (an estimate of) the residual norm of the
+	// initial guess followed by the residual norm after each iteration if
+	// Settings.ResidualHistory is true, otherwise it is nil.
+	ResidualHistory []float64
+
+	// Spectrum holds the spectral estimates computed by the Method if
+	// Settings.Spectrum is true, the Method implements the
+	// EigenvalueEstimator interface and at least one iteration has been
+	// performed, otherwise it is nil.
+	Spectrum *SpectrumEstimate
+
 	// Stats holds statistics about the iterative solve.
 	Stats Stats
 }
@@ -164,6 +205,17 @@ type Stats struct {
 // defaults, users are strongly encouraged to adjust these defaults for their
 // problem.
 func Iterative(a MulVecToer, b *mat.VecDense, m Method, settings *Settings) (*Result, error) {
+	return IterativeContext(context.Background(), a, b, m, settings)
+}
+
+// IterativeContext is like Iterative but it stops the iterative process when
+// ctx is done. The context is checked at the end of each iteration of m, so a
+// wall-clock limit can be imposed by using a context with a deadline.
+//
+// If ctx is done before convergence, IterativeContext returns ctx.Err() and
+// the result holds the iterate with the smallest residual norm among the
+// completed iterations and the initial guess.
+func IterativeContext(ctx context.Context, a MulVecToer, b *mat.VecDense, m Method, settings *Settings) (*Result, error) {
 	n := b.Len()
 
 	var s Settings
@@ -174,15 +226,15 @@ func Iterative(a MulVecToer, b *mat.VecDense, m Method, settings *Settings) (*Re
 	checkSettings(&s, n)
 
 	var stats Stats
-	ctx := s.Work
+	work := s.Work
 	rInit := mat.NewVecDense(n, nil)
 	if s.InitX != nil {
 		// Initial x is provided.
-		ctx.X.CloneFromVec(s.InitX)
-		computeResidual(rInit, a, b, ctx.X, &stats)
+		work.X.CloneFromVec(s.InitX)
+		computeResidual(rInit, a, b, work.X, &stats)
 	} else {
 		// Initial x is the zero vector.
-		ctx.X.Zero()
+		work.X.Zero()
 		// Residual b-A*x is then equal to b.
 		rInit.CopyVec(b)
 	}
@@ -192,32 +244,51 @@ func Iterative(a MulVecToer, b *mat.VecDense, m Method, settings *Settings) (*Re
 	}
 
 	var err error
-	ctx.ResidualNorm = mat.Norm(rInit, 2)
-	if ctx.ResidualNorm >= s.Tolerance {
-		err = iterate(a, b, rInit, s, m, &stats)
+	var hist []float64
+	work.ResidualNorm = mat.Norm(rInit, 2)
+	work.EnergyDecrease = 0
+	if s.ResidualHistory {
+		hist = []float64{work.ResidualNorm}
+	}
+	s.Stop.Init(mat.Norm(b, 2), work.ResidualNorm)
+	if work.ResidualNorm == 0 || s.Stop.Converged(work) {
+		s.Dst.CopyVec(work.X)
 	} else {
-		s.Dst.CopyVec(ctx.X)
+		err = iterate(ctx, a, b, rInit, s, m, &stats, &hist)
+	}
+
+	var spectrum *SpectrumEstimate
+	if s.Spectrum && stats.Iterations > 0 {
+		spectrum = estimateSpectrum(m)
 	}
 
 	return &Result{
-		X:            s.Dst,
-		ResidualNorm: ctx.ResidualNorm,
-		Stats:        stats,
+		X:               s.Dst,
+		ResidualNorm:    work.ResidualNorm,
+		ResidualHistory: hist,
+		Spectrum:        spectrum,
+		Stats:           stats,
 	}, err
 }
 
-func iterate(a MulVecToer, b, initRes *mat.VecDense, settings Settings, method Method, stats *Stats) error {
-	bNorm := mat.Norm(b, 2)
-	if bNorm == 0 {
-		bNorm = 1
+// iterate runs method until convergence. If *hist is not nil, the residual
+// norm after each iteration is appended to it.
+func iterate(ctx context.Context, a MulVecToer, b, initRes *mat.VecDense, settings Settings, method Method, stats *Stats, hist *[]float64) error {
+	work := settings.Work
+	settings.Dst.CopyVec(work.X)
+	if err := ctx.Err(); err != nil {
+		return err
 	}
+	// bestNorm is the smallest residual norm at the end of an iteration.
+	// The corresponding iterate is kept in settings.Dst if ctx can be
+	// done.
+	done := ctx.Done()
+	bestNorm := work.ResidualNorm
 
-	ctx := settings.Work
-	settings.Dst.CopyVec(ctx.X)
-
-	method.Init(ctx.X, initRes)
+	work.EnergyDecrease = math.NaN()
+	method.Init(work.X, initRes)
 	for {
-		op, err := method.Iterate(ctx)
+		op, err := method.Iterate(work)
 		if err != nil {
 			return err
 		}
@@ -225,27 +296,51 @@ func iterate(a MulVecToer, b, initRes *mat.VecDense, settings Settings, method M
 		case NoOperation:
 		case MulVec, MulVec | Trans:
 			stats.MulVec++
-			a.MulVecTo(ctx.Dst, op&Trans == Trans, ctx.Src)
+			a.MulVecTo(work.Dst, op&Trans == Trans, work.Src)
 		case PreconSolve, PreconSolve | Trans:
 			stats.PreconSolve++
-			err = settings.PreconSolve(ctx.Dst, op&Trans == Trans, ctx.Src)
+			err = settings.PreconSolve(work.Dst, op&Trans == Trans, work.Src)
 			if err != nil {
 				return err
 			}
 		case CheckResidualNorm:
-			ctx.Converged = ctx.ResidualNorm < settings.Tolerance*bNorm
+			work.Converged = settings.Stop.Converged(work)
+			work.EnergyDecrease = math.NaN()
 		case ComputeResidual:
-			computeResidual(ctx.Dst, a, b, ctx.X, stats)
+			computeResidual(work.Dst, a, b, work.X, stats)
 		case MajorIteration:
 			stats.Iterations++
-			if ctx.Converged {
-				settings.Dst.CopyVec(ctx.X)
+			if *hist != nil {
+				*hist = append(*hist, work.ResidualNorm)
+			}
+			if settings.Monitor != nil {
+				err = settings.Monitor(stats.Iterations, work.ResidualNorm, work.X)
+				if err != nil {
+					settings.Dst.CopyVec(work.X)
+					return err
+				}
+			}
+			if work.Converged {
+				settings.Dst.CopyVec(work.X)
 				return nil
 			}
 			if stats.Iterations == settings.MaxIterations {
-				settings.Dst.CopyVec(ctx.X)
+				settings.Dst.CopyVec(work.X)
 				return ErrIterationLimit
 			}
+			if done == nil {
+				break
+			}
+			if work.ResidualNorm < bestNorm {
+				bestNorm = work.ResidualNorm
+				settings.Dst.CopyVec(work.X)
+			}
+			select {
+			case <-done:
+				work.ResidualNorm = bestNorm
+				return ctx.Err()
+			default:
+			}
 		default:
 			panic("linsolve: invalid operation")
 		}
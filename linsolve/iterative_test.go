@@ -5,12 +5,18 @@
 package linsolve
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/cmplx"
 	"testing"
 
 	"golang.org/x/exp/rand"
 
+	"gonum.org/v1/exp/linsolve/sparse"
 	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/floats/scalar"
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -62,6 +68,271 @@ func TestCGDefaultSettings(t *testing.T) {
 	}
 }
 
+// preconEigenvalues returns the eigenvalues of the Jacobi-preconditioned
+// symmetric matrix of tc in ascending order.
+func preconEigenvalues(tc testCase) []float64 {
+	n := len(tc.b)
+	a := mat.NewSymDense(n, nil)
+	e := mat.NewVecDense(n, nil)
+	col := mat.NewVecDense(n, nil)
+	for j := 0; j < n; j++ {
+		e.Zero()
+		e.SetVec(j, 1)
+		tc.mulVecTo(col, false, e)
+		for i := 0; i <= j; i++ {
+			v := col.AtVec(i)
+			if tc.diag != nil {
+				// D^{-1/2} * A * D^{-1/2} is similar to D^{-1} * A.
+				v /= math.Sqrt(tc.diag[i] * tc.diag[j])
+			}
+			a.SetSym(i, j, v)
+		}
+	}
+	var eig mat.EigenSym
+	if !eig.Factorize(a, false) {
+		panic("bad test")
+	}
+	return eig.Values(nil)
+}
+
+func TestChebyshev(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, tc := range spdTestCases(rnd) {
+		eig := preconEigenvalues(tc)
+		m := &Chebyshev{
+			MinEig: 0.9 * eig[0],
+			MaxEig: 1.1 * eig[len(eig)-1],
+		}
+		s := newTestSettings(rnd, tc)
+		s.MaxIterations = 5000
+		testMethodWithSettings(t, m, s, tc)
+	}
+}
+
+func TestEigenvalueBounds(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, tc := range spdTestCases(rnd) {
+		eig := preconEigenvalues(tc)
+		wantMin, wantMax := eig[0], eig[len(eig)-1]
+		b := mat.NewVecDense(len(tc.b), tc.b)
+		for _, m := range []EigenvalueEstimator{&CG{}, &GMRES{}} {
+			_, err := Iterative(&tc, b, m, &Settings{
+				Tolerance:   tc.tol,
+				PreconSolve: tc.PreconSolve,
+			})
+			if err != nil {
+				t.Errorf("%v: %T: unexpected error: %v", tc.name, m, err)
+				continue
+			}
+			// The Ritz values lie inside the spectrum and the extreme
+			// ones converge first, although the right-hand side may
+			// not excite all eigenvectors equally.
+			min, max := m.EigenvalueBounds()
+			tol := 1e-6 * wantMax
+			if min < wantMin-tol || wantMin+1e-2*wantMax < min || max > wantMax+tol || max < 0.98*wantMax {
+				t.Errorf("%v: %T: unexpected eigenvalue bounds [%v,%v], want [%v,%v]", tc.name, m, min, max, wantMin, wantMax)
+			}
+		}
+	}
+
+	panicked := func() (panicked bool) {
+		defer func() {
+			panicked = recover() != nil
+		}()
+		(&CG{}).EigenvalueBounds()
+		return false
+	}()
+	if !panicked {
+		t.Errorf("EigenvalueBounds without iterations did not panic")
+	}
+}
+
+func TestSpectrum(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, tc := range spdTestCases(rnd) {
+		eig := preconEigenvalues(tc)
+		ratio := eig[len(eig)-1] / eig[0]
+		// M^{-1}*A is not symmetric with Jacobi preconditioning, so
+		// the ratio of its extreme eigenvalues is only a lower bound on
+		// its condition number.
+		n := len(tc.b)
+		a := mat.NewDense(n, n, nil)
+		for j := 0; j < n; j++ {
+			e := mat.NewVecDense(n, nil)
+			e.SetVec(j, 1)
+			tc.mulVecTo(a.ColView(j).(*mat.VecDense), false, e)
+		}
+		if tc.diag != nil {
+			for i := 0; i < n; i++ {
+				row := a.RawRowView(i)
+				for j := range row {
+					row[j] /= tc.diag[i]
+				}
+			}
+		}
+		cond := mat.Cond(a, 2)
+		b := mat.NewVecDense(n, tc.b)
+		for _, m := range []Method{&CG{}, &GMRES{}, &BiCGStab{}} {
+			settings := &Settings{
+				Tolerance:   tc.tol,
+				PreconSolve: tc.PreconSolve,
+				Spectrum:    true,
+			}
+			result, err := Iterative(&tc, b, m, settings)
+			if err != nil {
+				t.Errorf("%v: %T: unexpected error: %v", tc.name, m, err)
+				continue
+			}
+			e, ok := m.(EigenvalueEstimator)
+			if !ok {
+				if result.Spectrum != nil {
+					t.Errorf("%v: %T: unexpected spectrum estimate", tc.name, m)
+				}
+				continue
+			}
+			if result.Spectrum == nil {
+				t.Errorf("%v: %T: missing spectrum estimate", tc.name, m)
+				continue
+			}
+			min, max := e.EigenvalueBounds()
+			if result.Spectrum.MinEig != min || result.Spectrum.MaxEig != max {
+				t.Errorf("%v: %T: spectrum estimate does not match EigenvalueBounds", tc.name, m)
+			}
+			// The estimate is a lower bound on the condition number.
+			got := result.Spectrum.Condition
+			if got > cond*(1+1e-6) || got < ratio/2 {
+				t.Errorf("%v: %T: unexpected condition estimate %v, want in [%v,%v]", tc.name, m, got, ratio/2, cond)
+			}
+
+			settings.Spectrum = false
+			result, err = Iterative(&tc, b, m, settings)
+			if err != nil {
+				t.Errorf("%v: %T: unexpected error: %v", tc.name, m, err)
+				continue
+			}
+			if result.Spectrum != nil {
+				t.Errorf("%v: %T: unexpected spectrum estimate without Settings.Spectrum", tc.name, m)
+			}
+		}
+	}
+}
+
+func TestPipelinedCG(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	for _, tc := range testCases {
+		// The attainable accuracy of the recursively updated residual
+		// is lower than in CG.
+		tc.tol = math.Max(tc.tol, 1e-10)
+		s := newTestSettings(rnd, tc)
+		testMethodWithSettings(t, &PipelinedCG{}, s, tc)
+	}
+}
+
+func TestPipelinedCGDefaultSettings(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	for _, tc := range testCases {
+		testMethodWithSettings(t, &PipelinedCG{}, nil, tc)
+	}
+}
+
+// asyncReducer computes the dot products in a separate goroutine and checks
+// that PipelinedCG overlaps the reduction with a preconditioner solve and a
+// matrix-vector product.
+type asyncReducer struct {
+	t  *testing.T
+	tc *testCase
+
+	mulVec, preconSolve int
+	reductions          int
+}
+
+func (r *asyncReducer) MulVecTo(dst *mat.VecDense, trans bool, x mat.Vector) {
+	r.mulVec++
+	r.tc.MulVecTo(dst, trans, x)
+}
+
+func (r *asyncReducer) PreconSolve(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+	r.preconSolve++
+	return r.tc.PreconSolve(dst, trans, rhs)
+}
+
+func (r *asyncReducer) Reduce(dst []float64, x, y []*mat.VecDense) func() {
+	r.reductions++
+	mulVec, preconSolve := r.mulVec, r.preconSolve
+	done := make(chan []float64)
+	go func() {
+		dots := make([]float64, len(dst))
+		for i := range dots {
+			dots[i] = mat.Dot(x[i], y[i])
+		}
+		done <- dots
+	}()
+	return func() {
+		copy(dst, <-done)
+		if r.mulVec != mulVec+1 || r.preconSolve != preconSolve+1 {
+			r.t.Errorf("%v: reduction not overlapped with one MulVec and one PreconSolve", r.tc.name)
+		}
+	}
+}
+
+func TestPipelinedCGReducer(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, tc := range spdTestCases(rnd) {
+		tc.tol = math.Max(tc.tol, 1e-10)
+		b := mat.NewVecDense(len(tc.b), tc.b)
+		want, err := Iterative(&tc, b, &PipelinedCG{}, &Settings{
+			Tolerance:   tc.tol,
+			PreconSolve: tc.PreconSolve,
+		})
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		r := &asyncReducer{t: t, tc: &tc}
+		got, err := Iterative(r, b, &PipelinedCG{Reducer: r}, &Settings{
+			Tolerance:   tc.tol,
+			PreconSolve: r.PreconSolve,
+		})
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !mat.Equal(got.X, want.X) || got.Stats != want.Stats {
+			t.Errorf("%v: result with Reducer differs", tc.name)
+		}
+		// The first reduction starts before the first iteration.
+		if r.reductions != got.Stats.Iterations+1 {
+			t.Errorf("%v: unexpected number of reductions: got %v, want %v", tc.name, r.reductions, got.Stats.Iterations+1)
+		}
+	}
+}
+
+func TestPipelinedCGBreakdown(t *testing.T) {
+	// For the indefinite matrix [0 1; 1 0] and b = e_1 the search direction
+	// p_0 = r_0 satisfies p_0ᵀ A p_0 = 0.
+	a := sparse.NewCOO(2, 2)
+	a.Append(0, 1, 1)
+	a.Append(1, 0, 1)
+	b := mat.NewVecDense(2, []float64{1, 0})
+	result, err := Iterative(a, b, &PipelinedCG{}, nil)
+	var berr *BreakdownError
+	if !errors.As(err, &berr) {
+		t.Errorf("unexpected error: got %v, want breakdown", err)
+	}
+	if result == nil || !floats.Equal(result.X.RawVector().Data, []float64{0, 0}) {
+		t.Errorf("unexpected result after breakdown")
+	}
+}
+
 func TestBiCG(t *testing.T) {
 	rnd := rand.New(rand.NewSource(1))
 
@@ -217,88 +488,1676 @@ func TestGMRESDefaultSettings(t *testing.T) {
 	}
 }
 
-func newTestSettings(rnd *rand.Rand, tc testCase) *Settings {
-	n := len(tc.b)
+func TestMINRES(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
 
-	// Initial guess is a random vector.
-	initX := mat.NewVecDense(n, nil)
-	for i := 0; i < initX.Len(); i++ {
-		initX.SetVec(i, rnd.NormFloat64())
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases, symIndefTestCases(rnd)...)
+	for _, tc := range testCases {
+		s := newTestSettings(rnd, tc)
+		testMethodWithSettings(t, &MINRES{}, s, tc)
 	}
+}
 
-	// Allocate a destination vector and fill it with NaN.
-	dst := mat.NewVecDense(n, nil)
-	for i := 0; i < dst.Len(); i++ {
-		dst.SetVec(i, math.NaN())
-	}
+func TestMINRESDefaultSettings(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
 
-	// Preallocate a work context and fill it with NaN.
-	work := NewContext(n)
-	for i := 0; i < work.X.Len(); i++ {
-		work.X.SetVec(i, math.NaN())
-		work.Src.SetVec(i, math.NaN())
-		work.Dst.SetVec(i, math.NaN())
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases, symIndefTestCases(rnd)...)
+	for _, tc := range testCases {
+		testMethodWithSettings(t, &MINRES{}, nil, tc)
 	}
-	work.ResidualNorm = math.NaN()
+}
 
-	return &Settings{
-		InitX:         initX,
-		Dst:           dst,
-		Tolerance:     tc.tol,
-		MaxIterations: 5 * n,
-		PreconSolve:   tc.PreconSolve,
-		Work:          work,
+func TestMINRESIndefinitePreconditioner(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	tc := newRandomSPD(20, rnd)
+	b := mat.NewVecDense(len(tc.b), tc.b)
+	_, err := Iterative(&tc, b, &MINRES{}, &Settings{
+		PreconSolve: func(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+			dst.ScaleVec(-1, rhs)
+			return nil
+		},
+	})
+	if !errors.Is(err, ErrIndefinitePreconditioner) {
+		t.Errorf("unexpected error: got %v, want %v", err, ErrIndefinitePreconditioner)
 	}
 }
 
-func testMethodWithSettings(t *testing.T, m Method, s *Settings, tc testCase) {
-	wantTol := 1e-9
-	if s == nil {
-		// The default value of Settings.Tolerance is not as low as the tolerance in
-		// individual test cases, therefore we must use a higher tolerance for
-		// the expected accuracy of the computed solution.
-		wantTol = 1e-7
-	}
+func TestCGS(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
 
-	n := len(tc.b)
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+	)
+	for _, tc := range testCases {
+		s := newTestSettings(rnd, tc)
+		testMethodWithSettings(t, &CGS{}, s, noTrans(tc))
+	}
+}
 
-	b := make([]float64, n)
-	copy(b, tc.b)
-	bVec := mat.NewVecDense(n, b)
+func TestCGSDefaultSettings(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
 
-	result, err := Iterative(&tc, bVec, m, s)
-	if err != nil {
-		t.Errorf("%v: unexpected error %v", tc.name, err)
-		return
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+	)
+	for _, tc := range testCases {
+		testMethodWithSettings(t, &CGS{}, nil, noTrans(tc))
 	}
+}
 
-	if !floats.Equal(tc.b, b) {
-		t.Errorf("%v: unexpected modification of b", tc.name)
+func TestTFQMR(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+	)
+	for _, tc := range testCases {
+		s := newTestSettings(rnd, tc)
+		testMethodWithSettings(t, &TFQMR{}, s, noTrans(tc))
 	}
+}
 
-	wantVec := mat.NewVecDense(n, tc.want)
-	var diff mat.VecDense
-	diff.SubVec(result.X, wantVec)
-	dist := mat.Norm(&diff, 2) / mat.Norm(wantVec, 2)
-	if dist > wantTol {
-		t.Errorf("%v: unexpected solution, |want-got|/|want|=%v", tc.name, dist)
+func TestTFQMRDefaultSettings(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+	)
+	for _, tc := range testCases {
+		testMethodWithSettings(t, &TFQMR{}, nil, noTrans(tc))
 	}
+}
 
-	if s == nil {
-		return
+func TestIDRs(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+	)
+	for _, shadow := range []int{1, 2, 4, 8} {
+		for _, tc := range testCases {
+			if len(tc.b) < shadow {
+				continue
+			}
+			s := newTestSettings(rnd, tc)
+			testMethodWithSettings(t, &IDRs{S: shadow}, s, noTrans(tc))
+		}
 	}
+}
 
-	if s.MaxIterations > 0 && result.Stats.Iterations > s.MaxIterations {
-		t.Errorf("%v: Result.Stats.Iterations greater than Settings.MaxIterations", tc.name)
+func TestIDRsDefaultSettings(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+	)
+	for _, tc := range testCases {
+		testMethodWithSettings(t, &IDRs{}, nil, noTrans(tc))
 	}
+}
 
-	if s.Dst != nil {
-		if !mat.Equal(s.Dst, result.X) {
-			t.Errorf("%v: Settings.Dst and Result.X not equal\n%v\n%v", tc.name, s.Dst, result.X)
-		}
-		result.X.SetVec(0, 123456.7)
-		if s.Dst.AtVec(0) != result.X.AtVec(0) {
-			t.Errorf("%v: Settings.Dst and Result.X are not the same vector", tc.name)
+func TestFGMRES(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+		newPDENonsymmetric(16, 16, rnd),
+		newPDEYang47(16, 16, rnd),
+		newPDEYang48(16, 16, rnd),
+		newPDEYang49(16, 16, rnd),
+		newPDEYang410(16, 16, rnd),
+		newPDEYang412(16, 16, rnd),
+		newPDEYang413(16, 16, rnd),
+		newPDEYang414(16, 16, rnd),
+		newPDEYang415(16, 16, rnd),
+	)
+	for _, tc := range testCases {
+		s := newTestSettings(rnd, tc)
+		testMethodWithSettings(t, &FGMRES{}, s, noTrans(tc))
+	}
+}
+
+func TestFGMRESDefaultSettings(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+		newPDENonsymmetric(16, 16, rnd),
+		newPDEYang47(16, 16, rnd),
+		newPDEYang48(16, 16, rnd),
+		newPDEYang49(16, 16, rnd),
+		newPDEYang410(16, 16, rnd),
+		newPDEYang412(16, 16, rnd),
+		newPDEYang413(16, 16, rnd),
+		newPDEYang414(16, 16, rnd),
+		newPDEYang415(16, 16, rnd),
+	)
+	for _, tc := range testCases {
+		testMethodWithSettings(t, &FGMRES{}, nil, noTrans(tc))
+	}
+}
+
+func TestFGMRESVaryingPreconditioner(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+		newPDENonsymmetric(16, 16, rnd),
+		newPDEYang47(16, 16, rnd),
+		newPDEYang48(16, 16, rnd),
+		newPDEYang410(16, 16, rnd),
+		newPDEYang413(16, 16, rnd),
+		newPDEYang414(16, 16, rnd),
+		newPDEYang415(16, 16, rnd),
+	)
+	for _, tc := range testCases {
+		s := newTestSettings(rnd, tc)
+		// Use a few iterations of restarted GMRES as the preconditioner.
+		// Such preconditioner is a nonlinear function of the right-hand
+		// side.
+		s.PreconSolve = func(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+			if trans {
+				panic("unexpected transposed preconditioner solve")
+			}
+			b := mat.VecDenseCopyOf(rhs)
+			_, err := Iterative(&tc, b, &GMRES{Restart: min(3, b.Len())}, &Settings{
+				Dst:           dst,
+				Tolerance:     0.1,
+				MaxIterations: 1,
+			})
+			if err != nil && err != ErrIterationLimit {
+				return err
+			}
+			return nil
+		}
+		testMethodWithSettings(t, &FGMRES{}, s, tc)
+	}
+}
+
+func TestLGMRES(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+		newPDEYang47(16, 16, rnd),
+		newPDEYang48(16, 16, rnd),
+		newPDEYang410(16, 16, rnd),
+		newPDEYang413(16, 16, rnd),
+		newPDEYang414(16, 16, rnd),
+		newPDEYang415(16, 16, rnd),
+	)
+	for _, tc := range testCases {
+		s := newTestSettings(rnd, tc)
+		testMethodWithSettings(t, &LGMRES{}, s, noTrans(tc))
+	}
+}
+
+func TestLGMRESDefaultSettings(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	testCases := spdTestCases(rnd)
+	testCases = append(testCases,
+		nonsym3x3(),
+		nonsymTridiag(100),
+		newGreenbaum54(1, 1, rnd),
+		newGreenbaum54(1, 2, rnd),
+		newGreenbaum54(2, 4, rnd),
+		newGreenbaum54(10, 0, rnd),
+		newGreenbaum54(10, 20, rnd),
+		newGreenbaum54(50, 3, rnd),
+		newGreenbaum73(16, 16, rnd),
+		newPDEYang47(16, 16, rnd),
+		newPDEYang48(16, 16, rnd),
+		newPDEYang410(16, 16, rnd),
+		newPDEYang413(16, 16, rnd),
+		newPDEYang414(16, 16, rnd),
+		newPDEYang415(16, 16, rnd),
+	)
+	for _, tc := range testCases {
+		testMethodWithSettings(t, &LGMRES{}, nil, noTrans(tc))
+	}
+}
+
+func TestLGMRESAugmentation(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	const restart = 10
+	for _, tc := range []testCase{
+		newGreenbaum41(24, 0.001, 1, 0.8, rnd),
+		newPoisson2D(32, 32, one),
+		newPDEYang47(16, 16, rnd),
+	} {
+		b := mat.NewVecDense(len(tc.b), tc.b)
+		s := &Settings{Tolerance: 1e-10}
+		gmres, err := Iterative(&tc, b, &GMRES{Restart: restart}, s)
+		if err != nil {
+			t.Fatalf("%v: unexpected error from GMRES: %v", tc.name, err)
+		}
+		lgmres, err := Iterative(&tc, b, &LGMRES{Restart: restart}, s)
+		if err != nil {
+			t.Fatalf("%v: unexpected error from LGMRES: %v", tc.name, err)
+		}
+		if lgmres.Stats.MulVec >= gmres.Stats.MulVec {
+			t.Errorf("%v: LGMRES did not accelerate restarted GMRES, MulVec count %v >= %v",
+				tc.name, lgmres.Stats.MulVec, gmres.Stats.MulVec)
+		}
+	}
+}
+
+func newTestSettings(rnd *rand.Rand, tc testCase) *Settings {
+	n := len(tc.b)
+
+	// Initial guess is a random vector.
+	initX := mat.NewVecDense(n, nil)
+	for i := 0; i < initX.Len(); i++ {
+		initX.SetVec(i, rnd.NormFloat64())
+	}
+
+	// Allocate a destination vector and fill it with NaN.
+	dst := mat.NewVecDense(n, nil)
+	for i := 0; i < dst.Len(); i++ {
+		dst.SetVec(i, math.NaN())
+	}
+
+	// Preallocate a work context and fill it with NaN.
+	work := NewContext(n)
+	for i := 0; i < work.X.Len(); i++ {
+		work.X.SetVec(i, math.NaN())
+		work.Src.SetVec(i, math.NaN())
+		work.Dst.SetVec(i, math.NaN())
+	}
+	work.ResidualNorm = math.NaN()
+
+	return &Settings{
+		InitX:         initX,
+		Dst:           dst,
+		Tolerance:     tc.tol,
+		MaxIterations: 5 * n,
+		PreconSolve:   tc.PreconSolve,
+		Work:          work,
+	}
+}
+
+func testMethodWithSettings(t *testing.T, m Method, s *Settings, tc testCase) {
+	wantTol := 1e-9
+	if s == nil {
+		// The default value of Settings.Tolerance is not as low as the tolerance in
+		// individual test cases, therefore we must use a higher tolerance for
+		// the expected accuracy of the computed solution.
+		wantTol = 1e-7
+	}
+
+	n := len(tc.b)
+
+	b := make([]float64, n)
+	copy(b, tc.b)
+	bVec := mat.NewVecDense(n, b)
+
+	result, err := Iterative(&tc, bVec, m, s)
+	if err != nil {
+		t.Errorf("%v: unexpected error %v", tc.name, err)
+		return
+	}
+
+	if !floats.Equal(tc.b, b) {
+		t.Errorf("%v: unexpected modification of b", tc.name)
+	}
+
+	wantVec := mat.NewVecDense(n, tc.want)
+	var diff mat.VecDense
+	diff.SubVec(result.X, wantVec)
+	dist := mat.Norm(&diff, 2) / mat.Norm(wantVec, 2)
+	if dist > wantTol {
+		t.Errorf("%v: unexpected solution, |want-got|/|want|=%v", tc.name, dist)
+	}
+
+	if s == nil {
+		return
+	}
+
+	if s.MaxIterations > 0 && result.Stats.Iterations > s.MaxIterations {
+		t.Errorf("%v: Result.Stats.Iterations greater than Settings.MaxIterations", tc.name)
+	}
+
+	if s.Dst != nil {
+		if !mat.Equal(s.Dst, result.X) {
+			t.Errorf("%v: Settings.Dst and Result.X not equal\n%v\n%v", tc.name, s.Dst, result.X)
+		}
+		result.X.SetVec(0, 123456.7)
+		if s.Dst.AtVec(0) != result.X.AtVec(0) {
+			t.Errorf("%v: Settings.Dst and Result.X are not the same vector", tc.name)
+		}
+	}
+}
+
+// noTrans returns a copy of tc whose matrix-vector multiplication panics when
+// the transpose is requested.
+func noTrans(tc testCase) testCase {
+	mulVecTo := tc.mulVecTo
+	tc.mulVecTo = func(dst *mat.VecDense, trans bool, x mat.Vector) {
+		if trans {
+			panic("unexpected multiplication with the transpose")
+		}
+		mulVecTo(dst, false, x)
+	}
+	return tc
+}
+
+func TestBlockCG(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	const k = 4
+	for _, tc := range spdTestCases(rnd) {
+		n := len(tc.b)
+		// The right-hand sides are the right-hand side of the test case,
+		// a random vector, a duplicate of the first column and the zero
+		// vector.
+		b := mat.NewDense(n, k, nil)
+		b.SetCol(0, tc.b)
+		for i := 0; i < n; i++ {
+			b.Set(i, 1, rnd.NormFloat64())
+		}
+		b.SetCol(2, tc.b)
+		bCopy := mat.DenseCopyOf(b)
+
+		initX := mat.NewDense(n, k, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < k; j++ {
+				initX.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		// Use the same initial guess for the duplicate columns.
+		initX.SetCol(2, mat.Col(nil, 0, initX))
+		dst := mat.NewDense(n, k, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < k; j++ {
+				dst.Set(i, j, math.NaN())
+			}
+		}
+		s := &BlockSettings{
+			InitX:         initX,
+			Dst:           dst,
+			Tolerance:     tc.tol,
+			MaxIterations: 5 * n,
+			PreconSolve:   tc.PreconSolve,
+		}
+		result, err := IterativeBlock(&tc, b, &BlockCG{}, s)
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", tc.name, err)
+			continue
+		}
+		if !mat.Equal(b, bCopy) {
+			t.Errorf("%v: unexpected modification of B", tc.name)
+		}
+		if result.X != dst {
+			t.Errorf("%v: BlockSettings.Dst and BlockResult.X are not the same matrix", tc.name)
+		}
+
+		want := mat.NewVecDense(n, tc.want)
+		for j := 0; j < k; j++ {
+			x := mat.VecDenseCopyOf(result.X.ColView(j))
+			if j == 0 || j == 2 {
+				var diff mat.VecDense
+				diff.SubVec(x, want)
+				dist := mat.Norm(&diff, 2) / mat.Norm(want, 2)
+				if dist > 1e-9 {
+					t.Errorf("%v: unexpected solution for column %d, |want-got|/|want|=%v", tc.name, j, dist)
+				}
+				continue
+			}
+			bj := mat.VecDenseCopyOf(b.ColView(j))
+			var r mat.VecDense
+			r.ReuseAsVec(n)
+			tc.MulVecTo(&r, false, x)
+			r.SubVec(bj, &r)
+			bNorm := mat.Norm(bj, 2)
+			if bNorm == 0 {
+				bNorm = 1
+			}
+			if res := mat.Norm(&r, 2) / bNorm; res > 100*tc.tol {
+				t.Errorf("%v: unexpected residual for column %d, |b-A*x|/|b|=%v", tc.name, j, res)
+			}
+		}
+
+		// Solving for the right-hand side of the test case alone must
+		// not take fewer iterations.
+		cg, err := Iterative(&tc, mat.NewVecDense(n, tc.b), &CG{}, &Settings{
+			InitX:       mat.VecDenseCopyOf(initX.ColView(0)),
+			Tolerance:   tc.tol,
+			PreconSolve: tc.PreconSolve,
+		})
+		if err != nil {
+			t.Errorf("%v: unexpected error from CG %v", tc.name, err)
+			continue
+		}
+		if result.Stats.Iterations > cg.Stats.Iterations {
+			t.Errorf("%v: BlockCG needed more iterations than CG: %v > %v",
+				tc.name, result.Stats.Iterations, cg.Stats.Iterations)
+		}
+	}
+}
+
+// mulMatCounter is a matrix that can be multiplied only with several vectors
+// at once.
+type mulMatCounter struct {
+	a      *sparse.CSR
+	mulMat int
+}
+
+func (m *mulMatCounter) MulVecTo(*mat.VecDense, bool, mat.Vector) {
+	panic("unexpected matrix-vector multiplication")
+}
+
+func (m *mulMatCounter) MulMatTo(dst *mat.Dense, trans bool, x mat.Matrix) {
+	m.mulMat++
+	m.a.MulMatTo(dst, trans, x)
+}
+
+func TestBlockCGMulMatTo(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	const (
+		n = 100
+		k = 5
+	)
+	// The matrix is the 1D Laplacian.
+	coo := sparse.NewCOO(n, n)
+	for i := 0; i < n; i++ {
+		coo.Append(i, i, 2)
+		if i > 0 {
+			coo.Append(i, i-1, -1)
+			coo.Append(i-1, i, -1)
+		}
+	}
+	a := &mulMatCounter{a: coo.ToCSR()}
+	b := mat.NewDense(n, k, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < k; j++ {
+			b.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	result, err := IterativeBlock(a, b, &BlockCG{}, &BlockSettings{Tolerance: 1e-10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Stats.MulVec != a.mulMat {
+		t.Errorf("unexpected number of MulVec operations: got %v, want %v", result.Stats.MulVec, a.mulMat)
+	}
+	// In exact arithmetic block CG converges in at most n/k iterations.
+	if result.Stats.Iterations > 2*n/k {
+		t.Errorf("unexpected number of iterations: %v", result.Stats.Iterations)
+	}
+	var r mat.Dense
+	r.Mul(mat.DenseCopyOf(coo), result.X)
+	r.Sub(b, &r)
+	for j := 0; j < k; j++ {
+		res := mat.Norm(r.ColView(j), 2) / mat.Norm(b.ColView(j), 2)
+		if res > 1e-9 {
+			t.Errorf("unexpected residual for column %d: %v", j, res)
+		}
+	}
+}
+
+func TestDeflatedCG(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	// The same DeflatedCG value is used for all test cases so that the
+	// deflation subspace obtained from one test case is used with the
+	// matrix of the next test case of the same dimension.
+	dcg := &DeflatedCG{Deflation: 4}
+	testCases := spdTestCases(rnd)
+	for _, tc := range testCases {
+		s := newTestSettings(rnd, tc)
+		testMethodWithSettings(t, dcg, s, tc)
+	}
+	for _, tc := range testCases {
+		testMethodWithSettings(t, dcg, nil, tc)
+	}
+}
+
+func TestDeflatedCGRecycling(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	// The matrices are the 2D Laplacian with a slowly changing positive
+	// diagonal shift.
+	const (
+		nx = 32
+		n  = nx * nx
+	)
+	shift := make([]float64, n)
+	for i := range shift {
+		shift[i] = 1e-3 * rnd.Float64()
+	}
+	dcg := &DeflatedCG{}
+	for step := 0; step < 6; step++ {
+		coo := sparse.NewCOO(n, n)
+		for i := 0; i < nx; i++ {
+			for j := 0; j < nx; j++ {
+				k := i*nx + j
+				coo.Append(k, k, 4+float64(step)*shift[k])
+				if i > 0 {
+					coo.Append(k, k-nx, -1)
+					coo.Append(k-nx, k, -1)
+				}
+				if j > 0 {
+					coo.Append(k, k-1, -1)
+					coo.Append(k-1, k, -1)
+				}
+			}
+		}
+		a := coo.ToCSR()
+		b := mat.NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			b.SetVec(i, rnd.NormFloat64())
+		}
+		s := &Settings{Tolerance: 1e-10, MaxIterations: 10 * n}
+		got, err := Iterative(a, b, dcg, s)
+		if err != nil {
+			t.Fatalf("step %d: unexpected error from DeflatedCG: %v", step, err)
+		}
+		var r mat.VecDense
+		r.MulVec(a, got.X)
+		r.SubVec(b, &r)
+		if res := mat.Norm(&r, 2) / mat.Norm(b, 2); res > 1e-9 {
+			t.Errorf("step %d: unexpected residual %v", step, res)
+		}
+
+		cg, err := Iterative(a, b, &CG{}, s)
+		if err != nil {
+			t.Fatalf("step %d: unexpected error from CG: %v", step, err)
+		}
+		if step == 0 {
+			if got.Stats.Iterations != cg.Stats.Iterations {
+				t.Errorf("step %d: first solve differs from CG, iterations %v != %v",
+					step, got.Stats.Iterations, cg.Stats.Iterations)
+			}
+			continue
+		}
+		if 5*got.Stats.MulVec > 4*cg.Stats.MulVec {
+			t.Errorf("step %d: recycling did not reduce the number of MulVec operations, %v vs %v for CG",
+				step, got.Stats.MulVec, cg.Stats.MulVec)
+		}
+	}
+}
+
+// cancelAfter is a matrix that cancels a context after a given number of
+// matrix-vector multiplications.
+type cancelAfter struct {
+	testCase
+	n      int
+	cancel context.CancelFunc
+}
+
+func (c *cancelAfter) MulVecTo(dst *mat.VecDense, trans bool, x mat.Vector) {
+	c.n--
+	if c.n == 0 {
+		c.cancel()
+	}
+	c.testCase.MulVecTo(dst, trans, x)
+}
+
+func TestIterativeContext(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, m := range []struct {
+		name   string
+		method func() Method
+	}{
+		{"CG", func() Method { return &CG{} }},
+		{"BiCGStab", func() Method { return &BiCGStab{} }},
+		{"GMRES", func() Method { return &GMRES{Restart: 5} }},
+		{"MINRES", func() Method { return &MINRES{} }},
+	} {
+		tc := newPoisson2D(32, 32, one)
+		n := len(tc.b)
+		b := mat.NewVecDense(n, tc.b)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		a := &cancelAfter{testCase: tc, n: 20, cancel: cancel}
+		dst := mat.NewVecDense(n, nil)
+		result, err := IterativeContext(ctx, a, b, m.method(), &Settings{Dst: dst})
+		cancel()
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%v: unexpected error: got %v, want %v", m.name, err, context.Canceled)
+			continue
+		}
+		if result.X != dst {
+			t.Errorf("%v: Settings.Dst and Result.X are not the same vector", m.name)
+		}
+		if result.Stats.MulVec > 30 {
+			t.Errorf("%v: iteration not stopped after cancellation, MulVec=%v", m.name, result.Stats.MulVec)
+		}
+		if result.ResidualNorm >= mat.Norm(b, 2) {
+			t.Errorf("%v: returned iterate is not better than the initial guess", m.name)
+		}
+		var r mat.VecDense
+		r.ReuseAsVec(n)
+		tc.MulVecTo(&r, false, result.X)
+		r.SubVec(b, &r)
+		if diff := math.Abs(mat.Norm(&r, 2) - result.ResidualNorm); diff > 1e-8*mat.Norm(b, 2) {
+			t.Errorf("%v: residual norm does not correspond to the returned iterate, difference %v", m.name, diff)
+		}
+	}
+
+	// A context that is done before the call returns the initial guess.
+	tc := newPoisson2D(8, 8, one)
+	n := len(tc.b)
+	initX := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		initX.SetVec(i, rnd.NormFloat64())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	result, err := IterativeContext(ctx, &tc, mat.NewVecDense(n, tc.b), &CG{}, &Settings{InitX: initX})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("unexpected error: got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if !mat.Equal(result.X, initX) {
+		t.Errorf("unexpected solution for expired context")
+	}
+	if result.Stats.Iterations != 0 {
+		t.Errorf("unexpected number of iterations for expired context: %v", result.Stats.Iterations)
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	for _, m := range []struct {
+		name   string
+		method func() Method
+	}{
+		{"CG", func() Method { return &CG{} }},
+		{"GMRES", func() Method { return &GMRES{Restart: 8} }},
+		{"TFQMR", func() Method { return &TFQMR{} }},
+	} {
+		tc := newPoisson2D(8, 8, one)
+		n := len(tc.b)
+		b := mat.NewVecDense(n, tc.b)
+
+		var iters []int
+		var norms []float64
+		s := &Settings{
+			Tolerance: tc.tol,
+			Monitor: func(iter int, resNorm float64, x mat.Vector) error {
+				if x.Len() != n {
+					t.Errorf("%v: unexpected length of x: %v", m.name, x.Len())
+				}
+				iters = append(iters, iter)
+				norms = append(norms, resNorm)
+				return nil
+			},
+			ResidualHistory: true,
+		}
+		result, err := Iterative(&tc, b, m.method(), s)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", m.name, err)
+			continue
+		}
+		if len(iters) != result.Stats.Iterations {
+			t.Errorf("%v: Monitor called %v times, want %v", m.name, len(iters), result.Stats.Iterations)
+		}
+		for i, iter := range iters {
+			if iter != i+1 {
+				t.Errorf("%v: unexpected iteration number %v, want %v", m.name, iter, i+1)
+				break
+			}
+		}
+		hist := result.ResidualHistory
+		if len(hist) != result.Stats.Iterations+1 {
+			t.Errorf("%v: unexpected length of residual history: got %v, want %v",
+				m.name, len(hist), result.Stats.Iterations+1)
+			continue
+		}
+		if hist[0] != mat.Norm(b, 2) {
+			t.Errorf("%v: unexpected initial residual norm in history", m.name)
+		}
+		if !floats.Equal(hist[1:], norms) {
+			t.Errorf("%v: residual history does not match values passed to Monitor", m.name)
+		}
+		if hist[len(hist)-1] != result.ResidualNorm {
+			t.Errorf("%v: last residual norm in history differs from Result.ResidualNorm", m.name)
+		}
+
+		// Stop early.
+		errStop := errors.New("stop")
+		const stopAt = 3
+		var want *mat.VecDense
+		s = &Settings{
+			Tolerance: tc.tol,
+			Monitor: func(iter int, resNorm float64, x mat.Vector) error {
+				if iter == stopAt {
+					want = mat.VecDenseCopyOf(x)
+					return errStop
+				}
+				return nil
+			},
+		}
+		result, err = Iterative(&tc, b, m.method(), s)
+		if err != errStop {
+			t.Errorf("%v: unexpected error: got %v, want %v", m.name, err, errStop)
+			continue
+		}
+		if result.Stats.Iterations != stopAt {
+			t.Errorf("%v: unexpected number of iterations: got %v, want %v", m.name, result.Stats.Iterations, stopAt)
+		}
+		if !mat.Equal(result.X, want) {
+			t.Errorf("%v: unexpected solution after stopping", m.name)
+		}
+		if result.ResidualHistory != nil {
+			t.Errorf("%v: unexpected residual history", m.name)
+		}
+	}
+}
+
+func TestStoppingCriterion(t *testing.T) {
+	tc := newPoisson2D(16, 16, one)
+	n := len(tc.b)
+	b := mat.NewVecDense(n, tc.b)
+	bNorm := mat.Norm(b, 2)
+
+	// Form A explicitly for computing its norm and the exact solution.
+	a := mat.NewDense(n, n, nil)
+	e := mat.NewVecDense(n, nil)
+	col := mat.NewVecDense(n, nil)
+	for j := 0; j < n; j++ {
+		e.SetVec(j, 1)
+		tc.MulVecTo(col, false, e)
+		a.SetCol(j, col.RawVector().Data)
+		e.SetVec(j, 0)
+	}
+	aNorm := mat.Norm(a, 2)
+	var want mat.VecDense
+	err := want.SolveVec(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error from dense solve: %v", err)
+	}
+	// energyNorm returns the A-norm of want-x.
+	energyNorm := func(x mat.Vector) float64 {
+		var d, ad mat.VecDense
+		d.SubVec(&want, x)
+		ad.MulVec(a, &d)
+		return math.Sqrt(mat.Dot(&d, &ad))
+	}
+	initErr := energyNorm(mat.NewVecDense(n, nil))
+
+	for _, test := range []struct {
+		name string
+		stop func() StoppingCriterion
+		// satisfied reports whether the residual norm
+		// satisfies the criterion.
+		satisfied func(resNorm float64) bool
+	}{
+		{
+			name:      "RelativeResidual",
+			stop:      func() StoppingCriterion { return &RelativeResidual{Tolerance: 1e-6} },
+			satisfied: func(resNorm float64) bool { return resNorm < 1e-6*bNorm },
+		},
+		{
+			name:      "AbsoluteResidual",
+			stop:      func() StoppingCriterion { return &AbsoluteResidual{Tolerance: 1e-4} },
+			satisfied: func(resNorm float64) bool { return resNorm < 1e-4 },
+		},
+		{
+			name: "RelativeInitialResidual",
+			stop: func() StoppingCriterion { return &RelativeInitialResidual{Tolerance: 1e-5} },
+			// The initial guess is zero, so r_0 = b.
+			satisfied: func(resNorm float64) bool { return resNorm < 1e-5*bNorm },
+		},
+	} {
+		for _, m := range []struct {
+			name   string
+			method func() Method
+		}{
+			{"CG", func() Method { return &CG{} }},
+			{"GMRES", func() Method { return &GMRES{Restart: 10} }},
+		} {
+			result, err := Iterative(&tc, b, m.method(), &Settings{Stop: test.stop(), ResidualHistory: true})
+			if err != nil {
+				t.Errorf("%v,%v: unexpected error: %v", test.name, m.name, err)
+				continue
+			}
+			hist := result.ResidualHistory
+			if !test.satisfied(hist[len(hist)-1]) {
+				t.Errorf("%v,%v: criterion not satisfied by the final residual norm %v", test.name, m.name, hist[len(hist)-1])
+			}
+			if test.satisfied(hist[len(hist)-2]) {
+				t.Errorf("%v,%v: iteration not stopped when the criterion was satisfied", test.name, m.name)
+			}
+		}
+	}
+
+	// The backward error of the solution must be below the tolerance.
+	const tol = 1e-10
+	for _, m := range []struct {
+		name   string
+		method func() Method
+	}{
+		{"CG", func() Method { return &CG{} }},
+		{"BiCGStab", func() Method { return &BiCGStab{} }},
+		{"MINRES", func() Method { return &MINRES{} }},
+	} {
+		result, err := Iterative(&tc, b, m.method(), &Settings{Stop: &BackwardError{ANorm: aNorm, Tolerance: tol}})
+		if err != nil {
+			t.Errorf("BackwardError,%v: unexpected error: %v", m.name, err)
+			continue
+		}
+		var r mat.VecDense
+		r.MulVec(a, result.X)
+		r.SubVec(b, &r)
+		berr := mat.Norm(&r, 2) / (aNorm*mat.Norm(result.X, 2) + bNorm)
+		if berr > 2*tol {
+			t.Errorf("BackwardError,%v: backward error %v exceeds tolerance %v", m.name, berr, tol)
+		}
+	}
+
+	// The A-norm of the error must be below the tolerance.
+	for _, tol := range []float64{1e-4, 1e-8} {
+		for _, m := range []struct {
+			name   string
+			method Method
+		}{
+			{"CG", &CG{}},
+			{"DeflatedCG", &DeflatedCG{}},
+		} {
+			// Solve twice so that DeflatedCG uses the recycled
+			// subspace in the second solve.
+			for i := 0; i < 2; i++ {
+				result, err := Iterative(&tc, b, m.method, &Settings{Stop: &EnergyNorm{Tolerance: tol}})
+				if err != nil {
+					t.Errorf("EnergyNorm,%v,tol=%v: unexpected error: %v", m.name, tol, err)
+					continue
+				}
+				relErr := energyNorm(result.X) / initErr
+				if relErr > tol {
+					t.Errorf("EnergyNorm,%v,tol=%v: relative A-norm of the error %v exceeds tolerance", m.name, tol, relErr)
+				}
+			}
+		}
+	}
+
+	// The initial guess is checked by the stopping criterion, not by
+	// Settings.Tolerance.
+	for _, test := range []struct {
+		name  string
+		scale float64
+		stop  StoppingCriterion
+		iter  bool
+	}{
+		{"satisfied", 1, &AbsoluteResidual{Tolerance: 2 * bNorm}, false},
+		{"not satisfied", 1e-12, &AbsoluteResidual{Tolerance: 1e-20}, true},
+	} {
+		var bs mat.VecDense
+		bs.ScaleVec(test.scale, b)
+		result, err := Iterative(&tc, &bs, &CG{}, &Settings{Stop: test.stop})
+		if err != nil {
+			t.Errorf("initial guess %v: unexpected error: %v", test.name, err)
+			continue
+		}
+		if iter := result.Stats.Iterations > 0; iter != test.iter {
+			t.Errorf("initial guess %v: unexpected iterations: got %v, want %v", test.name, iter, test.iter)
+		}
+	}
+
+	// DeflatedCG does not decrease the A-norm of the error in the projection
+	// onto a recycled subspace that is orthogonal to the residual.
+	diag := newRandomDiagonal(10, rand.New(rand.NewSource(1)))
+	dcg := &DeflatedCG{}
+	for _, nz := range [][]int{{0, 1}, {5, 7}} {
+		rhs := mat.NewVecDense(10, nil)
+		for _, i := range nz {
+			rhs.SetVec(i, 1)
+		}
+		_, err := Iterative(&diag, rhs, dcg, &Settings{Stop: &EnergyNorm{Tolerance: 1e-8}})
+		if err != nil {
+			t.Errorf("EnergyNorm,DeflatedCG,orthogonal subspace: unexpected error: %v", err)
+		}
+	}
+
+	// EnergyNorm uses the current Delay when it is reused.
+	en := &EnergyNorm{Tolerance: 1e-8, Delay: 8}
+	en.Init(1, 1)
+	en.Delay = 2
+	en.Init(1, 1)
+	if cap(en.terms) != 2 {
+		t.Errorf("EnergyNorm: unexpected window size after reuse: got %v, want 2", cap(en.terms))
+	}
+
+	// EnergyNorm panics with methods that do not provide the decrease of the
+	// A-norm of the error.
+	panicked := func() (panicked bool) {
+		defer func() {
+			panicked = recover() != nil
+		}()
+		_, _ = Iterative(&tc, b, &GMRES{}, &Settings{Stop: &EnergyNorm{Tolerance: 1e-8}})
+		return false
+	}()
+	if !panicked {
+		t.Errorf("EnergyNorm with GMRES did not panic")
+	}
+}
+
+func TestCGMRES(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tc := range complexTestCases(rnd) {
+		n := len(tc.b)
+		for _, restart := range []int{0, 5, 20} {
+			if restart > n {
+				continue
+			}
+			testCMethodWithSettings(t, &CGMRES{Restart: restart}, newTestCSettings(rnd, tc), tc)
+		}
+		testCMethodWithSettings(t, nil, nil, tc)
+	}
+}
+
+func TestCBiCGStab(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tc := range complexTestCases(rnd) {
+		testCMethodWithSettings(t, &CBiCGStab{}, newTestCSettings(rnd, tc), tc)
+		testCMethodWithSettings(t, &CBiCGStab{}, nil, tc)
+	}
+}
+
+func TestCOCG(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tc := range complexSymTestCases(rnd) {
+		testCMethodWithSettings(t, &COCG{}, newTestCSettings(rnd, tc), tc)
+		testCMethodWithSettings(t, &COCG{}, nil, tc)
+	}
+}
+
+func newTestCSettings(rnd *rand.Rand, tc cTestCase) *CSettings {
+	n := len(tc.b)
+
+	// Initial guess is a random vector.
+	initX := mat.NewCDense(n, 1, nil)
+	for i := 0; i < n; i++ {
+		initX.Set(i, 0, complex(rnd.NormFloat64(), rnd.NormFloat64()))
+	}
+
+	// Allocate a destination vector and fill it with NaN.
+	dst := mat.NewCDense(n, 1, nil)
+	nan := complex(math.NaN(), math.NaN())
+	for i := 0; i < n; i++ {
+		dst.Set(i, 0, nan)
+	}
+
+	// Preallocate a work context and fill it with NaN.
+	work := NewCContext(n)
+	for i := 0; i < n; i++ {
+		work.X.Set(i, 0, nan)
+		work.Src.Set(i, 0, nan)
+		work.Dst.Set(i, 0, nan)
+	}
+	work.ResidualNorm = math.NaN()
+
+	return &CSettings{
+		InitX:         initX,
+		Dst:           dst,
+		Tolerance:     tc.tol,
+		MaxIterations: 5 * n,
+		PreconSolve:   tc.PreconSolve,
+		Work:          work,
+	}
+}
+
+func testCMethodWithSettings(t *testing.T, m CMethod, s *CSettings, tc cTestCase) {
+	wantTol := 1e-9
+	if s == nil {
+		// The default value of CSettings.Tolerance is not as low as the
+		// tolerance in individual test cases, therefore we must use a
+		// higher tolerance for the expected accuracy of the computed
+		// solution.
+		wantTol = 1e-7
+	}
+
+	n := len(tc.b)
+	b := mat.NewCDense(n, 1, append([]complex128(nil), tc.b...))
+
+	result, err := CIterative(&tc, b, m, s)
+	if err != nil {
+		t.Errorf("%v: unexpected error %v", tc.name, err)
+		return
+	}
+
+	for i, v := range tc.b {
+		if b.At(i, 0) != v {
+			t.Errorf("%v: unexpected modification of b", tc.name)
+			break
+		}
+	}
+
+	var dist, norm float64
+	for i, v := range tc.want {
+		d := cmplx.Abs(result.X.At(i, 0) - v)
+		dist += d * d
+		norm += real(v)*real(v) + imag(v)*imag(v)
+	}
+	dist = math.Sqrt(dist / norm)
+	if dist > wantTol {
+		t.Errorf("%v: unexpected solution, |want-got|/|want|=%v", tc.name, dist)
+	}
+
+	if s == nil {
+		return
+	}
+
+	if s.MaxIterations > 0 && result.Stats.Iterations > s.MaxIterations {
+		t.Errorf("%v: Result.Stats.Iterations greater than Settings.MaxIterations", tc.name)
+	}
+
+	if s.Dst != nil {
+		if !mat.CEqual(s.Dst, result.X) {
+			t.Errorf("%v: Settings.Dst and Result.X not equal", tc.name)
+		}
+		result.X.Set(0, 0, 123456.7)
+		if s.Dst.At(0, 0) != result.X.At(0, 0) {
+			t.Errorf("%v: Settings.Dst and Result.X are not the same vector", tc.name)
+		}
+	}
+}
+
+func TestIterative32(t *testing.T) {
+	coo, rhs, diag := newPDESystem2D(16, 16, negOne, negOne, zero, zero, zero, one)
+	a := coo.ToCSR()
+	a32 := sparse.NewCSR32(a)
+	n := len(rhs)
+	b := make([]float32, n)
+	for i, v := range rhs {
+		b[i] = float32(v)
+	}
+	jacobi := func(dst []float32, trans bool, rhs []float32) error {
+		for i, v := range rhs {
+			dst[i] = v / float32(diag[i])
+		}
+		return nil
+	}
+	initX := make([]float32, n)
+	for i := range initX {
+		initX[i] = 1
+	}
+
+	const tol = 1e-4
+	for _, m := range []struct {
+		name   string
+		method Method32
+	}{
+		{"CG32", &CG32{}},
+		{"GMRES32", &GMRES32{}},
+		{"GMRES32 restart", &GMRES32{Restart: 20}},
+		{"Default", nil},
+	} {
+		for _, init := range [][]float32{nil, initX} {
+			dst := make([]float32, n)
+			result, err := Iterative32(a32, b, m.method, &Settings32{
+				InitX:       init,
+				Dst:         dst,
+				Tolerance:   tol,
+				PreconSolve: jacobi,
+			})
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", m.name, err)
+				continue
+			}
+			if &result.X[0] != &dst[0] {
+				t.Errorf("%v: Settings32.Dst and Result32.X are not the same vector", m.name)
+			}
+			// The residual of the single precision solution computed in
+			// double precision is close to the tolerance. GMRES32 checks
+			// the norm of the preconditioned residual, which differs from
+			// the residual norm by a moderate factor.
+			x := mat.NewVecDense(n, nil)
+			for i, v := range result.X {
+				x.SetVec(i, float64(v))
+			}
+			r := mat.NewVecDense(n, nil)
+			a.MulVecTo(r, false, x)
+			r.SubVec(mat.NewVecDense(n, rhs), r)
+			if rNorm := mat.Norm(r, 2) / floats.Norm(rhs, 2); rNorm > 10*tol {
+				t.Errorf("%v: unexpected relative residual norm %v", m.name, rNorm)
+			}
+		}
+	}
+}
+
+func TestRefine(t *testing.T) {
+	coo, rhs, diag := newPDESystem2D(16, 16, negOne, negOne, zero, zero, zero, one)
+	a := coo.ToCSR()
+	a32 := sparse.NewCSR32(a)
+	n := len(rhs)
+	b := mat.NewVecDense(n, rhs)
+	bNorm := mat.Norm(b, 2)
+	jacobi := func(dst []float32, trans bool, rhs []float32) error {
+		for i, v := range rhs {
+			dst[i] = v / float32(diag[i])
+		}
+		return nil
+	}
+
+	var want mat.VecDense
+	err := want.SolveVec(mat.DenseCopyOf(a), b)
+	if err != nil {
+		t.Fatalf("unexpected error from dense solve: %v", err)
+	}
+	relErr := func(x mat.Vector) float64 {
+		var d mat.VecDense
+		d.SubVec(&want, x)
+		return mat.Norm(&d, 2) / mat.Norm(&want, 2)
+	}
+
+	// The single precision solve alone cannot attain double precision
+	// accuracy.
+	b32 := make([]float32, n)
+	for i, v := range rhs {
+		b32[i] = float32(v)
+	}
+	res32, _ := Iterative32(a32, b32, &CG32{}, &Settings32{Tolerance: 1e-12, MaxIterations: 200})
+	x32 := mat.NewVecDense(n, nil)
+	for i, v := range res32.X {
+		x32.SetVec(i, float64(v))
+	}
+	if e := relErr(x32); e < 1e-9 {
+		t.Errorf("unexpected accuracy of single precision solve: %v", e)
+	}
+
+	const tol = 1e-12
+	for _, m := range []struct {
+		name   string
+		method Method32
+	}{
+		{"CG32", &CG32{}},
+		{"GMRES32", &GMRES32{Restart: 20}},
+		{"Default", nil},
+	} {
+		dst := mat.NewVecDense(n, nil)
+		result, err := Refine(a, a32, b, m.method, &RefineSettings{
+			Dst:       dst,
+			Tolerance: tol,
+			Inner:     Settings32{PreconSolve: jacobi},
+		})
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", m.name, err)
+			continue
+		}
+		if result.X != dst {
+			t.Errorf("%v: Settings.Dst and Result.X are not the same vector", m.name)
+		}
+		if result.Refinements < 2 {
+			t.Errorf("%v: unexpected number of refinements: %v", m.name, result.Refinements)
+		}
+		var r mat.VecDense
+		a.MulVecTo(&r, false, result.X)
+		r.SubVec(b, &r)
+		if rNorm := mat.Norm(&r, 2); rNorm >= tol*bNorm || rNorm != result.ResidualNorm {
+			t.Errorf("%v: unexpected residual norm %v, reported %v", m.name, rNorm, result.ResidualNorm)
+		}
+		if e := relErr(result.X); e > 1e-10 {
+			t.Errorf("%v: unexpected solution, |want-got|/|want|=%v", m.name, e)
+		}
+	}
+
+	// A wrong single precision matrix leads to stagnation.
+	neg := mulVecToer32Func(func(dst []float32, trans bool, x []float32) {
+		a32.MulVecTo32(dst, trans, x)
+		for i, v := range dst {
+			dst[i] = -v
+		}
+	})
+	result, err := Refine(a, neg, b, &GMRES32{}, nil)
+	if err != ErrStagnation {
+		t.Errorf("unexpected error: got %v, want %v", err, ErrStagnation)
+	}
+	if result.ResidualNorm != bNorm || mat.Norm(result.X, 2) != 0 {
+		t.Errorf("unexpected result after stagnation")
+	}
+}
+
+// mulVecToer32Func is a function that implements the MulVecToer32 interface.
+type mulVecToer32Func func(dst []float32, trans bool, x []float32)
+
+func (f mulVecToer32Func) MulVecTo32(dst []float32, trans bool, x []float32) {
+	f(dst, trans, x)
+}
+
+func TestBacktrack(t *testing.T) {
+	// The sufficient decrease condition fails for λ = 1 and λ = 1/2, so two
+	// reductions are needed. The condition and the forcing term of the
+	// accepted step use the forcing term of the inner solve.
+	const fNorm, eta = 2.0, 0.5
+	var lambdas []float64
+	normAt := func(lambda float64) float64 {
+		lambdas = append(lambdas, lambda)
+		if lambda > 0.3 {
+			return fNorm
+		}
+		return (1 - 1e-4*lambda*(1-eta)) * fNorm
+	}
+	lambda, etaNew, fNormNew, err := backtrack(normAt, fNorm, eta, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lambda != 0.25 || !floats.Equal(lambdas, []float64{1, 0.5, 0.25}) {
+		t.Errorf("unexpected step lengths: got %v, accepted %v", lambdas, lambda)
+	}
+	if want := 1 - 0.25*(1-eta); etaNew != want {
+		t.Errorf("unexpected forcing term: got %v, want %v", etaNew, want)
+	}
+	if want := (1 - 1e-4*0.25*(1-eta)) * fNorm; fNormNew != want {
+		t.Errorf("unexpected norm: got %v, want %v", fNormNew, want)
+	}
+
+	lambdas = lambdas[:0]
+	_, _, _, err = backtrack(normAt, fNorm, eta, 1)
+	if err != ErrLineSearch || len(lambdas) != 2 {
+		t.Errorf("unexpected error %v after %d evaluations, want %v after 2", err, len(lambdas), ErrLineSearch)
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []struct {
+		name   string
+		method func() LeastSquaresMethod
+	}{
+		{"LSQR", func() LeastSquaresMethod { return &LSQR{} }},
+		{"LSMR", func() LeastSquaresMethod { return &LSMR{} }},
+		{"Default", func() LeastSquaresMethod { return nil }},
+	} {
+		for _, tc := range []struct {
+			rows, cols int
+			damp       float64
+			consistent bool
+		}{
+			{rows: 1, cols: 1},
+			{rows: 30, cols: 10},
+			{rows: 100, cols: 20},
+			{rows: 100, cols: 20, consistent: true},
+			{rows: 50, cols: 50},
+			{rows: 20, cols: 40, consistent: true},
+			{rows: 30, cols: 10, damp: 0.5},
+			{rows: 20, cols: 40, damp: 2},
+		} {
+			name := fmt.Sprintf("%v-%vx%v-damp=%v", m.name, tc.rows, tc.cols, tc.damp)
+			if tc.consistent {
+				name += "-consistent"
+			}
+
+			a := mat.NewDense(tc.rows, tc.cols, nil)
+			for i := 0; i < tc.rows; i++ {
+				for j := 0; j < tc.cols; j++ {
+					a.Set(i, j, rnd.NormFloat64())
+				}
+			}
+			b := mat.NewVecDense(tc.rows, nil)
+			if tc.consistent {
+				x := mat.NewVecDense(tc.cols, nil)
+				for i := 0; i < tc.cols; i++ {
+					x.SetVec(i, rnd.NormFloat64())
+				}
+				b.MulVec(a, x)
+			} else {
+				for i := 0; i < tc.rows; i++ {
+					b.SetVec(i, rnd.NormFloat64())
+				}
+			}
+
+			// Compute the reference solution from the augmented system
+			//  [ A ] x = [ b ]
+			//  [ λI ]    [ 0 ]
+			// or, if it is underdetermined, the minimum norm solution of
+			// the normal equations.
+			aug := mat.NewDense(tc.rows+tc.cols, tc.cols, nil)
+			aug.Slice(0, tc.rows, 0, tc.cols).(*mat.Dense).Copy(a)
+			for i := 0; i < tc.cols; i++ {
+				aug.Set(tc.rows+i, i, tc.damp)
+			}
+			augB := mat.NewVecDense(tc.rows+tc.cols, nil)
+			augB.SliceVec(0, tc.rows).(*mat.VecDense).CopyVec(b)
+			var want mat.VecDense
+			if tc.damp == 0 && tc.rows < tc.cols {
+				var aat mat.SymDense
+				aat.SymOuterK(1, a)
+				var y mat.VecDense
+				err := y.SolveVec(&aat, b)
+				if err != nil {
+					t.Fatalf("%v: unexpected error from dense solve: %v", name, err)
+				}
+				want.MulVec(a.T(), &y)
+			} else {
+				err := want.SolveVec(aug, augB)
+				if err != nil {
+					t.Fatalf("%v: unexpected error from dense solve: %v", name, err)
+				}
+			}
+
+			op := &testCase{mulVecTo: func(dst *mat.VecDense, trans bool, x mat.Vector) {
+				if trans {
+					dst.MulVec(a.T(), x)
+				} else {
+					dst.MulVec(a, x)
+				}
+			}}
+			dst := mat.NewVecDense(tc.cols, nil)
+			const tol = 1e-10
+			result, err := LeastSquares(op, b, tc.cols, m.method(), &LeastSquaresSettings{
+				Dst:            dst,
+				Damp:           tc.damp,
+				ATol:           tol,
+				BTol:           tol,
+				ConditionLimit: 1e12,
+			})
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", name, err)
+				continue
+			}
+			if result.X != dst {
+				t.Errorf("%v: LeastSquaresSettings.Dst and LeastSquaresResult.X are not the same vector", name)
+			}
+
+			var d mat.VecDense
+			d.SubVec(&want, result.X)
+			if e := mat.Norm(&d, 2) / mat.Norm(&want, 2); e > 1e-7 {
+				t.Errorf("%v: unexpected solution, |want-got|/|want|=%v", name, e)
+			}
+
+			// Check the estimates against the true values.
+			var r mat.VecDense
+			r.MulVec(aug, result.X)
+			r.SubVec(augB, &r)
+			rNorm := mat.Norm(&r, 2)
+			if math.Abs(rNorm-result.ResidualNorm) > 1e-6*mat.Norm(b, 2) {
+				t.Errorf("%v: unexpected estimate of |r|: got %v, want %v", name, result.ResidualNorm, rNorm)
+			}
+			if tc.consistent && tc.damp == 0 && rNorm > 1e-8*mat.Norm(b, 2) {
+				t.Errorf("%v: unexpected residual norm of consistent system: %v", name, rNorm)
+			}
+			var ar mat.VecDense
+			ar.MulVec(aug.T(), &r)
+			if arNorm := mat.Norm(&ar, 2); arNorm > 1e-6*mat.Norm(aug, 2)*rNorm && rNorm > 1e-6*mat.Norm(b, 2) {
+				t.Errorf("%v: unexpected normal residual norm: %v", name, arNorm)
+			}
+			if xNorm := mat.Norm(result.X, 2); math.Abs(xNorm-result.XNorm) > 1e-6*xNorm {
+				t.Errorf("%v: unexpected estimate of |x|: got %v, want %v", name, result.XNorm, xNorm)
+			}
+			// The estimate of the Frobenius norm grows with the loss of
+			// orthogonality in the bidiagonalization.
+			if aNorm := mat.Norm(aug, 2); result.ANorm < aNorm/2 || 2*aNorm < result.ANorm {
+				t.Errorf("%v: unexpected estimate of |A|: got %v, want %v", name, result.ANorm, aNorm)
+			}
+			if result.ACond < 1 {
+				t.Errorf("%v: unexpected estimate of cond(A): %v", name, result.ACond)
+			}
+			if result.Stats.Iterations == 0 || result.Stats.MulVec < 2*result.Stats.Iterations {
+				t.Errorf("%v: unexpected statistics: %+v", name, result.Stats)
+			}
+		}
+	}
+}
+
+func TestLeastSquaresZeroRHS(t *testing.T) {
+	op := &testCase{mulVecTo: func(dst *mat.VecDense, trans bool, x mat.Vector) {
+		panic("unexpected multiplication")
+	}}
+	dst := mat.NewVecDense(3, []float64{1, 2, 3})
+	result, err := LeastSquares(op, mat.NewVecDense(5, nil), 3, nil, &LeastSquaresSettings{Dst: dst})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mat.Norm(result.X, 2) != 0 || result.ResidualNorm != 0 {
+		t.Errorf("unexpected nonzero solution for zero right-hand side")
+	}
+}
+
+func TestLeastSquaresConditionLimit(t *testing.T) {
+	// A diagonal matrix with the condition number 1e10.
+	const n = 10
+	diag := make([]float64, n)
+	for i := range diag {
+		diag[i] = math.Pow(10, -float64(i)*10/(n-1))
+	}
+	op := &testCase{mulVecTo: func(dst *mat.VecDense, trans bool, x mat.Vector) {
+		dst.MulElemVec(mat.NewVecDense(n, diag), x)
+	}}
+	b := mat.NewVecDense(n, nil)
+	for i := range diag {
+		b.SetVec(i, 1)
+	}
+	for _, m := range []LeastSquaresMethod{&LSQR{}, &LSMR{}} {
+		result, err := LeastSquares(op, b, n, m, &LeastSquaresSettings{
+			ATol:           1e-15,
+			BTol:           1e-15,
+			ConditionLimit: 1e3,
+		})
+		if err != ErrConditionLimit {
+			t.Errorf("%T: unexpected error: got %v, want %v", m, err, ErrConditionLimit)
+			continue
+		}
+		if result.ACond < 1e3 {
+			t.Errorf("%T: unexpected estimate of cond(A): %v", m, result.ACond)
+		}
+	}
+}
+
+func TestKernels(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 10, parallelBlock, 3*parallelBlock + 5} {
+		// Use strided vectors from the columns of a matrix.
+		data := mat.NewDense(n, 3, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < 3; j++ {
+				data.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		col := func(j int) *mat.VecDense {
+			return data.ColView(j).(*mat.VecDense)
+		}
+		x, y := col(0), col(1)
+
+		var wantDst mat.VecDense
+		wantDst.AddScaledVec(x, 0.5, y)
+		var wantScale mat.VecDense
+		wantScale.ScaleVec(-2, x)
+
+		var dot, norm float64
+		var dst, scale *mat.VecDense
+		for _, workers := range []int{1, 2, 3, 8} {
+			name := fmt.Sprintf("n=%v,workers=%v", n, workers)
+			var k kernels
+			k.init(workers)
+
+			gotDot := k.dot(x, y)
+			if !scalar.EqualWithinAbsOrRel(gotDot, mat.Dot(x, y), 1e-12, 1e-12) {
+				t.Errorf("%v: unexpected dot product: got %v, want %v", name, gotDot, mat.Dot(x, y))
+			}
+			gotNorm := k.norm(x)
+			if !scalar.EqualWithinAbsOrRel(gotNorm, mat.Norm(x, 2), 1e-12, 1e-12) {
+				t.Errorf("%v: unexpected norm: got %v, want %v", name, gotNorm, mat.Norm(x, 2))
+			}
+			gotDst := col(2)
+			k.addScaled(gotDst, x, 0.5, y)
+			if !mat.EqualApprox(gotDst, &wantDst, 1e-14) {
+				t.Errorf("%v: unexpected result of addScaled", name)
+			}
+			gotScale := mat.VecDenseCopyOf(x)
+			k.scale(-2, gotScale)
+			if !mat.EqualApprox(gotScale, &wantScale, 1e-14) {
+				t.Errorf("%v: unexpected result of scale", name)
+			}
+
+			if workers == 1 {
+				dot, norm = gotDot, gotNorm
+				dst, scale = mat.VecDenseCopyOf(gotDst), gotScale
+				continue
+			}
+			// The results must not depend on the number of workers.
+			if gotDot != dot || gotNorm != norm || !mat.Equal(gotDst, dst) || !mat.Equal(gotScale, scale) {
+				t.Errorf("%v: result depends on the number of workers", name)
+			}
+		}
+	}
+}
+
+func TestParallelMethods(t *testing.T) {
+	// Use systems larger than a few blocks of the parallel kernels.
+	const nx, ny = 130, 130
+	laplace, lrhs, ldiag := newPDESystem2D(nx, ny, negOne, negOne, zero, zero, zero, one)
+	convection, crhs, cdiag := newPDESystem2D(nx, ny,
+		negOne, negOne,
+		func(x, _ float64) float64 { return 40 * x },
+		func(_, y float64) float64 { return 40 * y },
+		constant(-100), one)
+	n := len(lrhs)
+	if n < 2*parallelBlock {
+		panic("bad test")
+	}
+
+	for _, test := range []struct {
+		name    string
+		coo     *sparse.COO
+		rhs     []float64
+		diag    []float64
+		maxIter int
+		method  func(workers int) Method
+	}{
+		{"CG", laplace, lrhs, ldiag, 50, func(w int) Method { return &CG{Workers: w} }},
+		{"BiCGStab", convection, crhs, cdiag, 20, func(w int) Method { return &BiCGStab{Workers: w} }},
+		{"GMRES", convection, crhs, cdiag, 2, func(w int) Method { return &GMRES{Restart: 20, Workers: w} }},
+		{"FGMRES", convection, crhs, cdiag, 2, func(w int) Method { return &FGMRES{Restart: 20, Workers: w} }},
+		{"LGMRES", convection, crhs, cdiag, 2, func(w int) Method { return &LGMRES{Restart: 20, Workers: w} }},
+	} {
+		a := test.coo.ToCSR()
+		b := mat.NewVecDense(n, test.rhs)
+		d := mat.NewVecDense(n, test.diag)
+		settings := func() *Settings {
+			return &Settings{
+				Tolerance:     1e-10,
+				MaxIterations: test.maxIter,
+				PreconSolve: func(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+					dst.DivElemVec(rhs, d)
+					return nil
+				},
+			}
+		}
+
+		want, err := Iterative(a, b, test.method(0), settings())
+		if err != nil && err != ErrIterationLimit {
+			t.Fatalf("%v: unexpected error: %v", test.name, err)
+		}
+
+		var first *Result
+		for _, workers := range []int{1, 2, 5} {
+			name := fmt.Sprintf("%v-workers=%v", test.name, workers)
+			got, err := Iterative(sparse.NewParallelCSR(a, workers), b, test.method(workers), settings())
+			if err != nil && err != ErrIterationLimit {
+				t.Errorf("%v: unexpected error: %v", name, err)
+				continue
+			}
+			// The parallel kernels sum in a different order than the
+			// serial ones, so only approximate agreement is expected.
+			var diff mat.VecDense
+			diff.SubVec(got.X, want.X)
+			if e := mat.Norm(&diff, 2) / mat.Norm(want.X, 2); e > 1e-8 {
+				t.Errorf("%v: solution differs from serial solve, |want-got|/|want|=%v", name, e)
+			}
+			if first == nil {
+				first = got
+				continue
+			}
+			if !mat.Equal(got.X, first.X) || got.ResidualNorm != first.ResidualNorm || got.Stats != first.Stats {
+				t.Errorf("%v: result depends on the number of workers", name)
+			}
 		}
 	}
 }
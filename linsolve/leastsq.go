@@ -0,0 +1,333 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// ErrConditionLimit is returned by LeastSquares when the estimate of the
+// condition number of the matrix exceeds the limit given by
+// LeastSquaresSettings.ConditionLimit.
+var ErrConditionLimit = errors.New("linsolve: condition number limit reached")
+
+// LeastSquaresMethod is an iterative method that produces a sequence of
+// vectors that converge to the solution of the damped least-squares problem
+//
+//	minimize |A * x - b|^2 + λ^2 |x|^2,
+//
+// where A is an m×n matrix, b is a vector of dimension m and λ ≥ 0 is the
+// damping parameter. A may be square or rectangular and it can be rank
+// deficient.
+//
+// LeastSquaresMethod uses the same reverse-communication interface as Method,
+// except that MulVec commands the product A*x with x of dimension n stored in
+// LeastSquaresContext.Src and the result of dimension m stored in
+// LeastSquaresContext.Dst, and MulVec|Trans commands the product Aᵀ*y with y of
+// dimension m and the result of dimension n. See the documentation for Method
+// and LeastSquaresContext for more information.
+type LeastSquaresMethod interface {
+	// Init initializes the method for solving the
+	// least-squares problem with an m×n matrix, the
+	// right-hand side b of dimension m and the damping
+	// parameter damp. The initial estimate is the zero
+	// vector.
+	//
+	// LeastSquaresMethod will not retain b.
+	Init(b *mat.VecDense, n int, damp float64)
+
+	// Iterate performs a step in converging to the
+	// solution of a least-squares problem.
+	//
+	// Iterate retrieves data from LeastSquaresContext,
+	// updates it, and returns the next operation. The
+	// caller must perform the Operation using data in
+	// LeastSquaresContext, and depending on the state
+	// call Iterate again.
+	Iterate(*LeastSquaresContext) (Operation, error)
+}
+
+// LeastSquaresContext mediates the communication between the
+// LeastSquaresMethod and the caller. The caller must not modify
+// LeastSquaresContext apart from the commanded Operations.
+//
+// The estimates refer to the augmented matrix and residual
+//
+//	Ā = [ A ],  r̄ = [ b ] - Ā * x
+//	    [ λI ]       [ 0 ]
+//
+// of the damped problem. Without damping they are equal to A and b - A*x.
+type LeastSquaresContext struct {
+	// X will be set by LeastSquaresMethod to the current
+	// approximate solution when it commands MajorIteration.
+	X *mat.VecDense
+
+	// ResidualNorm is an estimate of |r̄|. LeastSquaresMethod
+	// will set it and the following estimates to the current
+	// values when it commands CheckResidualNorm.
+	ResidualNorm float64
+
+	// NormalResidualNorm is an estimate of |Āᵀ * r̄|, the
+	// norm of the residual of the normal equations.
+	NormalResidualNorm float64
+
+	// ANorm is an estimate of the Frobenius norm of Ā.
+	ANorm float64
+
+	// ACond is an estimate of the condition number of Ā.
+	ACond float64
+
+	// XNorm is an estimate of |x|.
+	XNorm float64
+
+	// Converged indicates to LeastSquaresMethod whether the
+	// estimates satisfy a stopping criterion as a result of
+	// CheckResidualNorm operation.
+	Converged bool
+
+	// Src and Dst are the source and destination vectors
+	// for the MulVec operations. Src will be set by
+	// LeastSquaresMethod and the caller must store the
+	// result in Dst. LeastSquaresMethod will ensure that
+	// Dst has the correct length.
+	Src, Dst *mat.VecDense
+}
+
+// LeastSquaresSettings holds settings for solving a least-squares problem.
+type LeastSquaresSettings struct {
+	// Dst, if not nil, will be used for storing the approximate solution,
+	// otherwise a new vector will be allocated. In both cases the vector will
+	// also be returned in LeastSquaresResult. If Dst is not empty, its length
+	// must be equal to n.
+	Dst *mat.VecDense
+
+	// Damp is the damping parameter λ. It must not be negative.
+	Damp float64
+
+	// ATol and BTol are the relative error tolerances for A and b. The
+	// iteration will be stopped when either
+	//  |r̄| <= BTol * |b| + ATol * |Ā| * |x|,
+	// which is satisfied when the system A*x = b is compatible, or
+	//  |Āᵀ * r̄| <= ATol * |Ā| * |r̄|,
+	// which is satisfied by the least-squares solution. If the elements of A
+	// and b are accurate to about k digits, ATol and BTol can be set to
+	// 10^{-k}.
+	//
+	// If ATol or BTol is zero, a default value of 1e-8 will be used,
+	// otherwise they must be positive and less than 1.
+	ATol, BTol float64
+
+	// ConditionLimit is the limit on the estimate of the condition number
+	// of Ā. If it is zero, a default value of 1e8 will be used, otherwise
+	// it must be greater than 1.
+	ConditionLimit float64
+
+	// MaxIterations is the limit on the number of iterations. If it is
+	// zero, a default value of four times n will be used.
+	MaxIterations int
+}
+
+// LeastSquaresResult holds the result of an iterative least-squares solve.
+type LeastSquaresResult struct {
+	// X is the approximate solution.
+	X *mat.VecDense
+
+	// ResidualNorm is an estimate of |r̄| for the final x.
+	ResidualNorm float64
+
+	// NormalResidualNorm is an estimate of |Āᵀ * r̄| for the
+	// final x.
+	NormalResidualNorm float64
+
+	// ANorm is an estimate of the Frobenius norm of Ā.
+	ANorm float64
+
+	// ACond is an estimate of the condition number of Ā.
+	ACond float64
+
+	// XNorm is an estimate of |x| for the final x.
+	XNorm float64
+
+	// Stats holds statistics about the iterative solve.
+	// MulVec counts the multiplications with both A and Aᵀ.
+	Stats Stats
+}
+
+func defaultLeastSquaresSettings(s *LeastSquaresSettings, n int) {
+	if s.Dst == nil {
+		s.Dst = mat.NewVecDense(n, nil)
+	} else if s.Dst.Len() == 0 {
+		s.Dst.ReuseAsVec(n)
+	}
+	if s.ATol == 0 {
+		s.ATol = defaultTolerance
+	}
+	if s.BTol == 0 {
+		s.BTol = defaultTolerance
+	}
+	if s.ConditionLimit == 0 {
+		s.ConditionLimit = 1e8
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 4 * n
+	}
+}
+
+func checkLeastSquaresSettings(s *LeastSquaresSettings, n int) {
+	if s.Dst.Len() != n {
+		panic("linsolve: mismatched destination length")
+	}
+	if s.Damp < 0 {
+		panic("linsolve: negative damping")
+	}
+	if s.ATol <= 0 || 1 <= s.ATol || s.BTol <= 0 || 1 <= s.BTol {
+		panic("linsolve: invalid tolerance")
+	}
+	if s.ConditionLimit <= 1 {
+		panic("linsolve: invalid condition number limit")
+	}
+	if s.MaxIterations <= 0 {
+		panic("linsolve: negative iteration limit")
+	}
+}
+
+// LeastSquares finds an approximate solution of the damped least-squares
+// problem
+//
+//	minimize |A * x - b|^2 + λ^2 |x|^2,
+//
+// where A is an m×n matrix, b is the right-hand side vector of dimension m, and
+// λ is given by settings.Damp, using the iterative method m. If m is nil,
+// default LSMR will be used. If the system A*x = b is consistent, LeastSquares
+// finds its solution, and if A has full column rank, λ is zero and m equals n,
+// it solves the square system. Only the products with A and Aᵀ are needed.
+//
+// settings provide means for adjusting parameters of the iterative process. See
+// the LeastSquaresSettings documentation for more information. LeastSquares
+// will not modify the fields of LeastSquaresSettings. If settings is nil,
+// default settings will be used.
+//
+// LeastSquares will panic if n is not positive.
+func LeastSquares(a MulVecToer, b *mat.VecDense, n int, m LeastSquaresMethod, settings *LeastSquaresSettings) (*LeastSquaresResult, error) {
+	if n <= 0 {
+		panic("linsolve: number of columns not positive")
+	}
+
+	var s LeastSquaresSettings
+	if settings != nil {
+		s = *settings
+	}
+	defaultLeastSquaresSettings(&s, n)
+	checkLeastSquaresSettings(&s, n)
+
+	if m == nil {
+		m = &LSMR{}
+	}
+
+	bNorm := mat.Norm(b, 2)
+	ctx := &LeastSquaresContext{
+		X:            mat.NewVecDense(n, nil),
+		ResidualNorm: bNorm,
+		Src:          &mat.VecDense{},
+		Dst:          &mat.VecDense{},
+	}
+	var stats Stats
+	var err error
+	if bNorm == 0 {
+		// x = 0 is the exact solution.
+		s.Dst.Zero()
+	} else {
+		err = iterateLeastSquares(a, b, n, bNorm, s, m, ctx, &stats)
+	}
+
+	return &LeastSquaresResult{
+		X:                  s.Dst,
+		ResidualNorm:       ctx.ResidualNorm,
+		NormalResidualNorm: ctx.NormalResidualNorm,
+		ANorm:              ctx.ANorm,
+		ACond:              ctx.ACond,
+		XNorm:              ctx.XNorm,
+		Stats:              stats,
+	}, err
+}
+
+func iterateLeastSquares(a MulVecToer, b *mat.VecDense, n int, bNorm float64, settings LeastSquaresSettings, method LeastSquaresMethod, ctx *LeastSquaresContext, stats *Stats) error {
+	settings.Dst.Zero()
+
+	// condErr is set when the condition number limit is reached and
+	// returned at the end of the iteration.
+	var condErr error
+	method.Init(b, n, settings.Damp)
+	for {
+		op, err := method.Iterate(ctx)
+		if err != nil {
+			return err
+		}
+		switch op {
+		case NoOperation:
+		case MulVec, MulVec | Trans:
+			stats.MulVec++
+			a.MulVecTo(ctx.Dst, op&Trans == Trans, ctx.Src)
+		case CheckResidualNorm:
+			ctx.Converged = ctx.ResidualNorm <= settings.BTol*bNorm+settings.ATol*ctx.ANorm*ctx.XNorm ||
+				ctx.NormalResidualNorm <= settings.ATol*ctx.ANorm*ctx.ResidualNorm
+			if !ctx.Converged && ctx.ACond >= settings.ConditionLimit {
+				ctx.Converged = true
+				condErr = ErrConditionLimit
+			}
+		case MajorIteration:
+			stats.Iterations++
+			if ctx.Converged {
+				settings.Dst.CopyVec(ctx.X)
+				return condErr
+			}
+			if stats.Iterations == settings.MaxIterations {
+				settings.Dst.CopyVec(ctx.X)
+				return ErrIterationLimit
+			}
+		default:
+			panic("linsolve: invalid operation")
+		}
+	}
+}
+
+// symOrtho returns the stable plane rotation (c, s) and r such that
+//
+//	[  c  s ] [ a ] = [ r ]
+//	[ -s  c ] [ b ]   [ 0 ].
+//
+// References:
+//   - Choi, S.-C. (2006). Iterative methods for singular linear equations and
+//     least-squares problems. PhD thesis, Stanford University. Table 2.9.
+func symOrtho(a, b float64) (c, s, r float64) {
+	switch {
+	case b == 0:
+		return math.Copysign(1, a), 0, math.Abs(a)
+	case a == 0:
+		return 0, math.Copysign(1, b), math.Abs(b)
+	case math.Abs(b) > math.Abs(a):
+		tau := a / b
+		s = math.Copysign(1, b) / math.Sqrt(1+tau*tau)
+		c = s * tau
+		r = b / s
+	default:
+		tau := b / a
+		c = math.Copysign(1, a) / math.Sqrt(1+tau*tau)
+		s = c * tau
+		r = a / c
+	}
+	return c, s, r
+}
+
+// resizeVec resizes v to length n reusing its storage if possible.
+func resizeVec(v *mat.VecDense, n int) {
+	if v.Len() != n {
+		v.Reset()
+		v.ReuseAsVec(n)
+	}
+}
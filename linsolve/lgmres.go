@@ -0,0 +1,271 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// LGMRES implements the Loose Generalized Minimum Residual method with right
+// preconditioning for solving systems of linear equations
+//
+//	A * x = b,
+//
+// where A is a nonsymmetric, nonsingular matrix. LGMRES is a variant of
+// restarted GMRES that augments the Krylov subspace at each restart with a few
+// error approximation vectors from previous restart cycles. These vectors
+// retain some of the information that is thrown away by restarting and
+// thereby reduce the stagnation that is typical for GMRES with a small restart
+// parameter, often without increasing the total memory requirements.
+//
+// Each augmentation vector adds one column to the search space of a restart
+// cycle but because the product of A with the augmentation vector is known
+// from the previous cycle, it does not require a matrix-vector product.
+//
+// References:
+//   - Baker, A., Jessup, E., and Manteuffel, T. (2005). A technique for
+//     accelerating the convergence of restarted GMRES. SIAM J. Matrix Anal.
+//     Appl., 26(4), 962-984. doi:10.1137/S0895479803422014
+type LGMRES struct {
+	// Restart is the dimension of the Krylov subspace generated in each
+	// restart cycle. It must hold that
+	//  1 <= Restart <= n
+	// where n is the dimension of the problem. If Restart is 0, min(20,n)
+	// will be used.
+	Restart int
+
+	// Augment is the maximum number of error approximation vectors that are
+	// added to the Krylov subspace in each restart cycle. It must not be
+	// negative. If Augment is 0, a default value of 3 will be used. The
+	// number of augmentation vectors is further limited to n-Restart.
+	Augment int
+
+	// Workers is the number of goroutines that perform the vector
+	// operations including the Gram-Schmidt orthogonalization. See the CG
+	// documentation for more information.
+	Workers int
+
+	// m is the used value of Restart.
+	m int
+	// k is the used value of Augment.
+	k int
+
+	// v is an n×(m+k+1) matrix V whose columns form an orthonormal basis of
+	// the search space.
+	v mat.Dense
+	// z is an n×(m+k) matrix Z whose columns span the search space and
+	// satisfy A * Z = V * H.
+	z mat.Dense
+	// h is an (m+k+1)×(m+k) upper Hessenberg matrix H.
+	h mat.Dense
+	// givs holds Givens rotations that are used to reduce H to upper
+	// triangular form.
+	givs []givens
+
+	// aug is an n×k matrix whose columns are the normalized error
+	// approximation vectors from previous restart cycles.
+	aug mat.Dense
+	// augA is an n×k matrix with the products of A with columns of aug.
+	augA mat.Dense
+	// naug is the number of stored augmentation vectors.
+	naug int
+	// augNext is the index of the column of aug that will be overwritten
+	// next.
+	augNext int
+
+	x  mat.VecDense
+	y  mat.VecDense
+	s  mat.VecDense
+	dx mat.VecDense
+
+	// beta is the norm of the residual at the beginning of a restart cycle.
+	beta float64
+
+	kern kernels
+
+	j      int // Loop variable for inner iterations.
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (g *LGMRES) Init(x, residual *mat.VecDense) {
+	dim := x.Len()
+	if residual.Len() != dim {
+		panic("lgmres: vector length mismatch")
+	}
+
+	g.m = g.Restart
+	if g.m == 0 {
+		g.m = min(20, dim)
+	}
+	if g.m <= 0 || dim < g.m {
+		panic("lgmres: invalid value of Restart")
+	}
+	if g.Augment < 0 {
+		panic("lgmres: negative value of Augment")
+	}
+	g.k = g.Augment
+	if g.k == 0 {
+		g.k = 3
+	}
+	g.k = min(g.k, dim-g.m)
+
+	size := g.m + g.k
+	g.v.Reset()
+	g.v.ReuseAs(dim, size+1)
+	// Store the residual in the first column of V.
+	g.vcol(0).CopyVec(residual)
+
+	g.z.Reset()
+	g.z.ReuseAs(dim, size)
+
+	g.h.Reset()
+	g.h.ReuseAs(size+1, size)
+
+	if cap(g.givs) < size {
+		g.givs = make([]givens, size)
+	} else {
+		g.givs = g.givs[:size]
+		for i := range g.givs {
+			g.givs[i].c = 0
+			g.givs[i].s = 0
+		}
+	}
+
+	g.aug.Reset()
+	g.augA.Reset()
+	if g.k > 0 {
+		g.aug.ReuseAs(dim, g.k)
+		g.augA.ReuseAs(dim, g.k)
+	}
+	g.naug = 0
+	g.augNext = 0
+
+	g.kern.init(g.Workers)
+
+	g.x.CloneFromVec(x)
+	g.y.Reset()
+	g.y.ReuseAsVec(size + 1)
+	g.s.Reset()
+	g.s.ReuseAsVec(size + 1)
+	g.dx.Reset()
+	g.dx.ReuseAsVec(dim)
+
+	g.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// LGMRES will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	ComputeResidual
+//	MajorIteration
+//	NoOperation
+func (g *LGMRES) Iterate(ctx *Context) (Operation, error) {
+	switch g.resume {
+	case 1:
+		// The residual is in the first column of V.
+		v0 := g.vcol(0)
+		// Normalize v_0.
+		g.beta = g.kern.norm(v0)
+		g.kern.scale(1/g.beta, v0)
+		// Initialize s to the elementary vector e_1 scaled by the norm.
+		g.s.Zero()
+		g.s.SetVec(0, g.beta)
+
+		// Begin the inner for-loop for j going from 0 to m+naug-1.
+		g.j = 0
+		fallthrough
+	case 2:
+		if g.j >= g.m {
+			// Extend the search space with an augmentation vector. The
+			// product with A is already known.
+			i := g.j - g.m
+			g.z.ColView(g.j).(*mat.VecDense).CopyVec(g.aug.ColView(i))
+			g.vcol(g.j + 1).CopyVec(g.augA.ColView(i))
+			ctx.ResidualNorm = g.arnoldi()
+			g.resume = 5
+			return CheckResidualNorm, nil
+		}
+		ctx.Src.CopyVec(g.vcol(g.j))
+		g.resume = 3
+		// Solve M^{-1} * v_j.
+		return PreconSolve, nil
+	case 3:
+		// z_j = M^{-1} * v_j
+		zj := g.z.ColView(g.j).(*mat.VecDense)
+		zj.CopyVec(ctx.Dst)
+		ctx.Src.CopyVec(zj)
+		g.resume = 4
+		// Compute A * z_j.
+		return MulVec, nil
+	case 4:
+		g.vcol(g.j + 1).CopyVec(ctx.Dst)
+		ctx.ResidualNorm = g.arnoldi()
+		g.resume = 5
+		return CheckResidualNorm, nil
+	case 5:
+		g.j++
+		if g.j < g.m+g.naug && !ctx.Converged {
+			// Continue the inner for-loop.
+			g.resume = 2
+			return NoOperation, nil
+		}
+		// Either restarting or converged, we have to update the solution.
+		// Solve the upper triangular system H*y=s.
+		solveLeastSquares(g.j, &g.y, &g.h, &g.s)
+		// Compute the update of x as a linear combination of columns of Z.
+		g.dx.Zero()
+		updateSolution(&g.kern, g.j, &g.dx, &g.z, &g.y)
+		g.x.AddVec(&g.x, &g.dx)
+		ctx.X.CopyVec(&g.x)
+		if ctx.Converged {
+			g.resume = 0
+			return MajorIteration, nil
+		}
+		// We are restarting, so we have to also compute the residual.
+		g.resume = 6
+		return ComputeResidual, nil
+	case 6:
+		r := ctx.Dst
+		// Store the update of x as a new augmentation vector. The product
+		// A * dx is the difference between the old and the new residual.
+		if dxNorm := mat.Norm(&g.dx, 2); g.k > 0 && dxNorm > 0 {
+			aug := g.aug.ColView(g.augNext).(*mat.VecDense)
+			aug.ScaleVec(1/dxNorm, &g.dx)
+			augA := g.augA.ColView(g.augNext).(*mat.VecDense)
+			augA.AddScaledVec(r, -g.beta, g.vcol(0))
+			augA.ScaleVec(-1/dxNorm, augA)
+			g.augNext = (g.augNext + 1) % g.k
+			g.naug = min(g.naug+1, g.k)
+		}
+		// Store the residual again in the first column of V.
+		g.vcol(0).CopyVec(r)
+		g.resume = 1
+		return MajorIteration, nil
+
+	default:
+		panic("lgmres: Init not called")
+	}
+}
+
+// arnoldi orthonormalizes the (j+1)-th column of V against the previous
+// columns, updates the j-th column of H and its QR factorization, and returns
+// the norm of the residual.
+func (g *LGMRES) arnoldi() float64 {
+	modifiedGS(&g.kern, g.j, &g.h, &g.v, g.vcol(g.j+1))
+	qr(g.j, g.givs, &g.h, &g.s)
+	return math.Abs(g.s.AtVec(g.j + 1))
+}
+
+// vcol returns a view of the j-th column of the matrix V.
+func (g *LGMRES) vcol(j int) *mat.VecDense {
+	return g.v.ColView(j).(*mat.VecDense)
+}
@@ -69,6 +69,17 @@ type Context struct {
 	// CheckResidualNorm operation.
 	Converged bool
 
+	// EnergyDecrease is the decrease of the squared A-norm
+	// of the error
+	//  |x - x_{i-1}|_A^2 - |x - x_i|_A^2
+	// in the current iteration. Methods for symmetric
+	// positive definite matrices such as CG set it when they
+	// command CheckResidualNorm. Iterative sets it to zero for
+	// checking the initial guess and to NaN after each
+	// CheckResidualNorm, so it is NaN for other methods. It is
+	// used by the EnergyNorm stopping criterion.
+	EnergyDecrease float64
+
 	// Src and Dst are the source and destination vectors
 	// for various Operations. Src will be set by Method
 	// and the caller must store the result in Dst. See
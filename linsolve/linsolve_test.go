@@ -7,10 +7,11 @@ package linsolve
 import (
 	"fmt"
 	"math"
+	"math/cmplx"
 
 	"golang.org/x/exp/rand"
 
-	"gonum.org/v1/exp/linsolve/internal/triplet"
+	"gonum.org/v1/exp/linsolve/sparse"
 	"gonum.org/v1/gonum/lapack/testlapack"
 	"gonum.org/v1/gonum/mat"
 )
@@ -221,6 +222,144 @@ func newGreenbaum41(n int, d1, dn, rho float64, rnd *rand.Rand) testCase {
 	}
 }
 
+// symIndefTestCases returns test cases with symmetric indefinite matrices.
+func symIndefTestCases(rnd *rand.Rand) []testCase {
+	return []testCase{
+		newRandomSymIndefinite(2, 1, rnd),
+		newRandomSymIndefinite(5, 2, rnd),
+		newRandomSymIndefinite(20, 10, rnd),
+		newRandomSymIndefinite(50, 5, rnd),
+		newSaddlePoint(20, 5, rnd),
+		newSaddlePoint(40, 20, rnd),
+		newHelmholtz1D(64, 200, random(rnd)),
+	}
+}
+
+// newRandomSymIndefinite returns a test case with a symmetric indefinite
+// matrix of order n with nneg negative eigenvalues, and a random right-hand
+// side. The eigenvalues are in the intervals [-10,-1] and [1,10].
+func newRandomSymIndefinite(n, nneg int, rnd *rand.Rand) testCase {
+	if nneg < 0 || n < nneg {
+		panic("bad test")
+	}
+	// Generate the eigenvalues.
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = 1 + 9*rnd.Float64()
+		if i < nneg {
+			d[i] *= -1
+		}
+	}
+	// Generate the symmetric matrix A.
+	a := make([]float64, n*n)
+	testlapack.Dlagsy(n, 0, d, a, n, rnd, make([]float64, 2*n))
+	A := mat.NewSymDense(n, a)
+	// Generate the right-hand side.
+	b := make([]float64, n)
+	for i := range b {
+		b[i] = rnd.NormFloat64()
+	}
+	// Compute the solution using the LU factorization.
+	var lu mat.LU
+	lu.Factorize(A)
+	want := make([]float64, n)
+	err := lu.SolveVecTo(mat.NewVecDense(n, want), false, mat.NewVecDense(n, b))
+	if err != nil {
+		panic("lu.SolveVecTo failed")
+	}
+	// Matrix-vector multiplication.
+	mulVecTo := func(dst *mat.VecDense, _ bool, x mat.Vector) {
+		if dst.Len() != n || x.Len() != n {
+			panic("mismatched vector length")
+		}
+		dst.MulVec(A, x)
+	}
+	return testCase{
+		name:     fmt.Sprintf("Random symmetric indefinite n=%v,nneg=%v", n, nneg),
+		mulVecTo: mulVecTo,
+		b:        b,
+		tol:      defaultTol,
+		want:     want,
+	}
+}
+
+// newSaddlePoint returns a test case with a symmetric indefinite matrix of
+// the saddle-point form
+//
+//	⎛K Bᵀ⎞
+//	⎝B  0⎠
+//
+// where K is a random symmetric positive definite matrix of order n and B is
+// a random m×n matrix with m <= n.
+func newSaddlePoint(n, m int, rnd *rand.Rand) testCase {
+	if m > n {
+		panic("bad test")
+	}
+	// Generate a random SPD matrix K.
+	c := make([]float64, n*n)
+	for i := range c {
+		c[i] = rnd.NormFloat64()
+	}
+	var K mat.SymDense
+	K.SymOuterK(1, mat.NewDense(n, n, c))
+	for i := 0; i < n; i++ {
+		K.SetSym(i, i, K.At(i, i)+float64(n))
+	}
+	// Assemble the saddle-point matrix.
+	A := mat.NewDense(n+m, n+m, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			A.Set(i, j, K.At(i, j))
+		}
+	}
+	for i := n; i < n+m; i++ {
+		for j := 0; j < n; j++ {
+			bij := rnd.NormFloat64() * math.Sqrt(float64(n))
+			A.Set(i, j, bij)
+			A.Set(j, i, bij)
+		}
+	}
+	// Generate the right-hand side.
+	b := make([]float64, n+m)
+	for i := range b {
+		b[i] = rnd.NormFloat64()
+	}
+	// Compute the solution using the LU factorization.
+	var lu mat.LU
+	lu.Factorize(A)
+	want := make([]float64, n+m)
+	err := lu.SolveVecTo(mat.NewVecDense(n+m, want), false, mat.NewVecDense(n+m, b))
+	if err != nil {
+		panic("lu.SolveVecTo failed")
+	}
+	// Matrix-vector multiplication.
+	mulVecTo := func(dst *mat.VecDense, _ bool, x mat.Vector) {
+		if dst.Len() != n+m || x.Len() != n+m {
+			panic("mismatched vector length")
+		}
+		dst.MulVec(A, x)
+	}
+	return testCase{
+		name:     fmt.Sprintf("Saddle point n=%v,m=%v", n, m),
+		mulVecTo: mulVecTo,
+		b:        b,
+		tol:      defaultTol,
+		want:     want,
+	}
+}
+
+// newHelmholtz1D returns a test case that arises from a finite-difference
+// discretization of the Helmholtz equation
+//   - ∂_x ∂_x u - k2*u = f
+//
+// on the interval [0,1]. The system matrix is symmetric and for k2 > π² it is
+// indefinite.
+func newHelmholtz1D(nx int, k2 float64, f func(float64, float64) float64) testCase {
+	tc := newPDE(nx, 1, negOne, nil, zero, nil, constant(-k2), f)
+	tc.name = fmt.Sprintf("Helmholtz 1D nx=%v,k2=%v", nx, k2)
+	return tc
+}
+
 func nonsym3x3() testCase {
 	return testCase{
 		name: "nonsym 3x3",
@@ -244,7 +383,7 @@ func nonsym3x3() testCase {
 }
 
 func nonsymTridiag(n int) testCase {
-	A := triplet.NewMatrix(n, n)
+	A := sparse.NewCOO(n, n)
 	for i := 0; i < n; i++ {
 		if i > 0 {
 			A.Append(i, i-1, -2)
@@ -596,7 +735,7 @@ func newPDE(nx, ny int, a, b, c, d, e, f func(float64, float64) float64) testCas
 	}
 
 	var (
-		A    *triplet.Matrix
+		A    *sparse.COO
 		rhs  []float64
 		diag []float64
 	)
@@ -605,8 +744,9 @@ func newPDE(nx, ny int, a, b, c, d, e, f func(float64, float64) float64) testCas
 	} else {
 		A, rhs, diag = newPDESystem2D(nx, ny, a, b, c, d, e, f)
 	}
+	csr := A.ToCSR()
 	// Use a dense solver to obtain a reference solution.
-	Ad := A.DenseCopy()
+	Ad := mat.DenseCopyOf(csr)
 	var lu mat.LU
 	lu.Factorize(Ad)
 	n := len(rhs)
@@ -616,7 +756,7 @@ func newPDE(nx, ny int, a, b, c, d, e, f func(float64, float64) float64) testCas
 		panic("lu.SolveVec failed")
 	}
 	return testCase{
-		mulVecTo: A.MulVecTo,
+		mulVecTo: csr.MulVecTo,
 		b:        rhs,
 		tol:      defaultTol,
 		diag:     diag,
@@ -626,9 +766,9 @@ func newPDE(nx, ny int, a, b, c, d, e, f func(float64, float64) float64) testCas
 
 // newPDESystem1D assembles and returns the matrix A, the right-hand side vector,
 // and the diagonal of A for a 1-dimensional PDE problem.
-func newPDESystem1D(nx int, a, b, c, f func(float64, float64) float64) (A *triplet.Matrix, rhs, diag []float64) {
+func newPDESystem1D(nx int, a, b, c, f func(float64, float64) float64) (A *sparse.COO, rhs, diag []float64) {
 	h := 1 / float64(nx+1)
-	A = triplet.NewMatrix(nx, nx)
+	A = sparse.NewCOO(nx, nx)
 	rhs = make([]float64, nx)
 	diag = make([]float64, nx)
 	var i int
@@ -686,7 +826,7 @@ func newStencil1D(i int, h float64, a, b, c func(float64, float64) float64) (s s
 
 // newPDESystem2D assembles and returns the matrix A, the right-hand side vector,
 // and the diagonal of A for a 2-dimensional PDE problem.
-func newPDESystem2D(nx, ny int, a, b, c, d, e, f func(float64, float64) float64) (A *triplet.Matrix, rhs, diag []float64) {
+func newPDESystem2D(nx, ny int, a, b, c, d, e, f func(float64, float64) float64) (A *sparse.COO, rhs, diag []float64) {
 	// Finite difference stencil:
 	//             * (ix,iy+1)
 	//             |
@@ -699,7 +839,7 @@ func newPDESystem2D(nx, ny int, a, b, c, d, e, f func(float64, float64) float64)
 
 	h := 1 / float64(nx+1)
 	n := nx * ny
-	A = triplet.NewMatrix(n, n)
+	A = sparse.NewCOO(n, n)
 	rhs = make([]float64, n)
 	diag = make([]float64, n)
 	var i int
@@ -799,3 +939,190 @@ func random(rnd *rand.Rand) func(_, _ float64) float64 {
 		return rnd.NormFloat64()
 	}
 }
+
+// cTestCase is a test case for methods for complex linear systems.
+type cTestCase struct {
+	name string
+
+	mulVecTo func(dst, x []complex128, trans bool) // Matrix-vector multiplication
+
+	b    []complex128 // Right-hand side vector
+	diag []complex128 // Diagonal for the Jacobi preconditioner
+	tol  float64      // Tolerance for the convergence criterion
+
+	want []complex128 // Expected solution
+}
+
+func (tc *cTestCase) MulVecTo(dst *mat.CDense, trans bool, x mat.CMatrix) {
+	n := len(tc.b)
+	xs := make([]complex128, n)
+	for i := range xs {
+		xs[i] = x.At(i, 0)
+	}
+	d := make([]complex128, n)
+	tc.mulVecTo(d, xs, trans)
+	for i, v := range d {
+		dst.Set(i, 0, v)
+	}
+}
+
+func (tc *cTestCase) PreconSolve(dst *mat.CDense, trans bool, rhs mat.CMatrix) error {
+	for i := range tc.b {
+		v := rhs.At(i, 0)
+		if tc.diag != nil {
+			d := tc.diag[i]
+			if trans {
+				d = cmplx.Conj(d)
+			}
+			v /= d
+		}
+		dst.Set(i, 0, v)
+	}
+	return nil
+}
+
+// complexSymTestCases returns test cases with complex symmetric matrices.
+func complexSymTestCases(rnd *rand.Rand) []cTestCase {
+	return []cTestCase{
+		newRandomComplexSym(1, rnd),
+		newRandomComplexSym(2, rnd),
+		newRandomComplexSym(5, rnd),
+		newRandomComplexSym(20, rnd),
+		newRandomComplexSym(50, rnd),
+		newComplexHelmholtz2D(12, 12, 50i, 0, rnd),
+		newComplexHelmholtz2D(12, 12, -200+100i, 0, rnd),
+	}
+}
+
+// complexTestCases returns test cases with general complex matrices.
+func complexTestCases(rnd *rand.Rand) []cTestCase {
+	return append(complexSymTestCases(rnd),
+		newRandomComplex(5, rnd),
+		newRandomComplex(30, rnd),
+		newComplexHelmholtz2D(12, 12, -50+50i, 20, rnd),
+	)
+}
+
+// newRandomComplexSym returns a test case with a random n×n complex symmetric
+// matrix that is diagonally dominant.
+func newRandomComplexSym(n int, rnd *rand.Rand) cTestCase {
+	a := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			v := complex(rnd.NormFloat64(), rnd.NormFloat64()) / complex(float64(n), 0)
+			a[i*n+j] = v
+			a[j*n+i] = v
+		}
+		a[i*n+i] = complex(2+rnd.Float64(), 2*rnd.Float64()-1)
+	}
+	tc := newComplexDense(n, a, rnd)
+	tc.name = fmt.Sprintf("random complex symmetric n=%v", n)
+	return tc
+}
+
+// newRandomComplex returns a test case with a random n×n complex matrix that
+// is diagonally dominant.
+func newRandomComplex(n int, rnd *rand.Rand) cTestCase {
+	a := make([]complex128, n*n)
+	for i := range a {
+		a[i] = complex(rnd.NormFloat64(), rnd.NormFloat64()) / complex(float64(n), 0)
+	}
+	for i := 0; i < n; i++ {
+		a[i*n+i] = complex(2+rnd.Float64(), 2*rnd.Float64()-1)
+	}
+	tc := newComplexDense(n, a, rnd)
+	tc.name = fmt.Sprintf("random complex n=%v", n)
+	return tc
+}
+
+// newComplexDense returns a test case with the n×n matrix stored in a in
+// row-major order and a random solution.
+func newComplexDense(n int, a []complex128, rnd *rand.Rand) cTestCase {
+	mulVecTo := func(dst, x []complex128, trans bool) {
+		for i := 0; i < n; i++ {
+			var v complex128
+			for j := 0; j < n; j++ {
+				if trans {
+					v += cmplx.Conj(a[j*n+i]) * x[j]
+				} else {
+					v += a[i*n+j] * x[j]
+				}
+			}
+			dst[i] = v
+		}
+	}
+	diag := make([]complex128, n)
+	for i := range diag {
+		diag[i] = a[i*n+i]
+	}
+	return newComplexTestCase(mulVecTo, diag, rnd)
+}
+
+// newComplexHelmholtz2D returns a test case that arises from a finite-difference
+// discretization of the partial differential equation
+//
+//	-Δu + conv*∂_x u + shift*u = f
+//
+// on the unit square with zero Dirichlet boundary conditions. If conv is zero,
+// the matrix is complex symmetric.
+func newComplexHelmholtz2D(nx, ny int, shift complex128, conv float64, rnd *rand.Rand) cTestCase {
+	// The equation is multiplied by h^2 to keep the matrix elements of
+	// order one.
+	h := 1 / float64(nx+1)
+	center := 4 + complex(h*h, 0)*shift
+	left := complex(-1-conv*h/2, 0)
+	right := complex(-1+conv*h/2, 0)
+	vert := complex(-1, 0)
+	n := nx * ny
+	mulVecTo := func(dst, x []complex128, trans bool) {
+		c, l, r := center, left, right
+		if trans {
+			c, l, r = cmplx.Conj(center), right, left
+		}
+		for iy := 0; iy < ny; iy++ {
+			for ix := 0; ix < nx; ix++ {
+				i := ix + iy*nx
+				v := c * x[i]
+				if ix > 0 {
+					v += l * x[i-1]
+				}
+				if ix < nx-1 {
+					v += r * x[i+1]
+				}
+				if iy > 0 {
+					v += vert * x[i-nx]
+				}
+				if iy < ny-1 {
+					v += vert * x[i+nx]
+				}
+				dst[i] = v
+			}
+		}
+	}
+	diag := make([]complex128, n)
+	for i := range diag {
+		diag[i] = center
+	}
+	tc := newComplexTestCase(mulVecTo, diag, rnd)
+	tc.name = fmt.Sprintf("complex Helmholtz 2D nx=%v,ny=%v,shift=%v,conv=%v", nx, ny, shift, conv)
+	return tc
+}
+
+// newComplexTestCase returns a test case with a random solution and the
+// corresponding right-hand side.
+func newComplexTestCase(mulVecTo func(dst, x []complex128, trans bool), diag []complex128, rnd *rand.Rand) cTestCase {
+	n := len(diag)
+	want := make([]complex128, n)
+	for i := range want {
+		want[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+	}
+	b := make([]complex128, n)
+	mulVecTo(b, want, false)
+	return cTestCase{
+		mulVecTo: mulVecTo,
+		b:        b,
+		diag:     diag,
+		tol:      1e-12,
+		want:     want,
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// LSMR implements the LSMR iterative method for solving damped least-squares
+// problems
+//
+//	minimize |A * x - b|^2 + λ^2 |x|^2,
+//
+// where A is a general m×n matrix. LSMR is analytically equivalent to MINRES
+// applied to the normal equations
+//
+//	(AᵀA + λ^2 I) * x = Aᵀb.
+//
+// Like LSQR, it is based on the Golub-Kahan bidiagonalization of A and requires
+// one multiplication with A and one with Aᵀ in each iteration, but both the
+// norm of the residual |r̄| and the norm of the residual of the normal
+// equations |Āᵀ * r̄| decrease monotonically. LSMR can therefore be stopped
+// earlier than LSQR and it is usually the better choice.
+//
+// References:
+//   - Fong, D., and Saunders, M. (2011). LSMR: An iterative algorithm for
+//     sparse least-squares problems. SIAM J. Sci. Comput., 33(5), 2950-2971.
+//     doi:10.1137/10079687X
+type LSMR struct {
+	damp float64
+
+	x, u, v, h, hbar mat.VecDense
+
+	alpha, beta       float64
+	alphabar, zetabar float64
+	rho, rhobar       float64
+	cbar, sbar        float64
+	zeta              float64
+
+	// Quantities for the estimate of |r̄|.
+	betadd, betad    float64
+	rhodold, taudold float64
+	thetatilde       float64
+	d                float64
+
+	// Quantities for the estimates of |Ā| and cond(Ā).
+	aNorm2           float64
+	maxrbar, minrbar float64
+	aCond            float64
+
+	rNorm, arNorm float64
+
+	iter   int
+	resume int
+}
+
+// Init initializes the data for a least-squares solve. See the LeastSquaresMethod interface for more details.
+func (l *LSMR) Init(b *mat.VecDense, n int, damp float64) {
+	if n <= 0 {
+		panic("lsmr: number of columns not positive")
+	}
+	l.damp = damp
+
+	l.x.Reset()
+	l.x.ReuseAsVec(n)
+	l.u.CloneFromVec(b)
+	l.v.Reset()
+	l.v.ReuseAsVec(n)
+	l.h.Reset()
+	l.h.ReuseAsVec(n)
+	l.hbar.Reset()
+	l.hbar.ReuseAsVec(n)
+
+	l.iter = 0
+	l.resume = 1
+}
+
+// Iterate performs an iteration of the least-squares solve. See the LeastSquaresMethod interface for more details.
+//
+// LSMR will command the following operations:
+//
+//	MulVec
+//	MulVec | Trans
+//	CheckResidualNorm
+//	MajorIteration
+func (l *LSMR) Iterate(ctx *LeastSquaresContext) (Operation, error) {
+	switch l.resume {
+	case 1:
+		// β_1 u_1 = b
+		l.beta = mat.Norm(&l.u, 2)
+		l.u.ScaleVec(1/l.beta, &l.u)
+		ctx.Src.CloneFromVec(&l.u)
+		resizeVec(ctx.Dst, l.x.Len())
+		l.resume = 2
+		// Compute Aᵀ * u_1.
+		return MulVec | Trans, nil
+	case 2:
+		// α_1 v_1 = Aᵀ u_1
+		l.v.CopyVec(ctx.Dst)
+		l.alpha = mat.Norm(&l.v, 2)
+		if l.alpha > 0 {
+			l.v.ScaleVec(1/l.alpha, &l.v)
+		}
+
+		l.zetabar = l.alpha * l.beta
+		l.alphabar = l.alpha
+		l.rho = 1
+		l.rhobar = 1
+		l.cbar = 1
+		l.sbar = 0
+		l.h.CopyVec(&l.v)
+		l.hbar.Zero()
+
+		l.betadd = l.beta
+		l.betad = 0
+		l.rhodold = 1
+		l.taudold = 0
+		l.thetatilde = 0
+		l.zeta = 0
+		l.d = 0
+
+		l.aNorm2 = l.alpha*l.alpha + l.damp*l.damp
+		l.maxrbar = 0
+		l.minrbar = math.Inf(1)
+		l.aCond = 1
+
+		l.rNorm = l.beta
+		l.arNorm = l.alpha * l.beta
+		if l.arNorm == 0 {
+			// b is orthogonal to the range of A, x = 0 is the
+			// least-squares solution and the zero estimate of
+			// |Āᵀ * r̄| satisfies the stopping criterion.
+			l.setEstimates(ctx)
+			l.resume = 6
+			return CheckResidualNorm, nil
+		}
+		fallthrough
+	case 3:
+		ctx.Src.CloneFromVec(&l.v)
+		resizeVec(ctx.Dst, l.u.Len())
+		l.resume = 4
+		// Compute A * v_i.
+		return MulVec, nil
+	case 4:
+		// β_{i+1} u_{i+1} = A v_i - α_i u_i
+		l.u.AddScaledVec(ctx.Dst, -l.alpha, &l.u)
+		l.beta = mat.Norm(&l.u, 2)
+		if l.beta == 0 {
+			// The bidiagonalization terminated, A v_i lies in
+			// the span of u_1, ..., u_i.
+			l.alpha = 0
+			return l.check(ctx), nil
+		}
+		l.u.ScaleVec(1/l.beta, &l.u)
+		ctx.Src.CloneFromVec(&l.u)
+		resizeVec(ctx.Dst, l.x.Len())
+		l.resume = 5
+		// Compute Aᵀ * u_{i+1}.
+		return MulVec | Trans, nil
+	case 5:
+		// α_{i+1} v_{i+1} = Aᵀ u_{i+1} - β_{i+1} v_i
+		l.v.AddScaledVec(ctx.Dst, -l.beta, &l.v)
+		l.alpha = mat.Norm(&l.v, 2)
+		if l.alpha > 0 {
+			l.v.ScaleVec(1/l.alpha, &l.v)
+		}
+		return l.check(ctx), nil
+	case 6:
+		ctx.X.CopyVec(&l.x)
+		if ctx.Converged {
+			l.resume = 0
+			return MajorIteration, nil
+		}
+		l.resume = 3
+		return MajorIteration, nil
+
+	default:
+		panic("lsmr: Init not called")
+	}
+}
+
+// check updates the solution and the estimates and commands the convergence
+// check.
+func (l *LSMR) check(ctx *LeastSquaresContext) Operation {
+	l.update()
+	l.setEstimates(ctx)
+	l.resume = 6
+	return CheckResidualNorm
+}
+
+// update applies the rotations to the bidiagonal matrix and updates the
+// solution and the estimates.
+func (l *LSMR) update() {
+	l.iter++
+
+	// Eliminate the damping parameter with a rotation.
+	chat, shat, alphahat := symOrtho(l.alphabar, l.damp)
+
+	// Use a rotation to turn the lower bidiagonal B_i into the upper
+	// bidiagonal R_i.
+	rhoold := l.rho
+	c, s, rho := symOrtho(alphahat, l.beta)
+	l.rho = rho
+	thetanew := s * l.alpha
+	l.alphabar = c * l.alpha
+
+	// Use a rotation to turn R_iᵀ into the upper bidiagonal R̄_i.
+	rhobarold := l.rhobar
+	zetaold := l.zeta
+	thetabar := l.sbar * rho
+	rhotemp := l.cbar * rho
+	l.cbar, l.sbar, l.rhobar = symOrtho(l.cbar*rho, thetanew)
+	l.zeta = l.cbar * l.zetabar
+	l.zetabar *= -l.sbar
+
+	// Update h, hbar and x.
+	l.hbar.AddScaledVec(&l.h, -thetabar*rho/(rhoold*rhobarold), &l.hbar)
+	l.x.AddScaledVec(&l.x, l.zeta/(rho*l.rhobar), &l.hbar)
+	l.h.AddScaledVec(&l.v, -thetanew/rho, &l.h)
+
+	// Estimate |r̄| by applying the rotations to the right-hand side of
+	// the bidiagonal least-squares problem.
+	betaacute := chat * l.betadd
+	betacheck := -shat * l.betadd
+	betahat := c * betaacute
+	l.betadd = -s * betaacute
+	thetatildeold := l.thetatilde
+	ctildeold, stildeold, rhotildeold := symOrtho(l.rhodold, thetabar)
+	l.thetatilde = stildeold * l.rhobar
+	l.rhodold = ctildeold * l.rhobar
+	l.betad = -stildeold*l.betad + ctildeold*betahat
+	l.taudold = (zetaold - thetatildeold*l.taudold) / rhotildeold
+	taud := (l.zeta - l.thetatilde*l.taudold) / l.rhodold
+	l.d += betacheck * betacheck
+	l.rNorm = math.Sqrt(l.d + (l.betad-taud)*(l.betad-taud) + l.betadd*l.betadd)
+
+	// Estimate |Ā| and cond(Ā).
+	l.aNorm2 += l.beta*l.beta + l.alpha*l.alpha + l.damp*l.damp
+	l.maxrbar = math.Max(l.maxrbar, rhobarold)
+	if l.iter > 1 {
+		l.minrbar = math.Min(l.minrbar, rhobarold)
+	}
+	l.aCond = math.Max(l.maxrbar, rhotemp) / math.Min(l.minrbar, rhotemp)
+
+	l.arNorm = math.Abs(l.zetabar)
+}
+
+func (l *LSMR) setEstimates(ctx *LeastSquaresContext) {
+	ctx.ResidualNorm = l.rNorm
+	ctx.NormalResidualNorm = l.arNorm
+	ctx.ANorm = math.Sqrt(l.aNorm2)
+	ctx.ACond = l.aCond
+	ctx.XNorm = mat.Norm(&l.x, 2)
+}
@@ -0,0 +1,218 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// LSQR implements the LSQR iterative method for solving damped least-squares
+// problems
+//
+//	minimize |A * x - b|^2 + λ^2 |x|^2,
+//
+// where A is a general m×n matrix. LSQR is analytically equivalent to CG
+// applied to the normal equations
+//
+//	(AᵀA + λ^2 I) * x = Aᵀb,
+//
+// but is numerically more reliable. It is based on the Golub-Kahan
+// bidiagonalization of A and requires one multiplication with A and one with
+// Aᵀ in each iteration. The norm of the residual |r̄| decreases monotonically.
+//
+// References:
+//   - Paige, C., and Saunders, M. (1982). LSQR: An algorithm for sparse linear
+//     equations and sparse least squares. ACM Transactions on Mathematical
+//     Software, 8(1), 43-71. doi:10.1145/355984.355989
+type LSQR struct {
+	damp float64
+
+	x, u, v, w mat.VecDense
+
+	alpha, beta    float64
+	rhobar, phibar float64
+	cs2, sn2, z    float64
+
+	// Quantities for the estimates of norms.
+	aNorm2, ddNorm float64
+	res2           float64
+	xNorm, xxNorm  float64
+	rNorm, arNorm  float64
+
+	resume int
+}
+
+// Init initializes the data for a least-squares solve. See the LeastSquaresMethod interface for more details.
+func (l *LSQR) Init(b *mat.VecDense, n int, damp float64) {
+	if n <= 0 {
+		panic("lsqr: number of columns not positive")
+	}
+	l.damp = damp
+
+	l.x.Reset()
+	l.x.ReuseAsVec(n)
+	l.w.Reset()
+	l.w.ReuseAsVec(n)
+	l.u.CloneFromVec(b)
+	l.v.Reset()
+	l.v.ReuseAsVec(n)
+
+	l.aNorm2 = 0
+	l.ddNorm = 0
+	l.res2 = 0
+	l.xxNorm = 0
+	l.z = 0
+	l.cs2 = -1
+	l.sn2 = 0
+
+	l.resume = 1
+}
+
+// Iterate performs an iteration of the least-squares solve. See the LeastSquaresMethod interface for more details.
+//
+// LSQR will command the following operations:
+//
+//	MulVec
+//	MulVec | Trans
+//	CheckResidualNorm
+//	MajorIteration
+func (l *LSQR) Iterate(ctx *LeastSquaresContext) (Operation, error) {
+	switch l.resume {
+	case 1:
+		// β_1 u_1 = b
+		l.beta = mat.Norm(&l.u, 2)
+		l.u.ScaleVec(1/l.beta, &l.u)
+		ctx.Src.CloneFromVec(&l.u)
+		resizeVec(ctx.Dst, l.x.Len())
+		l.resume = 2
+		// Compute Aᵀ * u_1.
+		return MulVec | Trans, nil
+	case 2:
+		// α_1 v_1 = Aᵀ u_1
+		l.v.CopyVec(ctx.Dst)
+		l.alpha = mat.Norm(&l.v, 2)
+		if l.alpha > 0 {
+			l.v.ScaleVec(1/l.alpha, &l.v)
+		}
+		l.w.CopyVec(&l.v)
+		l.rhobar = l.alpha
+		l.phibar = l.beta
+		l.rNorm = l.beta
+		l.arNorm = l.alpha * l.beta
+		if l.arNorm == 0 {
+			// b is orthogonal to the range of A, x = 0 is the
+			// least-squares solution and the zero estimate of
+			// |Āᵀ * r̄| satisfies the stopping criterion.
+			l.setEstimates(ctx)
+			l.resume = 6
+			return CheckResidualNorm, nil
+		}
+		fallthrough
+	case 3:
+		ctx.Src.CloneFromVec(&l.v)
+		resizeVec(ctx.Dst, l.u.Len())
+		l.resume = 4
+		// Compute A * v_i.
+		return MulVec, nil
+	case 4:
+		// β_{i+1} u_{i+1} = A v_i - α_i u_i
+		l.u.AddScaledVec(ctx.Dst, -l.alpha, &l.u)
+		l.beta = mat.Norm(&l.u, 2)
+		l.aNorm2 += l.alpha*l.alpha + l.beta*l.beta + l.damp*l.damp
+		if l.beta == 0 {
+			// The bidiagonalization terminated, A v_i lies in
+			// the span of u_1, ..., u_i.
+			l.alpha = 0
+			return l.check(ctx), nil
+		}
+		l.u.ScaleVec(1/l.beta, &l.u)
+		ctx.Src.CloneFromVec(&l.u)
+		resizeVec(ctx.Dst, l.x.Len())
+		l.resume = 5
+		// Compute Aᵀ * u_{i+1}.
+		return MulVec | Trans, nil
+	case 5:
+		// α_{i+1} v_{i+1} = Aᵀ u_{i+1} - β_{i+1} v_i
+		l.v.AddScaledVec(ctx.Dst, -l.beta, &l.v)
+		l.alpha = mat.Norm(&l.v, 2)
+		if l.alpha > 0 {
+			l.v.ScaleVec(1/l.alpha, &l.v)
+		}
+		return l.check(ctx), nil
+	case 6:
+		ctx.X.CopyVec(&l.x)
+		if ctx.Converged {
+			l.resume = 0
+			return MajorIteration, nil
+		}
+		l.resume = 3
+		return MajorIteration, nil
+
+	default:
+		panic("lsqr: Init not called")
+	}
+}
+
+// check updates the solution and the estimates and commands the convergence
+// check.
+func (l *LSQR) check(ctx *LeastSquaresContext) Operation {
+	l.update()
+	l.setEstimates(ctx)
+	l.resume = 6
+	return CheckResidualNorm
+}
+
+// update eliminates the damping parameter and the subdiagonal element of the
+// bidiagonal matrix and updates the solution and the estimates.
+func (l *LSQR) update() {
+	// Eliminate the damping parameter with a rotation.
+	rhobar1 := l.rhobar
+	var psi float64
+	if l.damp > 0 {
+		var cs1, sn1 float64
+		cs1, sn1, rhobar1 = symOrtho(l.rhobar, l.damp)
+		psi = sn1 * l.phibar
+		l.phibar *= cs1
+	}
+
+	// Eliminate the subdiagonal element β_{i+1}.
+	cs, sn, rho := symOrtho(rhobar1, l.beta)
+	theta := sn * l.alpha
+	l.rhobar = -cs * l.alpha
+	phi := cs * l.phibar
+	l.phibar *= sn
+	tau := sn * phi
+
+	// Update x and w.
+	l.ddNorm += mat.Dot(&l.w, &l.w) / (rho * rho)
+	l.x.AddScaledVec(&l.x, phi/rho, &l.w)
+	l.w.AddScaledVec(&l.v, -theta/rho, &l.w)
+
+	// Estimate |x| using the LQ factorization of the upper bidiagonal
+	// matrix R.
+	delta := l.sn2 * rho
+	gambar := -l.cs2 * rho
+	rhs := phi - delta*l.z
+	zbar := rhs / gambar
+	l.xNorm = math.Sqrt(l.xxNorm + zbar*zbar)
+	var gamma float64
+	l.cs2, l.sn2, gamma = symOrtho(gambar, theta)
+	l.z = rhs / gamma
+	l.xxNorm += l.z * l.z
+
+	l.res2 += psi * psi
+	l.rNorm = math.Sqrt(l.phibar*l.phibar + l.res2)
+	l.arNorm = l.alpha * math.Abs(tau)
+}
+
+func (l *LSQR) setEstimates(ctx *LeastSquaresContext) {
+	ctx.ResidualNorm = l.rNorm
+	ctx.NormalResidualNorm = l.arNorm
+	ctx.ANorm = math.Sqrt(l.aNorm2)
+	ctx.ACond = ctx.ANorm * math.Sqrt(l.ddNorm)
+	ctx.XNorm = l.xNorm
+}
@@ -0,0 +1,193 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// ErrIndefinitePreconditioner is returned by methods that require a symmetric
+// positive definite preconditioner when an indefinite one is detected.
+var ErrIndefinitePreconditioner = errors.New("linsolve: preconditioner not positive definite")
+
+// MINRES implements the Minimum Residual method with preconditioning for
+// solving systems of linear equations
+//
+//	A * x = b,
+//
+// where A is a symmetric, possibly indefinite, nonsingular matrix. Like CG,
+// MINRES is based on the Lanczos process and requires only a few vectors of
+// storage, but unlike CG it minimizes the residual norm and does not break
+// down on indefinite matrices such as those arising from saddle-point
+// problems. The preconditioner must be symmetric positive definite. If it is
+// detected to be indefinite, ErrIndefinitePreconditioner is returned.
+//
+// The residual norm reported at CheckResidualNorm is the norm of the residual
+// in the M⁻¹-norm, where M is the preconditioner. Without preconditioning it
+// is the Euclidean norm.
+//
+// References:
+//   - Paige, C., and Saunders, M. (1975). Solution of sparse indefinite systems
+//     of linear equations. SIAM J. Numer. Anal., 12(4), 617-629.
+//     doi:10.1137/0712047
+//   - Choi, S.-C. T. (2006). Iterative Methods for Singular Linear Equations
+//     and Least-Squares Problems (PhD thesis). Stanford University.
+type MINRES struct {
+	x mat.VecDense
+	// r1 and r2 hold the last two unnormalized Lanczos vectors.
+	r1, r2 mat.VecDense
+	y      mat.VecDense
+	v      mat.VecDense
+	// w, w1 and w2 hold the last three search directions.
+	w, w1, w2 mat.VecDense
+
+	alpha          float64
+	beta, betaPrev float64
+	dbar           float64
+	epsln          float64
+	phibar         float64
+	cs, sn         float64
+
+	k      int // Iteration counter.
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (m *MINRES) Init(x, residual *mat.VecDense) {
+	dim := x.Len()
+	if residual.Len() != dim {
+		panic("minres: vector length mismatch")
+	}
+
+	m.x.CloneFromVec(x)
+	m.r1.CloneFromVec(residual)
+	m.r2.CloneFromVec(residual)
+
+	m.y.Reset()
+	m.y.ReuseAsVec(dim)
+	m.v.Reset()
+	m.v.ReuseAsVec(dim)
+	m.w.Reset()
+	m.w.ReuseAsVec(dim)
+	m.w1.Reset()
+	m.w1.ReuseAsVec(dim)
+	m.w2.Reset()
+	m.w2.ReuseAsVec(dim)
+
+	m.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// MINRES will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+//	NoOperation
+func (m *MINRES) Iterate(ctx *Context) (Operation, error) {
+	switch m.resume {
+	case 1:
+		ctx.Src.CopyVec(&m.r1)
+		m.resume = 2
+		// Solve M^{-1} * r_0.
+		return PreconSolve, nil
+	case 2:
+		m.y.CopyVec(ctx.Dst)
+		beta1 := mat.Dot(&m.r1, &m.y)
+		if beta1 < 0 {
+			m.resume = 0
+			return NoOperation, ErrIndefinitePreconditioner
+		}
+		m.beta = math.Sqrt(beta1)
+		m.betaPrev = 0
+		m.dbar = 0
+		m.epsln = 0
+		m.phibar = m.beta
+		m.cs = -1
+		m.sn = 0
+		m.w.Zero()
+		m.w1.Zero()
+		m.w2.Zero()
+		m.k = 0
+		fallthrough
+	case 3:
+		// v_k = y / β_k is the k-th Lanczos vector.
+		m.v.ScaleVec(1/m.beta, &m.y)
+		ctx.Src.CopyVec(&m.v)
+		m.resume = 4
+		// Compute A * v_k.
+		return MulVec, nil
+	case 4:
+		// Continue the three-term Lanczos recurrence.
+		m.y.CopyVec(ctx.Dst)
+		if m.k > 0 {
+			m.y.AddScaledVec(&m.y, -m.beta/m.betaPrev, &m.r1)
+		}
+		m.alpha = mat.Dot(&m.v, &m.y)
+		m.y.AddScaledVec(&m.y, -m.alpha/m.beta, &m.r2)
+		m.r1, m.r2 = m.r2, m.r1
+		m.r2.CopyVec(&m.y)
+		ctx.Src.CopyVec(&m.r2)
+		m.resume = 5
+		// Solve M^{-1} * r2.
+		return PreconSolve, nil
+	case 5:
+		m.y.CopyVec(ctx.Dst)
+		m.betaPrev = m.beta
+		beta2 := mat.Dot(&m.r2, &m.y)
+		if beta2 < 0 {
+			m.resume = 0
+			return NoOperation, ErrIndefinitePreconditioner
+		}
+		m.beta = math.Sqrt(beta2)
+
+		// Apply the previous Givens rotation to the new column of the
+		// tridiagonal matrix.
+		epslnPrev := m.epsln
+		delta := m.cs*m.dbar + m.sn*m.alpha
+		gbar := m.sn*m.dbar - m.cs*m.alpha
+		m.epsln = m.sn * m.beta
+		m.dbar = -m.cs * m.beta
+
+		// Compute the next Givens rotation that annihilates β_{k+1}.
+		gamma := math.Max(math.Hypot(gbar, m.beta), eps)
+		m.cs = gbar / gamma
+		m.sn = m.beta / gamma
+		phi := m.cs * m.phibar
+		m.phibar *= m.sn
+
+		// Update the search direction and the solution.
+		m.w1, m.w2, m.w = m.w2, m.w, m.w1
+		m.w.AddScaledVec(&m.v, -epslnPrev, &m.w1)
+		m.w.AddScaledVec(&m.w, -delta, &m.w2)
+		m.w.ScaleVec(1/gamma, &m.w)
+		m.x.AddScaledVec(&m.x, phi, &m.w)
+
+		ctx.ResidualNorm = m.phibar
+		m.resume = 6
+		return CheckResidualNorm, nil
+	case 6:
+		ctx.X.CopyVec(&m.x)
+		if ctx.Converged {
+			m.resume = 0
+			return MajorIteration, nil
+		}
+		if m.beta < breakdownTol {
+			m.resume = 0
+			return NoOperation, &BreakdownError{m.beta, breakdownTol}
+		}
+		m.k++
+		m.resume = 3
+		return MajorIteration, nil
+
+	default:
+		panic("minres: Init not called")
+	}
+}
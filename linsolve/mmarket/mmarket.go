@@ -0,0 +1,300 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mmarket provides a reader and a writer for the Matrix Market
+// exchange format.
+//
+// The Matrix Market format stores a single matrix in a text file. The first
+// line of the file is a header of the form
+//
+//	%%MatrixMarket matrix <format> <field> <symmetry>
+//
+// followed by optional comment lines starting with '%', a line with the size of
+// the matrix and the matrix entries. The format is either coordinate for
+// sparse matrices or array for dense matrices, the field is one of real,
+// integer, complex or pattern and the symmetry is one of general, symmetric,
+// skew-symmetric or hermitian. For matrices with a symmetry other than
+// general, only the entries in the lower triangle are stored.
+//
+// References:
+//   - Boisvert, R., Pozo, R., and Remington, K. (1996). The Matrix Market
+//     exchange formats: Initial design. NISTIR 5935. National Institute of
+//     Standards and Technology.
+//   - https://math.nist.gov/MatrixMarket/formats.html
+package mmarket
+
+import (
+	"errors"
+	"fmt"
+	"math/cmplx"
+
+	"gonum.org/v1/exp/linsolve/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	// ErrTruncatedHeader is returned when the header line does not contain
+	// all required fields.
+	ErrTruncatedHeader = errors.New("mmarket: truncated header")
+	// ErrInvalidHeader is returned when the header line is not a valid
+	// Matrix Market header or it specifies an invalid combination of format,
+	// field and symmetry.
+	ErrInvalidHeader = errors.New("mmarket: invalid header")
+	// ErrInvalidSize is returned when the size line is missing or invalid.
+	ErrInvalidSize = errors.New("mmarket: invalid size line")
+	// ErrInvalidEntry is returned when an entry is malformed, its indices are
+	// out of range or it is not in the stored triangle of a matrix with
+	// symmetry.
+	ErrInvalidEntry = errors.New("mmarket: invalid entry")
+	// ErrNotReal is returned when a complex matrix with non-zero imaginary
+	// parts is converted to a real matrix.
+	ErrNotReal = errors.New("mmarket: matrix is not real")
+)
+
+// ParseError is returned by Reader.Read when the input cannot be parsed. It
+// wraps one of the errors defined in this package or io.ErrUnexpectedEOF if
+// the input ends prematurely.
+type ParseError struct {
+	// Line is the 1-based line number at which the error was detected.
+	Line int
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%v on line %d", e.Err, e.Line)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Format is the storage format of a matrix.
+type Format string
+
+const (
+	// Coordinate is the sparse format which stores the non-zero entries with
+	// their indices.
+	Coordinate Format = "coordinate"
+	// Array is the dense format which stores all entries in column-major
+	// order.
+	Array Format = "array"
+)
+
+// Field is the type of the matrix entries.
+type Field string
+
+const (
+	Real    Field = "real"
+	Integer Field = "integer"
+	Complex Field = "complex"
+	// Pattern specifies that only the positions of the non-zero entries are
+	// stored. The value of each entry is 1. Pattern can be used only with the
+	// Coordinate format.
+	Pattern Field = "pattern"
+)
+
+// Symmetry is the symmetry structure of a square matrix.
+type Symmetry string
+
+const (
+	// General specifies a matrix without symmetry. All entries are stored.
+	General Symmetry = "general"
+	// Symmetric specifies a matrix with a_ji = a_ij. The entries on and below
+	// the diagonal are stored.
+	Symmetric Symmetry = "symmetric"
+	// SkewSymmetric specifies a matrix with a_ji = -a_ij. The entries below
+	// the diagonal are stored, the diagonal is zero.
+	SkewSymmetric Symmetry = "skew-symmetric"
+	// Hermitian specifies a complex matrix with a_ji = conj(a_ij). The
+	// entries on and below the diagonal are stored. Hermitian can be used
+	// only with the Complex field.
+	Hermitian Symmetry = "hermitian"
+)
+
+// Header describes a matrix stored in the Matrix Market format.
+type Header struct {
+	Format   Format
+	Field    Field
+	Symmetry Symmetry
+
+	// Rows and Cols are the dimensions of the matrix.
+	Rows, Cols int
+
+	// Comments holds the comment lines without the leading '%'.
+	Comments []string
+}
+
+// validate checks that h describes a valid matrix.
+func (h *Header) validate() error {
+	switch h.Format {
+	case Coordinate, Array:
+	default:
+		return ErrInvalidHeader
+	}
+	switch h.Field {
+	case Real, Integer, Complex:
+	case Pattern:
+		if h.Format == Array {
+			return ErrInvalidHeader
+		}
+	default:
+		return ErrInvalidHeader
+	}
+	switch h.Symmetry {
+	case General:
+	case Symmetric:
+	case SkewSymmetric:
+		if h.Field == Pattern {
+			return ErrInvalidHeader
+		}
+	case Hermitian:
+		if h.Field != Complex {
+			return ErrInvalidHeader
+		}
+	default:
+		return ErrInvalidHeader
+	}
+	if h.Rows < 0 || h.Cols < 0 {
+		return ErrInvalidSize
+	}
+	if h.Symmetry != General && h.Rows != h.Cols {
+		return ErrInvalidSize
+	}
+	return nil
+}
+
+// Entry is an entry of a matrix. The indices are 0-based.
+type Entry struct {
+	Row, Col int
+	// Value is the value of the entry. For the Real and Integer fields the
+	// imaginary part is zero and for the Pattern field the value is 1.
+	Value complex128
+}
+
+// Matrix is a matrix in the Matrix Market format.
+type Matrix struct {
+	Header
+
+	// Entries holds the stored entries of the matrix. For the Array format
+	// they are in column-major order. For matrices with a symmetry other than
+	// General, only entries in the stored triangle are held.
+	Entries []Entry
+}
+
+// checkEntry returns whether the entry e is valid for the matrix described
+// by h.
+func (h *Header) checkEntry(e Entry) bool {
+	if e.Row < 0 || h.Rows <= e.Row || e.Col < 0 || h.Cols <= e.Col {
+		return false
+	}
+	switch h.Symmetry {
+	case Symmetric:
+		return e.Row >= e.Col
+	case SkewSymmetric:
+		return e.Row > e.Col
+	case Hermitian:
+		return e.Row > e.Col || (e.Row == e.Col && imag(e.Value) == 0)
+	}
+	return true
+}
+
+// Expand returns all entries of the matrix m including the entries that are
+// implied by its symmetry.
+func (m *Matrix) Expand() []Entry {
+	entries := make([]Entry, 0, len(m.Entries))
+	for _, e := range m.Entries {
+		entries = append(entries, e)
+		if e.Row == e.Col {
+			continue
+		}
+		switch m.Symmetry {
+		case Symmetric:
+			entries = append(entries, Entry{e.Col, e.Row, e.Value})
+		case SkewSymmetric:
+			entries = append(entries, Entry{e.Col, e.Row, -e.Value})
+		case Hermitian:
+			entries = append(entries, Entry{e.Col, e.Row, cmplx.Conj(e.Value)})
+		}
+	}
+	return entries
+}
+
+// COO returns the real matrix m as a sparse matrix in coordinate format with
+// all entries including those implied by its symmetry. COO returns ErrNotReal
+// if an entry has a non-zero imaginary part.
+func (m *Matrix) COO() (*sparse.COO, error) {
+	if m.Rows == 0 || m.Cols == 0 {
+		return nil, ErrInvalidSize
+	}
+	a := sparse.NewCOO(m.Rows, m.Cols)
+	for _, e := range m.Expand() {
+		if imag(e.Value) != 0 {
+			return nil, ErrNotReal
+		}
+		a.Append(e.Row, e.Col, real(e.Value))
+	}
+	return a, nil
+}
+
+// FromMatrix returns the real matrix a in the Coordinate format with the given
+// symmetry. Only the non-zero elements of a are stored. If a implements
+// mat.NonZeroDoer, it is used to obtain the non-zero elements, otherwise all
+// elements are queried with At. FromMatrix returns an error if sym is Hermitian
+// or if a does not have the symmetry.
+func FromMatrix(a mat.Matrix, sym Symmetry) (*Matrix, error) {
+	r, c := a.Dims()
+	m := &Matrix{
+		Header: Header{
+			Format:   Coordinate,
+			Field:    Real,
+			Symmetry: sym,
+			Rows:     r,
+			Cols:     c,
+		},
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	add := func(i, j int, v float64) {
+		if v != 0 {
+			m.Entries = append(m.Entries, Entry{i, j, complex(v, 0)})
+		}
+	}
+	if nz, ok := a.(mat.NonZeroDoer); ok {
+		nz.DoNonZero(add)
+	} else {
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				add(i, j, a.At(i, j))
+			}
+		}
+	}
+	if sym == General {
+		return m, nil
+	}
+
+	// Check the symmetry and keep only the entries in the stored triangle.
+	sum := make(map[[2]int]float64)
+	for _, e := range m.Entries {
+		sum[[2]int{e.Row, e.Col}] += real(e.Value)
+	}
+	sign := 1.0
+	if sym == SkewSymmetric {
+		sign = -1
+	}
+	for ij, v := range sum {
+		if sum[[2]int{ij[1], ij[0]}] != sign*v {
+			return nil, fmt.Errorf("mmarket: matrix is not %v", sym)
+		}
+	}
+	lower := m.Entries[:0]
+	for _, e := range m.Entries {
+		if m.checkEntry(e) {
+			lower = append(lower, e)
+		}
+	}
+	m.Entries = lower
+	return m, nil
+}
@@ -0,0 +1,606 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mmarket
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name string
+		in   string
+		want Matrix
+	}{
+		{
+			name: "coordinate real general",
+			in: `%%MatrixMarket matrix coordinate real general
+% A 5x5 sparse matrix with 8 nonzeros
+%
+  5  5  8
+    1     1   1.000e+00
+    2     2   1.050e+01
+    3     3   1.500e-02
+    1     4   6.000e+00
+    4     2   2.505e+02
+    4     4  -2.800e+02
+    4     5   3.332e+01
+    5     5   1.200e+01
+`,
+			want: Matrix{
+				Header: Header{
+					Format: Coordinate, Field: Real, Symmetry: General, Rows: 5, Cols: 5,
+					Comments: []string{" A 5x5 sparse matrix with 8 nonzeros", ""},
+				},
+				Entries: []Entry{
+					{0, 0, 1}, {1, 1, 10.5}, {2, 2, 0.015}, {0, 3, 6},
+					{3, 1, 250.5}, {3, 3, -280}, {3, 4, 33.32}, {4, 4, 12},
+				},
+			},
+		},
+		{
+			name: "array real general",
+			in: `%%MatrixMarket matrix array real general
+2 3
+1
+2
+
+3
+4
+5
+6
+`,
+			want: Matrix{
+				Header: Header{Format: Array, Field: Real, Symmetry: General, Rows: 2, Cols: 3},
+				Entries: []Entry{
+					{0, 0, 1}, {1, 0, 2}, {0, 1, 3}, {1, 1, 4}, {0, 2, 5}, {1, 2, 6},
+				},
+			},
+		},
+		{
+			name: "array integer symmetric",
+			in: `%%MatrixMarket matrix array integer symmetric
+3 3
+1
+2
+3
+4
+5
+6
+`,
+			want: Matrix{
+				Header: Header{Format: Array, Field: Integer, Symmetry: Symmetric, Rows: 3, Cols: 3},
+				Entries: []Entry{
+					{0, 0, 1}, {1, 0, 2}, {2, 0, 3}, {1, 1, 4}, {2, 1, 5}, {2, 2, 6},
+				},
+			},
+		},
+		{
+			name: "array real skew-symmetric",
+			in: `%%MatrixMarket matrix array real skew-symmetric
+3 3
+1
+2
+3
+`,
+			want: Matrix{
+				Header: Header{Format: Array, Field: Real, Symmetry: SkewSymmetric, Rows: 3, Cols: 3},
+				Entries: []Entry{
+					{1, 0, 1}, {2, 0, 2}, {2, 1, 3},
+				},
+			},
+		},
+		{
+			name: "coordinate pattern symmetric",
+			in: `%%MatrixMarket matrix coordinate pattern symmetric
+3 3 2
+1 1
+3 2
+`,
+			want: Matrix{
+				Header: Header{Format: Coordinate, Field: Pattern, Symmetry: Symmetric, Rows: 3, Cols: 3},
+				Entries: []Entry{
+					{0, 0, 1}, {2, 1, 1},
+				},
+			},
+		},
+		{
+			name: "coordinate complex hermitian",
+			in: `%%MatrixMarket MATRIX COORDINATE COMPLEX HERMITIAN
+2 2 3
+1 1 1.5 0
+2 1 -2 3.25
+2 2 4 0
+`,
+			want: Matrix{
+				Header: Header{Format: Coordinate, Field: Complex, Symmetry: Hermitian, Rows: 2, Cols: 2},
+				Entries: []Entry{
+					{0, 0, 1.5}, {1, 0, complex(-2, 3.25)}, {1, 1, 4},
+				},
+			},
+		},
+	} {
+		got, err := NewReader(strings.NewReader(test.in)).Read()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(*got, test.want) {
+			t.Errorf("%s: unexpected matrix\ngot: %+v\nwant:%+v", test.name, *got, test.want)
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name string
+		in   string
+		want error
+		line int
+	}{
+		{
+			name: "empty",
+			in:   "",
+			want: ErrTruncatedHeader,
+			line: 1,
+		},
+		{
+			name: "truncated header",
+			in:   "%%MatrixMarket matrix coordinate\n1 1 1\n1 1 1\n",
+			want: ErrTruncatedHeader,
+			line: 1,
+		},
+		{
+			name: "missing banner",
+			in:   "%MatrixMarket matrix coordinate real general\n1 1 0\n",
+			want: ErrInvalidHeader,
+			line: 1,
+		},
+		{
+			name: "extra header field",
+			in:   "%%MatrixMarket matrix coordinate real general extra\n1 1 0\n",
+			want: ErrInvalidHeader,
+			line: 1,
+		},
+		{
+			name: "vector object",
+			in:   "%%MatrixMarket vector coordinate real general\n1 1 0\n",
+			want: ErrInvalidHeader,
+			line: 1,
+		},
+		{
+			name: "array pattern",
+			in:   "%%MatrixMarket matrix array pattern general\n1 1\n",
+			want: ErrInvalidHeader,
+			line: 1,
+		},
+		{
+			name: "real hermitian",
+			in:   "%%MatrixMarket matrix coordinate real hermitian\n1 1 0\n",
+			want: ErrInvalidHeader,
+			line: 1,
+		},
+		{
+			name: "missing size",
+			in:   "%%MatrixMarket matrix coordinate real general\n% comment\n",
+			want: io.ErrUnexpectedEOF,
+			line: 3,
+		},
+		{
+			name: "short size",
+			in:   "%%MatrixMarket matrix coordinate real general\n2 2\n",
+			want: ErrInvalidSize,
+			line: 2,
+		},
+		{
+			name: "non-square symmetric",
+			in:   "%%MatrixMarket matrix coordinate real symmetric\n2 3 1\n1 1 1\n",
+			want: ErrInvalidSize,
+			line: 2,
+		},
+		{
+			name: "too many entries",
+			in:   "%%MatrixMarket matrix coordinate real symmetric\n2 2 4\n",
+			want: ErrInvalidSize,
+			line: 2,
+		},
+		{
+			name: "entries in empty skew-symmetric",
+			in:   "%%MatrixMarket matrix coordinate real skew-symmetric\n0 0 1\n",
+			want: ErrInvalidSize,
+			line: 2,
+		},
+		{
+			name: "line too long",
+			in:   "%%MatrixMarket matrix coordinate real general\n%" + strings.Repeat("x", 1<<20) + "\n1 1 0\n",
+			want: bufio.ErrTooLong,
+			line: 2,
+		},
+		{
+			name: "too few entries",
+			in:   "%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+			want: io.ErrUnexpectedEOF,
+			line: 4,
+		},
+		{
+			name: "huge truncated coordinate",
+			in:   "%%MatrixMarket matrix coordinate real general\n100000 100000 1000000000\n1 1 1\n",
+			want: io.ErrUnexpectedEOF,
+			line: 4,
+		},
+		{
+			name: "huge truncated array",
+			in:   "%%MatrixMarket matrix array real general\n100000 100000\n1\n",
+			want: io.ErrUnexpectedEOF,
+			line: 4,
+		},
+		{
+			name: "overflowing size",
+			in:   "%%MatrixMarket matrix coordinate real general\n4294967296 4294967296 5\n",
+			want: io.ErrUnexpectedEOF,
+			line: 3,
+		},
+		{
+			name: "too few array entries",
+			in:   "%%MatrixMarket matrix array real general\n2 2\n1\n2\n3\n",
+			want: io.ErrUnexpectedEOF,
+			line: 6,
+		},
+		{
+			name: "index out of range",
+			in:   "%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+			want: ErrInvalidEntry,
+			line: 3,
+		},
+		{
+			name: "zero index",
+			in:   "%%MatrixMarket matrix coordinate real general\n2 2 1\n0 1 1\n",
+			want: ErrInvalidEntry,
+			line: 3,
+		},
+		{
+			name: "upper triangle",
+			in:   "%%MatrixMarket matrix coordinate real symmetric\n2 2 1\n1 2 1\n",
+			want: ErrInvalidEntry,
+			line: 3,
+		},
+		{
+			name: "skew-symmetric diagonal",
+			in:   "%%MatrixMarket matrix coordinate real skew-symmetric\n2 2 1\n1 1 1\n",
+			want: ErrInvalidEntry,
+			line: 3,
+		},
+		{
+			name: "complex hermitian diagonal",
+			in:   "%%MatrixMarket matrix coordinate complex hermitian\n2 2 1\n1 1 1 1\n",
+			want: ErrInvalidEntry,
+			line: 3,
+		},
+		{
+			name: "missing value",
+			in:   "%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1\n",
+			want: ErrInvalidEntry,
+			line: 3,
+		},
+		{
+			name: "non-integer value",
+			in:   "%%MatrixMarket matrix coordinate integer general\n2 2 1\n1 1 1.5\n",
+			want: ErrInvalidEntry,
+			line: 3,
+		},
+		{
+			name: "pattern with value",
+			in:   "%%MatrixMarket matrix coordinate pattern general\n2 2 1\n1 1 1\n",
+			want: ErrInvalidEntry,
+			line: 3,
+		},
+	} {
+		_, err := NewReader(strings.NewReader(test.in)).Read()
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s: unexpected error: got %v, want %v", test.name, err, test.want)
+			continue
+		}
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%s: error is not a *ParseError: %v", test.name, err)
+			continue
+		}
+		if perr.Line != test.line {
+			t.Errorf("%s: unexpected line: got %d, want %d", test.name, perr.Line, test.line)
+		}
+	}
+
+	// Errors of the underlying reader are annotated with the line number.
+	errRead := errors.New("read error")
+	in := io.MultiReader(strings.NewReader("%%MatrixMarket matrix coordinate real general\n"), iotest.ErrReader(errRead))
+	_, err := NewReader(in).Read()
+	var perr *ParseError
+	if !errors.Is(err, errRead) || !errors.As(err, &perr) || perr.Line != 2 {
+		t.Errorf("read error: unexpected error %v", err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+	const n = 4
+	for _, format := range []Format{Coordinate, Array} {
+		for _, field := range []Field{Real, Integer, Complex, Pattern} {
+			for _, sym := range []Symmetry{General, Symmetric, SkewSymmetric, Hermitian} {
+				m := Matrix{
+					Header: Header{
+						Format:   format,
+						Field:    field,
+						Symmetry: sym,
+						Rows:     n,
+						Cols:     n,
+						Comments: []string{" round trip", ""},
+					},
+				}
+				if sym == General {
+					m.Cols = n + 1
+				}
+				if m.validate() != nil {
+					continue
+				}
+				name := strings.Join([]string{string(format), string(field), string(sym)}, " ")
+
+				// Generate the stored entries in column-major order so that
+				// they match the order of the Array format.
+				for j := 0; j < m.Cols; j++ {
+					for i := 0; i < m.Rows; i++ {
+						v := complex(float64(3*i-2*j+1), 0)
+						switch field {
+						case Real:
+							v /= 7
+						case Complex:
+							v = complex(real(v)/3, float64(i-j)/5)
+						case Pattern:
+							v = 1
+						}
+						if sym == Hermitian && i == j {
+							v = complex(real(v), 0)
+						}
+						e := Entry{Row: i, Col: j, Value: v}
+						if !m.checkEntry(e) {
+							continue
+						}
+						if format == Coordinate && (i+j)%2 == 1 {
+							// Leave out some entries of the sparse matrix.
+							continue
+						}
+						m.Entries = append(m.Entries, e)
+					}
+				}
+
+				var buf bytes.Buffer
+				err := NewWriter(&buf).Write(&m)
+				if err != nil {
+					t.Errorf("%s: unexpected error from Write: %v", name, err)
+					continue
+				}
+				got, err := NewReader(&buf).Read()
+				if err != nil {
+					t.Errorf("%s: unexpected error from Read: %v", name, err)
+					continue
+				}
+				if !reflect.DeepEqual(*got, m) {
+					t.Errorf("%s: round trip mismatch\ngot: %+v\nwant:%+v", name, *got, m)
+				}
+			}
+		}
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name string
+		m    Matrix
+		want error
+	}{
+		{
+			name: "invalid header",
+			m: Matrix{
+				Header: Header{Format: Array, Field: Pattern, Symmetry: General, Rows: 1, Cols: 1},
+			},
+			want: ErrInvalidHeader,
+		},
+		{
+			name: "multiline comment",
+			m: Matrix{
+				Header: Header{Format: Coordinate, Field: Real, Symmetry: General, Rows: 1, Cols: 1,
+					Comments: []string{"a\nb"}},
+			},
+			want: ErrInvalidHeader,
+		},
+		{
+			name: "upper triangle",
+			m: Matrix{
+				Header:  Header{Format: Coordinate, Field: Real, Symmetry: Symmetric, Rows: 2, Cols: 2},
+				Entries: []Entry{{0, 1, 1}},
+			},
+			want: ErrInvalidEntry,
+		},
+		{
+			name: "out of range",
+			m: Matrix{
+				Header:  Header{Format: Array, Field: Real, Symmetry: General, Rows: 2, Cols: 2},
+				Entries: []Entry{{2, 0, 1}},
+			},
+			want: ErrInvalidEntry,
+		},
+		{
+			name: "non-integer",
+			m: Matrix{
+				Header:  Header{Format: Coordinate, Field: Integer, Symmetry: General, Rows: 2, Cols: 2},
+				Entries: []Entry{{0, 0, 0.5}},
+			},
+			want: ErrInvalidEntry,
+		},
+		{
+			name: "complex real",
+			m: Matrix{
+				Header:  Header{Format: Coordinate, Field: Real, Symmetry: General, Rows: 2, Cols: 2},
+				Entries: []Entry{{0, 0, 1i}},
+			},
+			want: ErrNotReal,
+		},
+	} {
+		var buf bytes.Buffer
+		err := NewWriter(&buf).Write(&test.m)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s: unexpected error: got %v, want %v", test.name, err, test.want)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("%s: unexpected output after error: %q", test.name, buf.String())
+		}
+	}
+}
+
+func TestCOO(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		in   string
+		want *mat.Dense
+	}{
+		{
+			in: `%%MatrixMarket matrix coordinate real symmetric
+3 3 4
+1 1 2
+2 1 -1
+3 2 -1
+3 3 2
+`,
+			want: mat.NewDense(3, 3, []float64{
+				2, -1, 0,
+				-1, 0, -1,
+				0, -1, 2,
+			}),
+		},
+		{
+			in: `%%MatrixMarket matrix array integer skew-symmetric
+3 3
+1
+2
+3
+`,
+			want: mat.NewDense(3, 3, []float64{
+				0, -1, -2,
+				1, 0, -3,
+				2, 3, 0,
+			}),
+		},
+		{
+			in: `%%MatrixMarket matrix coordinate pattern general
+2 3 3
+1 1
+2 3
+1 1
+`,
+			want: mat.NewDense(2, 3, []float64{
+				2, 0, 0,
+				0, 0, 1,
+			}),
+		},
+	} {
+		m, err := NewReader(strings.NewReader(test.in)).Read()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		a, err := m.COO()
+		if err != nil {
+			t.Errorf("unexpected error from COO: %v", err)
+			continue
+		}
+		if !mat.Equal(a, test.want) {
+			t.Errorf("unexpected matrix\ngot:  %v\nwant: %v", mat.Formatted(a), mat.Formatted(test.want))
+		}
+	}
+
+	m := &Matrix{
+		Header:  Header{Format: Coordinate, Field: Complex, Symmetry: Hermitian, Rows: 2, Cols: 2},
+		Entries: []Entry{{1, 0, 1 + 1i}},
+	}
+	_, err := m.COO()
+	if err != ErrNotReal {
+		t.Errorf("unexpected error for complex matrix: got %v, want %v", err, ErrNotReal)
+	}
+}
+
+func TestFromMatrix(t *testing.T) {
+	t.Parallel()
+	sym := mat.NewDense(3, 3, []float64{
+		4, 1, 0,
+		1, 4, 2,
+		0, 2, 4,
+	})
+	skew := mat.NewDense(3, 3, []float64{
+		0, 1, 0,
+		-1, 0, -2,
+		0, 2, 0,
+	})
+	for _, test := range []struct {
+		a    mat.Matrix
+		sym  Symmetry
+		fail bool
+	}{
+		{a: sym, sym: General},
+		{a: sym, sym: Symmetric},
+		{a: sym, sym: SkewSymmetric, fail: true},
+		{a: sym, sym: Hermitian, fail: true},
+		{a: skew, sym: SkewSymmetric},
+		{a: skew, sym: Symmetric, fail: true},
+		{a: mat.NewDense(2, 3, []float64{1, 0, 2, 0, 3, 0}), sym: General},
+		{a: mat.NewDense(2, 3, []float64{1, 0, 2, 0, 3, 0}), sym: Symmetric, fail: true},
+	} {
+		m, err := FromMatrix(test.a, test.sym)
+		if test.fail {
+			if err == nil {
+				t.Errorf("expected error for %v matrix", test.sym)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %v matrix: %v", test.sym, err)
+			continue
+		}
+		for _, e := range m.Entries {
+			if !m.checkEntry(e) {
+				t.Errorf("%v: invalid entry %+v", test.sym, e)
+			}
+		}
+
+		var buf bytes.Buffer
+		err = NewWriter(&buf).Write(m)
+		if err != nil {
+			t.Errorf("%v: unexpected error from Write: %v", test.sym, err)
+			continue
+		}
+		m, err = NewReader(&buf).Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error from Read: %v", test.sym, err)
+			continue
+		}
+		got, err := m.COO()
+		if err != nil {
+			t.Errorf("%v: unexpected error from COO: %v", test.sym, err)
+			continue
+		}
+		if !mat.Equal(got, test.a) {
+			t.Errorf("%v: unexpected matrix\ngot:  %v\nwant: %v", test.sym, mat.Formatted(got), mat.Formatted(test.a))
+		}
+	}
+}
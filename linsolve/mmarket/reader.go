@@ -0,0 +1,282 @@
+// Copyright ©2017 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mmarket
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Reader reads a matrix in the Matrix Market format.
+type Reader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+// NewReader returns a new Reader that reads from r.
+func NewReader(r io.Reader) *Reader {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	return &Reader{s: s}
+}
+
+// Read reads a matrix. If the input is not a valid Matrix Market file, Read
+// returns a *ParseError.
+func (r *Reader) Read() (*Matrix, error) {
+	var m Matrix
+	if err := r.readHeader(&m.Header); err != nil {
+		return nil, err
+	}
+	nnz, err := r.readSize(&m)
+	if err != nil {
+		return nil, err
+	}
+	if m.Format == Array {
+		err = r.readArray(&m)
+	} else {
+		err = r.readCoordinate(&m, nnz)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// next advances to the next line and returns it. It returns io.EOF or
+// io.ErrUnexpectedEOF if there are no more lines.
+func (r *Reader) next(eof error) (string, error) {
+	if !r.s.Scan() {
+		r.line++
+		if err := r.s.Err(); err != nil {
+			return "", r.errorf(err)
+		}
+		return "", r.errorf(eof)
+	}
+	r.line++
+	return r.s.Text(), nil
+}
+
+// errorf returns err annotated with the current line number.
+func (r *Reader) errorf(err error) error {
+	return &ParseError{Line: r.line, Err: err}
+}
+
+func (r *Reader) readHeader(h *Header) error {
+	line, err := r.next(ErrTruncatedHeader)
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 || fields[0] != "%%MatrixMarket" {
+		return r.errorf(ErrInvalidHeader)
+	}
+	if len(fields) < 5 {
+		return r.errorf(ErrTruncatedHeader)
+	}
+	if len(fields) > 5 || strings.ToLower(fields[1]) != "matrix" {
+		return r.errorf(ErrInvalidHeader)
+	}
+	h.Format = Format(strings.ToLower(fields[2]))
+	h.Field = Field(strings.ToLower(fields[3]))
+	h.Symmetry = Symmetry(strings.ToLower(fields[4]))
+	if err := h.validate(); err != nil {
+		return r.errorf(err)
+	}
+	return nil
+}
+
+// readSize reads the comments and the size line. For the Coordinate format it
+// returns the number of entries.
+func (r *Reader) readSize(m *Matrix) (nnz int, err error) {
+	for {
+		line, err := r.next(io.ErrUnexpectedEOF)
+		if err != nil {
+			return 0, err
+		}
+		if strings.HasPrefix(line, "%") {
+			m.Comments = append(m.Comments, line[1:])
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		want := 3
+		if m.Format == Array {
+			want = 2
+		}
+		if len(fields) != want {
+			return 0, r.errorf(ErrInvalidSize)
+		}
+		size := make([]int, want)
+		for i, f := range fields {
+			size[i], err = strconv.Atoi(f)
+			if err != nil || size[i] < 0 {
+				return 0, r.errorf(ErrInvalidSize)
+			}
+		}
+		m.Rows, m.Cols = size[0], size[1]
+		if err := m.validate(); err != nil {
+			return 0, r.errorf(err)
+		}
+		if m.Format == Array {
+			return 0, nil
+		}
+		nnz = size[2]
+		if nnz > m.maxEntries() {
+			return 0, r.errorf(ErrInvalidSize)
+		}
+		return nnz, nil
+	}
+}
+
+// maxPrealloc is the maximum number of entries that are preallocated from the
+// size line. Larger matrices grow their entries as they are read, so that a
+// truncated file with a huge size line does not exhaust the memory.
+const maxPrealloc = 1 << 16
+
+// maxEntries returns the number of entries in the stored triangle, or
+// math.MaxInt if the number overflows int.
+func (h *Header) maxEntries() int {
+	switch h.Symmetry {
+	case Symmetric, Hermitian:
+		return triangle(h.Rows, h.Rows+1)
+	case SkewSymmetric:
+		if h.Rows == 0 {
+			return 0
+		}
+		return triangle(h.Rows-1, h.Rows)
+	}
+	return mulInt(h.Rows, h.Cols)
+}
+
+// triangle returns a*b/2 for consecutive non-negative integers a and b, or
+// math.MaxInt if the result overflows int.
+func triangle(a, b int) int {
+	if a%2 == 0 {
+		a /= 2
+	} else {
+		b /= 2
+	}
+	return mulInt(a, b)
+}
+
+// mulInt returns a*b for non-negative a and b, or math.MaxInt if the product
+// overflows int.
+func mulInt(a, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
+
+// readEntryLine returns the fields of the next non-empty line.
+func (r *Reader) readEntryLine() ([]string, error) {
+	for {
+		line, err := r.next(io.ErrUnexpectedEOF)
+		if err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(line)
+		if len(fields) > 0 {
+			return fields, nil
+		}
+	}
+}
+
+// parseValue parses the value of an entry from fields according to the field
+// type of m.
+func (m *Matrix) parseValue(fields []string) (complex128, bool) {
+	switch m.Field {
+	case Pattern:
+		return 1, len(fields) == 0
+	case Integer:
+		if len(fields) != 1 {
+			return 0, false
+		}
+		v, err := strconv.ParseInt(fields[0], 10, 64)
+		return complex(float64(v), 0), err == nil
+	case Real:
+		if len(fields) != 1 {
+			return 0, false
+		}
+		v, err := strconv.ParseFloat(fields[0], 64)
+		return complex(v, 0), err == nil
+	case Complex:
+		if len(fields) != 2 {
+			return 0, false
+		}
+		re, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, false
+		}
+		im, err := strconv.ParseFloat(fields[1], 64)
+		return complex(re, im), err == nil
+	}
+	panic("mmarket: invalid field")
+}
+
+func (r *Reader) readCoordinate(m *Matrix, nnz int) error {
+	m.Entries = make([]Entry, 0, min(nnz, maxPrealloc))
+	for k := 0; k < nnz; k++ {
+		fields, err := r.readEntryLine()
+		if err != nil {
+			return err
+		}
+		if len(fields) < 2 {
+			return r.errorf(ErrInvalidEntry)
+		}
+		i, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return r.errorf(ErrInvalidEntry)
+		}
+		j, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return r.errorf(ErrInvalidEntry)
+		}
+		v, ok := m.parseValue(fields[2:])
+		if !ok {
+			return r.errorf(ErrInvalidEntry)
+		}
+		e := Entry{Row: i - 1, Col: j - 1, Value: v}
+		if !m.checkEntry(e) {
+			return r.errorf(ErrInvalidEntry)
+		}
+		m.Entries = append(m.Entries, e)
+	}
+	return nil
+}
+
+func (r *Reader) readArray(m *Matrix) error {
+	m.Entries = make([]Entry, 0, min(m.maxEntries(), maxPrealloc))
+	for j := 0; j < m.Cols; j++ {
+		start := 0
+		switch m.Symmetry {
+		case Symmetric, Hermitian:
+			start = j
+		case SkewSymmetric:
+			start = j + 1
+		}
+		for i := start; i < m.Rows; i++ {
+			fields, err := r.readEntryLine()
+			if err != nil {
+				return err
+			}
+			v, ok := m.parseValue(fields)
+			if !ok {
+				return r.errorf(ErrInvalidEntry)
+			}
+			e := Entry{Row: i, Col: j, Value: v}
+			if !m.checkEntry(e) {
+				return r.errorf(ErrInvalidEntry)
+			}
+			m.Entries = append(m.Entries, e)
+		}
+	}
+	return nil
+}
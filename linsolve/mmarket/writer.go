@@ -0,0 +1,145 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mmarket
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Writer writes a matrix in the Matrix Market format.
+type Writer struct {
+	w   *bufio.Writer
+	buf []byte
+}
+
+// NewWriter returns a new Writer that writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Write writes the matrix m. Write returns an error if the header of m is
+// invalid, if an entry is not valid for the matrix described by the header or
+// if its value cannot be represented in the field of m. Entries of a matrix in
+// the Array format that are not present in m.Entries are written as zero,
+// duplicate entries are summed.
+func (w *Writer) Write(m *Matrix) error {
+	if err := m.validate(); err != nil {
+		return err
+	}
+	for _, c := range m.Comments {
+		if strings.ContainsAny(c, "\r\n") {
+			return ErrInvalidHeader
+		}
+	}
+	for _, e := range m.Entries {
+		if !m.checkEntry(e) {
+			return ErrInvalidEntry
+		}
+		switch m.Field {
+		case Real:
+			if imag(e.Value) != 0 {
+				return ErrNotReal
+			}
+		case Integer:
+			if imag(e.Value) != 0 {
+				return ErrNotReal
+			}
+			v := real(e.Value)
+			if v != math.Trunc(v) || v < -1<<63 || 1<<63 <= v {
+				return ErrInvalidEntry
+			}
+		}
+	}
+
+	w.w.WriteString("%%MatrixMarket matrix ")
+	w.w.WriteString(string(m.Format))
+	w.w.WriteByte(' ')
+	w.w.WriteString(string(m.Field))
+	w.w.WriteByte(' ')
+	w.w.WriteString(string(m.Symmetry))
+	w.w.WriteByte('\n')
+	for _, c := range m.Comments {
+		w.w.WriteByte('%')
+		w.w.WriteString(c)
+		w.w.WriteByte('\n')
+	}
+
+	if m.Format == Array {
+		w.writeArray(m)
+	} else {
+		w.writeCoordinate(m)
+	}
+	return w.w.Flush()
+}
+
+func (w *Writer) writeCoordinate(m *Matrix) {
+	w.writeInts(m.Rows, m.Cols, len(m.Entries))
+	for _, e := range m.Entries {
+		w.buf = strconv.AppendInt(w.buf[:0], int64(e.Row+1), 10)
+		w.buf = append(w.buf, ' ')
+		w.buf = strconv.AppendInt(w.buf, int64(e.Col+1), 10)
+		if m.Field != Pattern {
+			w.buf = append(w.buf, ' ')
+			w.buf = m.appendValue(w.buf, e.Value)
+		}
+		w.buf = append(w.buf, '\n')
+		w.w.Write(w.buf)
+	}
+}
+
+func (w *Writer) writeArray(m *Matrix) {
+	w.writeInts(m.Rows, m.Cols)
+	a := make([]complex128, m.Rows*m.Cols)
+	for _, e := range m.Entries {
+		a[e.Row+e.Col*m.Rows] += e.Value
+	}
+	for j := 0; j < m.Cols; j++ {
+		start := 0
+		switch m.Symmetry {
+		case Symmetric, Hermitian:
+			start = j
+		case SkewSymmetric:
+			start = j + 1
+		}
+		for i := start; i < m.Rows; i++ {
+			w.buf = m.appendValue(w.buf[:0], a[i+j*m.Rows])
+			w.buf = append(w.buf, '\n')
+			w.w.Write(w.buf)
+		}
+	}
+}
+
+// writeInts writes a line with the space-separated integers v.
+func (w *Writer) writeInts(v ...int) {
+	w.buf = w.buf[:0]
+	for i, n := range v {
+		if i > 0 {
+			w.buf = append(w.buf, ' ')
+		}
+		w.buf = strconv.AppendInt(w.buf, int64(n), 10)
+	}
+	w.buf = append(w.buf, '\n')
+	w.w.Write(w.buf)
+}
+
+// appendValue appends the value v formatted according to the field type of m
+// to dst.
+func (m *Matrix) appendValue(dst []byte, v complex128) []byte {
+	switch m.Field {
+	case Integer:
+		return strconv.AppendInt(dst, int64(real(v)), 10)
+	case Real:
+		return strconv.AppendFloat(dst, real(v), 'g', -1, 64)
+	case Complex:
+		dst = strconv.AppendFloat(dst, real(v), 'g', -1, 64)
+		dst = append(dst, ' ')
+		return strconv.AppendFloat(dst, imag(v), 'g', -1, 64)
+	}
+	panic("mmarket: invalid field")
+}
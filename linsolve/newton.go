@@ -0,0 +1,388 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// ErrLineSearch is returned by NewtonKrylov when the backtracking line search
+// does not find a step that sufficiently decreases the norm of the residual
+// function.
+var ErrLineSearch = errors.New("linsolve: line search failed")
+
+const (
+	// defaultFDStep is the default relative step of the finite-difference
+	// approximation of Jacobian-vector products, the square root of the
+	// unit roundoff.
+	defaultFDStep = 1.4901161193847656e-08
+
+	// Parameters of the second Eisenstat–Walker choice of the forcing
+	// terms.
+	forcingGamma = 0.9
+	forcingAlpha = 2
+
+	// sufficientDecrease is the parameter of the sufficient decrease
+	// condition in the line search.
+	sufficientDecrease = 1e-4
+)
+
+// JacobianFree is a MulVecToer that approximates the product of the Jacobian
+// J of a function F at the point X with a vector v by the forward difference
+//
+//	J*v ≈ (F(X + h*v) - F(X)) / h,
+//
+// where the step is h = Step * (1 + |X|) / |v|. Each product requires one
+// evaluation of F. The transpose of the Jacobian is not available, so
+// JacobianFree can be used only with methods that do not command
+// MulVec|Trans.
+type JacobianFree struct {
+	// F evaluates the function and stores F(x) into dst.
+	F func(dst, x *mat.VecDense)
+
+	// X is the point at which the Jacobian is approximated and FX holds
+	// F(X).
+	X, FX *mat.VecDense
+
+	// Step is the relative step of the finite difference. If it is zero,
+	// a default value of the square root of the unit roundoff will be
+	// used.
+	Step float64
+
+	xh mat.VecDense
+}
+
+// MulVecTo computes J*v and stores the result into dst. MulVecTo panics if
+// trans is true.
+func (j *JacobianFree) MulVecTo(dst *mat.VecDense, trans bool, v mat.Vector) {
+	if trans {
+		panic("linsolve: transpose of Jacobian-free operator not available")
+	}
+	vNorm := mat.Norm(v, 2)
+	if vNorm == 0 {
+		dst.Zero()
+		return
+	}
+	step := j.Step
+	if step == 0 {
+		step = defaultFDStep
+	}
+	h := step * (1 + mat.Norm(j.X, 2)) / vNorm
+	j.xh.AddScaledVec(j.X, h, v)
+	j.F(dst, &j.xh)
+	dst.SubVec(dst, j.FX)
+	dst.ScaleVec(1/h, dst)
+}
+
+// NewtonSettings holds settings for the Newton–Krylov nonlinear solver.
+type NewtonSettings struct {
+	// Dst, if not nil, will be used for storing the approximate solution,
+	// otherwise a new vector will be allocated. In both cases the vector will
+	// also be returned in NewtonResult. If Dst is not empty, its length must
+	// be equal to the dimension of the system.
+	Dst *mat.VecDense
+
+	// Tolerance specifies error tolerance for the final (approximate)
+	// solution. The iteration will be stopped when
+	//  |F(x_k)| < Tolerance * |F(x_0)|.
+	//
+	// If Tolerance is zero, a default value of 1e-8 will be used, otherwise
+	// it must be positive and less than 1.
+	Tolerance float64
+
+	// MaxIterations is the limit on the number of Newton iterations. If it
+	// is zero, a default value of 50 will be used.
+	MaxIterations int
+
+	// InitForcing is the forcing term of the first Newton iteration, that
+	// is, the relative tolerance of its inner linear solve. If it is zero,
+	// a default value of 0.5 will be used, otherwise it must be positive
+	// and not larger than MaxForcing.
+	InitForcing float64
+
+	// MaxForcing is the upper bound on the forcing terms. If it is zero,
+	// a default value of 0.9 will be used, otherwise it must be positive
+	// and less than 1.
+	MaxForcing float64
+
+	// MaxBacktracks is the limit on the number of step reductions in the
+	// line search of each Newton iteration. If it is zero, a default value
+	// of 10 will be used.
+	MaxBacktracks int
+
+	// Step is the relative step of the finite-difference Jacobian-vector
+	// products. See the JacobianFree documentation for more information.
+	Step float64
+
+	// Inner holds the settings for the inner linear solves. Inner.InitX,
+	// Inner.Dst and Inner.Stop must be nil and Inner.Tolerance must be zero
+	// because the tolerance is given by the forcing terms. Other fields are
+	// interpreted as described in the Settings documentation.
+	Inner Settings
+}
+
+// NewtonResult holds the result of the Newton–Krylov nonlinear solver.
+type NewtonResult struct {
+	// X is the approximate solution.
+	X *mat.VecDense
+
+	// ResidualNorm is the norm of F at the approximate solution.
+	ResidualNorm float64
+
+	// Iterations is the number of Newton iterations.
+	Iterations int
+
+	// FuncEvaluations is the number of evaluations of F including those
+	// in the Jacobian-vector products.
+	FuncEvaluations int
+
+	// Stats holds statistics about the inner solves accumulated over all
+	// Newton iterations.
+	Stats Stats
+}
+
+func defaultNewtonSettings(s *NewtonSettings, dim int) {
+	if s.Dst == nil {
+		s.Dst = mat.NewVecDense(dim, nil)
+	} else if s.Dst.Len() == 0 {
+		s.Dst.ReuseAsVec(dim)
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = defaultTolerance
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 50
+	}
+	if s.MaxForcing == 0 {
+		s.MaxForcing = 0.9
+	}
+	if s.InitForcing == 0 {
+		s.InitForcing = math.Min(0.5, s.MaxForcing)
+	}
+	if s.MaxBacktracks == 0 {
+		s.MaxBacktracks = 10
+	}
+	if s.Step == 0 {
+		s.Step = defaultFDStep
+	}
+	if s.Inner.Work == nil {
+		// Reuse the work context in all inner solves.
+		s.Inner.Work = NewContext(dim)
+	}
+}
+
+func checkNewtonSettings(s *NewtonSettings, dim int) {
+	if s.Dst.Len() != dim {
+		panic("linsolve: mismatched destination length")
+	}
+	if s.Tolerance <= 0 || 1 <= s.Tolerance {
+		panic("linsolve: invalid tolerance")
+	}
+	if s.MaxIterations <= 0 {
+		panic("linsolve: negative iteration limit")
+	}
+	if s.MaxForcing <= 0 || 1 <= s.MaxForcing {
+		panic("linsolve: invalid maximum forcing term")
+	}
+	if s.InitForcing <= 0 || s.MaxForcing < s.InitForcing {
+		panic("linsolve: invalid initial forcing term")
+	}
+	if s.MaxBacktracks <= 0 {
+		panic("linsolve: negative backtrack limit")
+	}
+	if s.Step <= 0 {
+		panic("linsolve: invalid finite-difference step")
+	}
+	if s.Inner.InitX != nil || s.Inner.Dst != nil {
+		panic("linsolve: initial guess or destination set for inner solve")
+	}
+	if s.Inner.Tolerance != 0 || s.Inner.Stop != nil {
+		panic("linsolve: tolerance or stopping criterion set for inner solve")
+	}
+}
+
+// NewtonKrylov finds an approximate solution of the system of n nonlinear
+// equations
+//
+//	F(x) = 0
+//
+// by the inexact Newton method starting from the initial guess x0. f evaluates
+// F and stores F(x) into dst, it must not modify x.
+//
+// Each Newton iteration approximately solves the linear system
+//
+//	J(x_k)*d_k = -F(x_k)
+//
+// with Iterative and the method m, where J(x_k) is the Jacobian of F at x_k.
+// The Jacobian is not formed, its products with vectors are approximated by
+// finite differences of F, see JacobianFree. The relative tolerance η_k of the
+// inner solve, the forcing term, is chosen by the second rule of Eisenstat and
+// Walker
+//
+//	η_k = 0.9 * (|F(x_k)| / |F(x_{k-1})|)^2
+//
+// with safeguards, so that the linear systems are solved only loosely far from
+// the solution and more accurately close to it, which preserves the fast local
+// convergence of Newton's method without oversolving.
+//
+// The step is globalized by a backtracking line search. The step length λ is
+// halved until the sufficient decrease condition
+//
+//	|F(x_k + λ*d_k)| <= (1 - 1e-4*λ*(1 - η_k)) * |F(x_k)|
+//
+// holds and the forcing term is increased accordingly. If the condition does
+// not hold after settings.MaxBacktracks reductions, NewtonKrylov returns
+// ErrLineSearch and the approximate solution before the failed iteration. If
+// the tolerance is not reached in settings.MaxIterations iterations,
+// NewtonKrylov returns ErrIterationLimit. An ErrIterationLimit from an inner
+// solve is not an error, the partial solution is used as the step. Other
+// errors from the inner solve are returned.
+//
+// Preconditioning of the inner solves is specified by settings.Inner.PreconSolve,
+// for example with an approximation of the Jacobian that is updated by the
+// caller. m must not command MulVec|Trans because the transpose of the
+// Jacobian is not available. If m is nil, default GMRES will be used.
+//
+// settings provide means for adjusting parameters of the nonlinear solve. See
+// the NewtonSettings documentation for more information. NewtonKrylov will not
+// modify the fields of NewtonSettings. If settings is nil, default settings will
+// be used.
+//
+// References:
+//   - Eisenstat, S. C., and Walker, H. F. (1996). Choosing the forcing terms in
+//     an inexact Newton method. SIAM Journal on Scientific Computing, 17(1),
+//     16-32. doi:10.1137/0917003
+//   - Knoll, D. A., and Keyes, D. E. (2004). Jacobian-free Newton–Krylov
+//     methods: a survey of approaches and applications. Journal of
+//     Computational Physics, 193(2), 357-397. doi:10.1016/j.jcp.2003.08.010
+//   - Pernice, M., and Walker, H. F. (1998). NITSOL: a Newton iterative solver
+//     for nonlinear systems. SIAM Journal on Scientific Computing, 19(1),
+//     302-318. doi:10.1137/S1064827596303843
+func NewtonKrylov(f func(dst, x *mat.VecDense), x0 *mat.VecDense, m Method, settings *NewtonSettings) (*NewtonResult, error) {
+	n := x0.Len()
+
+	var s NewtonSettings
+	if settings != nil {
+		s = *settings
+	}
+	defaultNewtonSettings(&s, n)
+	checkNewtonSettings(&s, n)
+
+	if m == nil {
+		m = &GMRES{}
+	}
+
+	result := &NewtonResult{X: s.Dst}
+	eval := func(dst, x *mat.VecDense) {
+		result.FuncEvaluations++
+		f(dst, x)
+	}
+
+	x := s.Dst
+	x.CopyVec(x0)
+	fx := mat.NewVecDense(n, nil)
+	eval(fx, x)
+	result.ResidualNorm = mat.Norm(fx, 2)
+	tol := s.Tolerance * result.ResidualNorm
+
+	jac := &JacobianFree{
+		F:    eval,
+		X:    x,
+		FX:   fx,
+		Step: s.Step,
+	}
+	inner := s.Inner
+	d := mat.NewVecDense(n, nil)
+	inner.Dst = d
+	rhs := mat.NewVecDense(n, nil)
+	xNew := mat.NewVecDense(n, nil)
+	fxNew := mat.NewVecDense(n, nil)
+	eta := s.InitForcing
+	for {
+		fNorm := result.ResidualNorm
+		if fNorm <= tol {
+			return result, nil
+		}
+		if result.Iterations == s.MaxIterations {
+			return result, ErrIterationLimit
+		}
+
+		// Solve J(x_k)*d_k = -F(x_k) to the relative tolerance η_k.
+		// The right-hand side is scaled to unit norm because the
+		// initial residual of Iterative is compared with the
+		// tolerance.
+		rhs.ScaleVec(-1/fNorm, fx)
+		inner.Tolerance = eta
+		res, err := Iterative(jac, rhs, m, &inner)
+		result.Iterations++
+		result.Stats.Iterations += res.Stats.Iterations
+		result.Stats.MulVec += res.Stats.MulVec
+		result.Stats.PreconSolve += res.Stats.PreconSolve
+		if err != nil && err != ErrIterationLimit {
+			return result, err
+		}
+
+		// Backtrack until the norm of F decreases sufficiently. The
+		// accepted step is left in xNew and fxNew.
+		var fNormNew float64
+		_, eta, fNormNew, err = backtrack(func(lambda float64) float64 {
+			xNew.AddScaledVec(x, lambda*fNorm, d)
+			eval(fxNew, xNew)
+			return mat.Norm(fxNew, 2)
+		}, fNorm, eta, s.MaxBacktracks)
+		if err != nil {
+			return result, err
+		}
+		x.CopyVec(xNew)
+		fx.CopyVec(fxNew)
+		result.ResidualNorm = fNormNew
+
+		eta = forcingTerm(eta, fNormNew, fNorm, tol, s.MaxForcing)
+	}
+}
+
+// backtrack performs the backtracking line search for the Newton step with the
+// forcing term eta. normAt returns the norm of F at the iterate with the step
+// length λ and fNorm is the norm of F at the current iterate. The step length
+// is halved until the sufficient decrease condition holds for the forcing term
+// eta of the inner solve. backtrack returns the accepted step length, the
+// forcing term 1 - λ*(1 - eta) corresponding to the shortened step, and the
+// norm of F at the accepted iterate. It returns ErrLineSearch if the condition
+// does not hold after maxBacktracks reductions.
+func backtrack(normAt func(lambda float64) float64, fNorm, eta float64, maxBacktracks int) (lambda, etaNew, fNormNew float64, err error) {
+	lambda = 1
+	for i := 0; ; i++ {
+		fNormNew = normAt(lambda)
+		if fNormNew <= (1-sufficientDecrease*lambda*(1-eta))*fNorm {
+			return lambda, 1 - lambda*(1-eta), fNormNew, nil
+		}
+		if i == maxBacktracks {
+			return lambda, eta, fNormNew, ErrLineSearch
+		}
+		lambda /= 2
+	}
+}
+
+// forcingTerm returns the next forcing term from the previous forcing term eta
+// and the norms of F at the current and the previous iterate by the second
+// rule of Eisenstat and Walker with the safeguards from Kelley (1995).
+//
+// References:
+//   - Kelley, C. T. (1995). Section 6.3 Choosing the forcing term. In
+//     Iterative Methods for Linear and Nonlinear Equations (pp. 105-106).
+//     Philadelphia, PA: SIAM.
+func forcingTerm(eta, fNorm, fNormPrev, tol, maxForcing float64) float64 {
+	etaNew := forcingGamma * math.Pow(fNorm/fNormPrev, forcingAlpha)
+	// Prevent the forcing terms from decreasing too fast.
+	if safe := forcingGamma * math.Pow(eta, forcingAlpha); safe > 0.1 {
+		etaNew = math.Max(etaNew, safe)
+	}
+	etaNew = math.Min(etaNew, maxForcing)
+	// Avoid oversolving in the last iteration.
+	return math.Min(maxForcing, math.Max(etaNew, 0.5*tol/fNorm))
+}
@@ -0,0 +1,175 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+	"sync"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+// parallelBlock is the number of vector elements that form a block in the
+// parallel vector kernels. The partial results of reductions are computed for
+// blocks of this fixed size and summed in order of the blocks, so the results
+// do not depend on the number of workers.
+const parallelBlock = 1 << 13
+
+// kernels performs the vector operations of a Method. If workers is zero, the
+// operations are delegated to the serial gonum/mat implementation, otherwise
+// the vectors are split into blocks which are processed by workers goroutines.
+type kernels struct {
+	workers int
+	// partial holds the partial results of reductions, one for each
+	// block.
+	partial []float64
+}
+
+// init sets the number of workers of k. It panics if workers is negative.
+func (k *kernels) init(workers int) {
+	if workers < 0 {
+		panic("linsolve: negative number of workers")
+	}
+	k.workers = workers
+}
+
+// run calls fn for contiguous ranges of the blocks [0, nb) concurrently and
+// waits until all calls have returned.
+func (k *kernels) run(nb int, fn func(lo, hi int)) {
+	w := min(k.workers, nb)
+	if w <= 1 {
+		fn(0, nb)
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(w)
+	for i := 0; i < w; i++ {
+		lo := i * nb / w
+		hi := (i + 1) * nb / w
+		go func() {
+			defer wg.Done()
+			fn(lo, hi)
+		}()
+	}
+	wg.Wait()
+}
+
+// blocks returns the number of blocks of a vector of length n and resizes
+// the partial results.
+func (k *kernels) blocks(n int) int {
+	nb := (n + parallelBlock - 1) / parallelBlock
+	if cap(k.partial) < nb {
+		k.partial = make([]float64, nb)
+	}
+	k.partial = k.partial[:nb]
+	return nb
+}
+
+// block returns the i-th block of v.
+func block(v blas64.Vector, i int) blas64.Vector {
+	lo := i * parallelBlock
+	n := min(parallelBlock, v.N-lo)
+	return blas64.Vector{
+		N:    n,
+		Inc:  v.Inc,
+		Data: v.Data[lo*v.Inc : (lo+n-1)*v.Inc+1],
+	}
+}
+
+// dot returns the dot product x · y.
+func (k *kernels) dot(x, y *mat.VecDense) float64 {
+	if k.workers == 0 {
+		return mat.Dot(x, y)
+	}
+	if x.Len() != y.Len() {
+		panic("linsolve: vector length mismatch")
+	}
+	if x.Len() == 0 {
+		return 0
+	}
+	xr, yr := x.RawVector(), y.RawVector()
+	nb := k.blocks(xr.N)
+	k.run(nb, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			k.partial[i] = blas64.Dot(block(xr, i), block(yr, i))
+		}
+	})
+	var sum float64
+	for _, v := range k.partial {
+		sum += v
+	}
+	return sum
+}
+
+// norm returns the Euclidean norm of x.
+func (k *kernels) norm(x *mat.VecDense) float64 {
+	if k.workers == 0 {
+		return mat.Norm(x, 2)
+	}
+	if x.Len() == 0 {
+		return 0
+	}
+	xr := x.RawVector()
+	nb := k.blocks(xr.N)
+	k.run(nb, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			k.partial[i] = blas64.Nrm2(block(xr, i))
+		}
+	})
+	// Combine the norms of the blocks with scaling to avoid overflow.
+	var scale float64
+	for _, v := range k.partial {
+		scale = math.Max(scale, v)
+	}
+	if scale == 0 || math.IsInf(scale, 1) {
+		return scale
+	}
+	var sum float64
+	for _, v := range k.partial {
+		v /= scale
+		sum += v * v
+	}
+	return scale * math.Sqrt(sum)
+}
+
+// addScaled computes dst = a + alpha * b.
+func (k *kernels) addScaled(dst, a *mat.VecDense, alpha float64, b *mat.VecDense) {
+	if k.workers == 0 {
+		dst.AddScaledVec(a, alpha, b)
+		return
+	}
+	n := a.Len()
+	if b.Len() != n || dst.Len() != n {
+		panic("linsolve: vector length mismatch")
+	}
+	if n == 0 {
+		return
+	}
+	dr, ar, br := dst.RawVector(), a.RawVector(), b.RawVector()
+	k.run(k.blocks(n), func(lo, hi int) {
+		for i := lo * parallelBlock; i < min(hi*parallelBlock, n); i++ {
+			dr.Data[i*dr.Inc] = ar.Data[i*ar.Inc] + alpha*br.Data[i*br.Inc]
+		}
+	})
+}
+
+// scale computes x = alpha * x.
+func (k *kernels) scale(alpha float64, x *mat.VecDense) {
+	if k.workers == 0 {
+		x.ScaleVec(alpha, x)
+		return
+	}
+	n := x.Len()
+	if n == 0 {
+		return
+	}
+	xr := x.RawVector()
+	k.run(k.blocks(n), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			blas64.Scal(alpha, block(xr, i))
+		}
+	})
+}
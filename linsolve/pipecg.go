@@ -0,0 +1,232 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Reducer computes the global reductions of PipelinedCG. It is intended to be
+// implemented together with a MulVecToer whose vectors are distributed across
+// processes, so that the reductions can be overlapped with the matrix-vector
+// product.
+type Reducer interface {
+	// Reduce starts computing the dot products
+	//  dst[i] = x[i] · y[i]
+	// and returns a function that waits for the results. Reduce may return
+	// before the results are available, but they must be stored in dst
+	// when wait returns. Between the calls to Reduce and wait, PipelinedCG
+	// commands one PreconSolve and one MulVec operation. The vectors in x
+	// and y will not be modified before wait returns.
+	Reduce(dst []float64, x, y []*mat.VecDense) (wait func())
+}
+
+// PipelinedCG implements the pipelined Conjugate Gradient iterative method with
+// preconditioning for solving systems of linear equations
+//
+//	A * x = b,
+//
+// where A is a symmetric positive definite matrix.
+//
+// In exact arithmetic PipelinedCG produces the same iterates as CG. CG has two
+// global synchronization points per iteration because each of its two inner
+// products depends on the result of the previous one. PipelinedCG uses
+// additional recurrences to compute all inner products of an iteration in a
+// single reduction and to start it before the preconditioner solve and the
+// matrix-vector product, which can then hide its latency. The price is the
+// storage of seven additional vectors, more vector updates and an additional
+// preconditioner solve and matrix-vector product at the end of the solve,
+// because the residual norm of the current iterate is known only after the
+// next reduction. The recurrences may also lead to a somewhat lower attainable
+// accuracy than CG.
+//
+// References:
+//   - Ghysels, P., and Vanroose, W. (2014). Hiding global synchronization
+//     latency in the preconditioned Conjugate Gradient algorithm. Parallel
+//     Computing, 40(7), 224-238. doi:10.1016/j.parco.2013.06.001
+type PipelinedCG struct {
+	// Reducer computes the reductions of the method. If Reducer is nil,
+	// the dot products are computed when they are requested.
+	Reducer Reducer
+
+	// Workers is the number of goroutines that perform the vector
+	// operations and the dot products if Reducer is nil. See the CG
+	// documentation for more information.
+	Workers int
+
+	x mat.VecDense
+	r mat.VecDense
+	u mat.VecDense // u = M^{-1} * r
+	w mat.VecDense // w = A * u
+	m mat.VecDense // m = M^{-1} * w
+	n mat.VecDense // n = A * m
+	p mat.VecDense
+	s mat.VecDense // s = A * p
+	q mat.VecDense // q = M^{-1} * s
+	z mat.VecDense // z = A * q
+
+	gamma, alpha float64
+
+	// dots holds the results of the reduction and dotX and dotY the
+	// reduced vectors.
+	dots       [3]float64
+	dotX, dotY [3]*mat.VecDense
+	wait       func()
+
+	kern kernels
+
+	iter   int
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (cg *PipelinedCG) Init(x, residual *mat.VecDense) {
+	dim := x.Len()
+	if residual.Len() != dim {
+		panic("pipecg: vector length mismatch")
+	}
+
+	cg.kern.init(cg.Workers)
+
+	cg.x.CloneFromVec(x)
+	cg.r.CloneFromVec(residual)
+	for _, v := range []*mat.VecDense{&cg.u, &cg.w, &cg.m, &cg.n, &cg.p, &cg.s, &cg.q, &cg.z} {
+		v.Reset()
+		v.ReuseAsVec(dim)
+	}
+
+	// The reduction computes γ = r·u, δ = w·u and r·r.
+	cg.dotX = [3]*mat.VecDense{&cg.r, &cg.w, &cg.r}
+	cg.dotY = [3]*mat.VecDense{&cg.u, &cg.u, &cg.r}
+
+	cg.iter = 0
+	cg.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// PipelinedCG will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+func (cg *PipelinedCG) Iterate(ctx *Context) (Operation, error) {
+	switch cg.resume {
+	case 1:
+		ctx.Src.CopyVec(&cg.r)
+		cg.resume = 2
+		// Compute u_0 = M^{-1} * r_0.
+		return PreconSolve, nil
+	case 2:
+		cg.u.CopyVec(ctx.Dst)
+		ctx.Src.CopyVec(&cg.u)
+		cg.resume = 3
+		// Compute w_0 = A * u_0.
+		return MulVec, nil
+	case 3:
+		cg.w.CopyVec(ctx.Dst)
+		return cg.startReduction(ctx), nil
+	case 4:
+		cg.m.CopyVec(ctx.Dst)
+		ctx.Src.CopyVec(&cg.m)
+		cg.resume = 5
+		// Compute n_i = A * m_i while the reduction is in progress.
+		return MulVec, nil
+	case 5:
+		cg.n.CopyVec(ctx.Dst)
+		cg.wait()
+		cg.wait = nil
+		if cg.iter == 0 {
+			// The initial residual norm has been checked by
+			// the caller.
+			if err := cg.update(); err != nil {
+				cg.resume = 0
+				return NoOperation, err
+			}
+			return cg.startReduction(ctx), nil
+		}
+		// The reduction provides the norm of the residual of the
+		// previous iterate.
+		ctx.ResidualNorm = math.Sqrt(cg.dots[2])
+		ctx.EnergyDecrease = cg.alpha * cg.gamma // |e_{i-1}|_A^2 - |e_i|_A^2 = α_{i-1} γ_{i-1}
+		cg.resume = 6
+		return CheckResidualNorm, nil
+	case 6:
+		ctx.X.CopyVec(&cg.x)
+		if ctx.Converged {
+			cg.resume = 0
+			return MajorIteration, nil
+		}
+		cg.resume = 7
+		return MajorIteration, nil
+	case 7:
+		if err := cg.update(); err != nil {
+			cg.resume = 0
+			return NoOperation, err
+		}
+		return cg.startReduction(ctx), nil
+
+	default:
+		panic("pipecg: Init not called")
+	}
+}
+
+// startReduction starts the reduction of the current iteration and commands
+// the preconditioner solve with w_i.
+func (cg *PipelinedCG) startReduction(ctx *Context) Operation {
+	if cg.Reducer != nil {
+		cg.wait = cg.Reducer.Reduce(cg.dots[:], cg.dotX[:], cg.dotY[:])
+	} else {
+		for i := range cg.dots {
+			cg.dots[i] = cg.kern.dot(cg.dotX[i], cg.dotY[i])
+		}
+		cg.wait = func() {}
+	}
+	ctx.Src.CopyVec(&cg.w)
+	cg.resume = 4
+	// Compute m_i = M^{-1} * w_i.
+	return PreconSolve
+}
+
+// update computes the step length and the search direction from the results
+// of the reduction and updates the vectors. It returns a *BreakdownError if
+// γ_i or the denominator of α_i is zero.
+func (cg *PipelinedCG) update() error {
+	gamma, delta := cg.dots[0], cg.dots[1]
+	if math.Abs(gamma) < breakdownTol {
+		return &BreakdownError{math.Abs(gamma), breakdownTol}
+	}
+	var beta float64
+	if cg.iter == 0 {
+		if math.Abs(delta) < breakdownTol {
+			return &BreakdownError{math.Abs(delta), breakdownTol}
+		}
+		cg.alpha = gamma / delta // α_0 = γ_0 / δ_0
+	} else {
+		beta = gamma / cg.gamma // β_i = γ_i / γ_{i-1}
+		denom := delta - beta*gamma/cg.alpha
+		if math.Abs(denom) < breakdownTol {
+			return &BreakdownError{math.Abs(denom), breakdownTol}
+		}
+		cg.alpha = gamma / denom // α_i = γ_i / (δ_i - β_i γ_i / α_{i-1})
+	}
+	cg.gamma = gamma
+
+	cg.kern.addScaled(&cg.z, &cg.n, beta, &cg.z) // z_i = n_i + β_i z_{i-1}
+	cg.kern.addScaled(&cg.q, &cg.m, beta, &cg.q) // q_i = m_i + β_i q_{i-1}
+	cg.kern.addScaled(&cg.s, &cg.w, beta, &cg.s) // s_i = w_i + β_i s_{i-1}
+	cg.kern.addScaled(&cg.p, &cg.u, beta, &cg.p) // p_i = u_i + β_i p_{i-1}
+
+	cg.kern.addScaled(&cg.x, &cg.x, cg.alpha, &cg.p)  // x_{i+1} = x_i + α_i p_i
+	cg.kern.addScaled(&cg.r, &cg.r, -cg.alpha, &cg.s) // r_{i+1} = r_i - α_i s_i
+	cg.kern.addScaled(&cg.u, &cg.u, -cg.alpha, &cg.q) // u_{i+1} = u_i - α_i q_i
+	cg.kern.addScaled(&cg.w, &cg.w, -cg.alpha, &cg.z) // w_{i+1} = w_i - α_i z_i
+
+	cg.iter++
+	return nil
+}
@@ -0,0 +1,478 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package precond
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/exp/linsolve"
+	"gonum.org/v1/exp/linsolve/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Smoother specifies the smoother used in a multigrid cycle.
+type Smoother int
+
+const (
+	// JacobiSmoother is the weighted Jacobi smoother.
+	JacobiSmoother Smoother = iota
+	// GaussSeidelSmoother is the Gauss-Seidel smoother. Forward sweeps are
+	// used for pre-smoothing and backward sweeps for post-smoothing, so the
+	// multigrid cycle is symmetric if the matrix is symmetric and the number
+	// of pre- and post-smoothing steps is equal.
+	GaussSeidelSmoother
+)
+
+// AMGSettings holds settings for constructing an algebraic multigrid hierarchy.
+type AMGSettings struct {
+	// Smoother is the smoother used on all levels except the coarsest.
+	Smoother Smoother
+
+	// PreSmooth and PostSmooth are the number of smoothing steps before and
+	// after the coarse-level correction. They must not be negative. If both
+	// are zero, one pre- and one post-smoothing step will be used.
+	PreSmooth, PostSmooth int
+
+	// Weight is the relaxation parameter ω of the weighted Jacobi smoother
+	// which performs the update
+	//  x += ω/ρ * D^{-1} * (b - A*x),
+	// where ρ is an upper bound on the spectral radius of D^{-1} * A. Weight
+	// must be in the interval [0,2). If Weight is zero, a default value of 4/3
+	// will be used.
+	Weight float64
+
+	// StrengthThreshold is the threshold θ for strong connections. The
+	// element a_ij is a strong connection if
+	//  |a_ij| >= θ * sqrt(|a_ii * a_jj|).
+	// StrengthThreshold must be in the interval [0,1). If it is zero, all
+	// off-diagonal non-zero elements are strong connections.
+	StrengthThreshold float64
+
+	// MaxLevels is the maximum number of levels in the hierarchy. If it is
+	// zero, a default value of 10 will be used, otherwise it must be
+	// positive.
+	MaxLevels int
+
+	// MaxCoarse is the dimension below which no further coarsening is done.
+	// The system on the coarsest level is solved with a dense LU
+	// factorization. If MaxCoarse is zero, a default value of 50 will be
+	// used, otherwise it must be positive.
+	MaxCoarse int
+}
+
+// AMG is an algebraic multigrid preconditioner based on smoothed aggregation.
+// Its PreconSolve method applies one V-cycle to the system with zero initial
+// guess.
+//
+// AMG also implements the linsolve.Method interface as the stationary
+// iteration
+//
+//	x_{k+1} = x_k + B * (b - A*x_k),
+//
+// where B is the V-cycle operator. The V-cycle is applied by AMG itself to the
+// residual of x_k which is needed for the convergence check anyway. As a
+// Method, AMG can be used only for solving systems with the matrix it has been
+// constructed from.
+//
+// Smoothed aggregation is most effective for symmetric positive definite
+// matrices arising from the discretization of elliptic partial differential
+// equations whose near null space is spanned by the constant vector, for
+// example Poisson-like problems. For such problems the number of iterations
+// of CG preconditioned with AMG is nearly independent of the mesh size.
+//
+// AMG is not safe for concurrent use.
+//
+// References:
+//   - Vaněk, P., Mandel, J., and Brezina, M. (1996). Algebraic multigrid by
+//     smoothed aggregation for second and fourth order elliptic problems.
+//     Computing, 56(3), 179-196. doi:10.1007/BF02238511
+//   - Trottenberg, U., Oosterlee, C., and Schüller, A. (2001). Multigrid.
+//     Academic Press.
+type AMG struct {
+	levels []amgLevel
+
+	coarse  mat.LU
+	coarseX mat.VecDense
+	coarseB mat.VecDense
+
+	smoother  Smoother
+	pre, post int
+	weight    float64
+	coarsest  int
+
+	// Data for use as linsolve.Method.
+	stationary
+}
+
+// amgLevel is a level of the multigrid hierarchy.
+type amgLevel struct {
+	a  *sparse.CSR
+	at *sparse.CSR // Transpose of a.
+
+	diag []float64
+	// rho is an upper bound on the spectral radius of D^{-1} * A.
+	rho float64
+
+	// p is the prolongation from the next coarser level and r = pᵀ is the
+	// restriction to it.
+	p, r *sparse.CSR
+
+	// Work vectors.
+	x, b, res []float64
+}
+
+// NewAMG returns the smoothed aggregation algebraic multigrid preconditioner
+// for the n×n matrix a. If settings is nil, default settings will be used.
+//
+// NewAMG returns an error if a diagonal element of the matrix on any level
+// except the coarsest is zero, or if the matrix on the coarsest level is
+// singular.
+func NewAMG(a mat.Matrix, settings *AMGSettings) (*AMG, error) {
+	var s AMGSettings
+	if settings != nil {
+		s = *settings
+	}
+	if s.PreSmooth < 0 || s.PostSmooth < 0 {
+		panic("precond: negative number of smoothing steps")
+	}
+	if s.PreSmooth == 0 && s.PostSmooth == 0 {
+		s.PreSmooth = 1
+		s.PostSmooth = 1
+	}
+	if s.Weight < 0 || 2 <= s.Weight {
+		panic("precond: invalid Jacobi weight")
+	}
+	if s.Weight == 0 {
+		s.Weight = 4.0 / 3
+	}
+	if s.StrengthThreshold < 0 || 1 <= s.StrengthThreshold {
+		panic("precond: invalid strength threshold")
+	}
+	if s.MaxLevels < 0 {
+		panic("precond: negative maximum number of levels")
+	}
+	if s.MaxLevels == 0 {
+		s.MaxLevels = 10
+	}
+	if s.MaxCoarse < 0 {
+		panic("precond: negative maximum coarse dimension")
+	}
+	if s.MaxCoarse == 0 {
+		s.MaxCoarse = 50
+	}
+	switch s.Smoother {
+	case JacobiSmoother, GaussSeidelSmoother:
+	default:
+		panic("precond: unknown smoother")
+	}
+
+	amg := &AMG{
+		smoother: s.Smoother,
+		pre:      s.PreSmooth,
+		post:     s.PostSmooth,
+		weight:   s.Weight,
+	}
+	m := newCSR(a)
+	for {
+		n := rows(m)
+		lvl := amgLevel{
+			a: m,
+			x: make([]float64, n),
+			b: make([]float64, n),
+		}
+		if n <= s.MaxCoarse || len(amg.levels)+1 == s.MaxLevels {
+			amg.levels = append(amg.levels, lvl)
+			break
+		}
+
+		lvl.diag = diagonal(m)
+		for i, d := range lvl.diag {
+			if d == 0 {
+				return nil, fmt.Errorf("precond: zero diagonal element in row %d on level %d", i, len(amg.levels))
+			}
+		}
+		lvl.rho = gershgorin(m, lvl.diag)
+
+		t := tentativeProlongator(aggregate(m, s.StrengthThreshold))
+		if _, nc := t.Dims(); nc == n {
+			// The aggregation did not reduce the dimension, so this is the
+			// coarsest level.
+			amg.levels = append(amg.levels, lvl)
+			break
+		}
+		lvl.at = transpose(m)
+		lvl.res = make([]float64, n)
+		lvl.p = smoothProlongator(m, lvl.diag, 4/(3*lvl.rho), t)
+		lvl.r = transpose(lvl.p)
+		amg.levels = append(amg.levels, lvl)
+
+		// A_c = Pᵀ * A * P
+		m = mul(lvl.r, mul(m, lvl.p))
+	}
+
+	// Factorize the matrix on the coarsest level.
+	amg.coarsest = len(amg.levels) - 1
+	last := &amg.levels[amg.coarsest]
+	n := rows(last.a)
+	dense := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		ind, val := row(last.a, i)
+		for k, j := range ind {
+			dense.Set(i, j, val[k])
+		}
+	}
+	amg.coarse.Factorize(dense)
+	if math.IsInf(amg.coarse.Cond(), 1) {
+		return nil, fmt.Errorf("precond: singular matrix on coarsest level %d", amg.coarsest)
+	}
+	amg.coarseX.SetRawVector(mat.NewVecDense(n, last.x).RawVector())
+	amg.coarseB.SetRawVector(mat.NewVecDense(n, last.b).RawVector())
+	amg.stationary = stationary{a: amg.levels[0].a}
+	return amg, nil
+}
+
+// PreconSolve applies one V-cycle with zero initial guess to the system
+// A * dst = rhs or Aᵀ * dst = rhs if trans is true. If dst is empty, it will
+// be resized to the length of rhs.
+func (amg *AMG) PreconSolve(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+	solveInPlace(dst, rhs, rows(amg.levels[0].a), func(x []float64) {
+		b := amg.levels[0].b
+		copy(b, x)
+		amg.cycle(0, x, b, trans)
+	})
+	return nil
+}
+
+// cycle computes an approximate solution x of the system A_l * x = b on the
+// l-th level using a V-cycle with zero initial guess. If trans is true, the
+// transposed cycle is performed on the system A_lᵀ * x = b.
+func (amg *AMG) cycle(l int, x, b []float64, trans bool) {
+	if l == amg.coarsest {
+		// The solution vector of the coarsest level is stored in levels[l].x
+		// which is not necessarily x.
+		_ = amg.coarse.SolveVecTo(&amg.coarseX, trans, &amg.coarseB)
+		copy(x, amg.levels[l].x)
+		return
+	}
+
+	lvl := &amg.levels[l]
+	a := lvl.a
+	pre, post := amg.pre, amg.post
+	if trans {
+		// The transpose of the V-cycle operator is the V-cycle with Aᵀ in
+		// which the roles of the pre- and post-smoothers are exchanged.
+		a = lvl.at
+		pre, post = post, pre
+	}
+
+	for i := range x {
+		x[i] = 0
+	}
+	for k := 0; k < pre; k++ {
+		amg.smooth(lvl, a, x, b, true)
+	}
+
+	// Restrict the residual to the coarse level.
+	mulVec(a, lvl.res, x)
+	for i, v := range b {
+		lvl.res[i] = v - lvl.res[i]
+	}
+	next := &amg.levels[l+1]
+	mulVec(lvl.r, next.b, lvl.res)
+	amg.cycle(l+1, next.x, next.b, trans)
+
+	// Prolongate the coarse-level correction.
+	mulVec(lvl.p, lvl.res, next.x)
+	for i, v := range lvl.res {
+		x[i] += v
+	}
+
+	for k := 0; k < post; k++ {
+		amg.smooth(lvl, a, x, b, false)
+	}
+}
+
+// smooth performs one smoothing step on the system a * x = b. For the
+// Gauss-Seidel smoother forward specifies the direction of the sweep.
+func (amg *AMG) smooth(lvl *amgLevel, a *sparse.CSR, x, b []float64, forward bool) {
+	switch amg.smoother {
+	case JacobiSmoother:
+		mulVec(a, lvl.res, x)
+		w := amg.weight / lvl.rho
+		for i, d := range lvl.diag {
+			x[i] += w * (b[i] - lvl.res[i]) / d
+		}
+	case GaussSeidelSmoother:
+		sorSweep(a, lvl.diag, 1, x, b, forward)
+	}
+}
+
+// Init initializes the data for a linear solve. See the linsolve.Method
+// interface for more details.
+func (amg *AMG) Init(x, residual *mat.VecDense) {
+	amg.init(x, residual)
+}
+
+// Iterate performs an iteration of the linear solve. See the linsolve.Method
+// interface for more details.
+//
+// AMG will command the following operations:
+//
+//	MulVec
+//	ComputeResidual
+//	CheckResidualNorm
+//	MajorIteration
+func (amg *AMG) Iterate(ctx *linsolve.Context) (linsolve.Operation, error) {
+	return amg.iterate(ctx, func(x, _, r []float64) {
+		lvl := &amg.levels[0]
+		copy(lvl.b, r)
+		amg.cycle(0, lvl.x, lvl.b, false)
+		for i, v := range lvl.x {
+			x[i] += v
+		}
+	})
+}
+
+// gershgorin returns the Gershgorin bound on the spectral radius of
+// D^{-1} * A.
+func gershgorin(a *sparse.CSR, diag []float64) float64 {
+	var rho float64
+	for i := range diag {
+		_, val := row(a, i)
+		var sum float64
+		for _, v := range val {
+			sum += math.Abs(v)
+		}
+		rho = math.Max(rho, sum/math.Abs(diag[i]))
+	}
+	return rho
+}
+
+// aggregate partitions the unknowns of a into aggregates of strongly connected
+// unknowns and returns the aggregate index of each unknown and the number of
+// aggregates.
+func aggregate(a *sparse.CSR, theta float64) (agg []int, nagg int) {
+	n := rows(a)
+	diag := diagonal(a)
+
+	// Build the symmetrized graph of strong connections.
+	strong := make([][]int, n)
+	for i := 0; i < n; i++ {
+		ind, val := row(a, i)
+		for k, j := range ind {
+			if j != i && math.Abs(val[k]) >= theta*math.Sqrt(math.Abs(diag[i]*diag[j])) {
+				strong[i] = append(strong[i], j)
+				strong[j] = append(strong[j], i)
+			}
+		}
+	}
+
+	agg = make([]int, n)
+	for i := range agg {
+		agg[i] = -1
+	}
+	// Pass 1: Form aggregates from unknowns whose strong neighbors are all
+	// unaggregated.
+	for i := 0; i < n; i++ {
+		if agg[i] >= 0 {
+			continue
+		}
+		free := true
+		for _, j := range strong[i] {
+			if agg[j] >= 0 {
+				free = false
+				break
+			}
+		}
+		if !free {
+			continue
+		}
+		agg[i] = nagg
+		for _, j := range strong[i] {
+			agg[j] = nagg
+		}
+		nagg++
+	}
+	// Pass 2: Add the remaining unknowns to an aggregate of a strong neighbor.
+	pass1 := append([]int(nil), agg...)
+	for i := 0; i < n; i++ {
+		if agg[i] >= 0 {
+			continue
+		}
+		for _, j := range strong[i] {
+			if pass1[j] >= 0 {
+				agg[i] = pass1[j]
+				break
+			}
+		}
+	}
+	// Pass 3: Form new aggregates from the unknowns that are still
+	// unaggregated.
+	for i := 0; i < n; i++ {
+		if agg[i] >= 0 {
+			continue
+		}
+		agg[i] = nagg
+		for _, j := range strong[i] {
+			if agg[j] < 0 {
+				agg[j] = nagg
+			}
+		}
+		nagg++
+	}
+	return agg, nagg
+}
+
+// tentativeProlongator returns the tentative prolongator that interpolates
+// the constant vector on each aggregate. Its columns have unit norm.
+func tentativeProlongator(agg []int, nagg int) *sparse.CSR {
+	size := make([]int, nagg)
+	for _, k := range agg {
+		size[k]++
+	}
+	t := newRowBuilder(len(agg), nagg)
+	for _, k := range agg {
+		t.appendRow([]int{k}, []float64{1 / math.Sqrt(float64(size[k]))})
+	}
+	return t.csr()
+}
+
+// smoothProlongator returns the prolongator
+//
+//	P = (I - ω * D^{-1} * A) * T.
+func smoothProlongator(a *sparse.CSR, diag []float64, omega float64, t *sparse.CSR) *sparse.CSR {
+	at := mul(a, t)
+	p := newRowBuilder(t.Dims())
+	var (
+		cols []int
+		vals []float64
+	)
+	for i := range diag {
+		ind, val := row(at, i)
+		cols = append(cols[:0], ind...)
+		vals = vals[:0]
+		for _, v := range val {
+			vals = append(vals, -omega*v/diag[i])
+		}
+		tind, tval := row(t, i)
+		for k, j := range tind {
+			q := sort.SearchInts(cols, j)
+			if q < len(cols) && cols[q] == j {
+				vals[q] += tval[k]
+				continue
+			}
+			cols = append(cols, 0)
+			copy(cols[q+1:], cols[q:])
+			cols[q] = j
+			vals = append(vals, 0)
+			copy(vals[q+1:], vals[q:])
+			vals[q] = tval[k]
+		}
+		p.appendRow(cols, vals)
+	}
+	return p.csr()
+}
@@ -0,0 +1,266 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package precond
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// ILU is an incomplete LU factorization
+//
+//	A ≈ M = L * U
+//
+// of a square matrix A, where L is unit lower triangular and U is upper
+// triangular. ILU is used as a preconditioner for nonsymmetric systems.
+type ILU struct {
+	l triangular
+	u triangular
+}
+
+// NewILU0 returns the incomplete LU factorization of the n×n matrix a with
+// zero fill-in, ILU(0). The factors L and U have the same sparsity pattern as
+// the strictly lower and the upper triangular part of a, respectively.
+//
+// NewILU0 returns an error if a zero pivot is encountered, for example if a
+// diagonal element of a is zero.
+//
+// References:
+//   - Saad, Y. (2003). Section 10.3.2 Zero Fill-in ILU (ILU(0)). In Iterative
+//     Methods for Sparse Linear Systems (2nd ed.) (pp. 307-312). Philadelphia,
+//     PA: SIAM.
+func NewILU0(a mat.Matrix) (*ILU, error) {
+	m := newCSR(a)
+	n := rows(m)
+	indptr, ind, data := m.RawCSR()
+
+	// diag[i] is the position of the diagonal element in the i-th row of m.
+	diag := make([]int, n)
+	// iw maps column indices of the current row to positions in data.
+	iw := make([]int, n)
+	for j := range iw {
+		iw[j] = -1
+	}
+	for i := 0; i < n; i++ {
+		start, end := indptr[i], indptr[i+1]
+		for p := start; p < end; p++ {
+			iw[ind[p]] = p
+		}
+		for p := start; p < end; p++ {
+			k := ind[p]
+			if k >= i {
+				break
+			}
+			// l_ik = a_ik / u_kk
+			lik := data[p] / data[diag[k]]
+			data[p] = lik
+			// a_ij -= l_ik * u_kj for j > k in the pattern of the i-th row.
+			for q := diag[k] + 1; q < indptr[k+1]; q++ {
+				if pos := iw[ind[q]]; pos >= 0 {
+					data[pos] -= lik * data[q]
+				}
+			}
+		}
+		diag[i] = iw[i]
+		for p := start; p < end; p++ {
+			iw[ind[p]] = -1
+		}
+		if diag[i] < 0 || data[diag[i]] == 0 {
+			return nil, fmt.Errorf("precond: zero pivot in row %d", i)
+		}
+	}
+
+	l, u, d := split(m)
+	return &ILU{
+		l: newTriangular(l, true, nil),
+		u: newTriangular(u, false, d),
+	}, nil
+}
+
+// NewILUT returns the incomplete LU factorization of the n×n matrix a with
+// threshold dropping, ILUT(fill,dropTol). While computing the i-th row of the
+// factors, all elements whose magnitude is less than dropTol times the norm of
+// the i-th row of a are dropped. Of the remaining off-diagonal elements, only
+// the fill largest in magnitude are kept in the i-th row of L and in the i-th
+// row of U. With fill equal to n and dropTol equal to zero, ILUT computes the
+// complete LU factorization without pivoting.
+//
+// If a zero pivot is encountered, it is replaced by (1e-4+dropTol) times the
+// norm of the row. NewILUT returns an error if a has a zero row.
+//
+// NewILUT panics if fill or dropTol is negative.
+//
+// References:
+//   - Saad, Y. (1994). ILUT: A dual threshold incomplete LU factorization.
+//     Numer. Linear Algebra Appl., 1(4), 387-402. doi:10.1002/nla.1680010405
+//   - Saad, Y. (2003). Section 10.4.3 Threshold Strategies and ILUT. In
+//     Iterative Methods for Sparse Linear Systems (2nd ed.) (pp. 321-327).
+//     Philadelphia, PA: SIAM.
+func NewILUT(a mat.Matrix, fill int, dropTol float64) (*ILU, error) {
+	if fill < 0 {
+		panic("precond: negative fill")
+	}
+	if dropTol < 0 {
+		panic("precond: negative drop tolerance")
+	}
+	m := newCSR(a)
+	n := rows(m)
+
+	l := newRowBuilder(n, n)
+	u := newRowBuilder(n, n)
+	udiag := make([]float64, n)
+
+	// w is the dense working row and nz marks its structurally non-zero
+	// elements.
+	w := make([]float64, n)
+	nz := make([]bool, n)
+	var (
+		lower  indexHeap // Column indices j < i that remain to be eliminated.
+		lind   []int     // Column indices of the kept elements of L.
+		uind   []int     // Column indices j > i of the elements of U.
+		lvals  []float64
+		uvals  []float64
+		pruned []int
+		// touched holds the column indices of all structurally non-zero
+		// elements of w.
+		touched []int
+	)
+	for i := 0; i < n; i++ {
+		ind, val := row(m, i)
+		var norm float64
+		for k, j := range ind {
+			norm = math.Hypot(norm, val[k])
+			w[j] = val[k]
+			nz[j] = true
+			touched = append(touched, j)
+			switch {
+			case j < i:
+				heap.Push(&lower, j)
+			case j > i:
+				uind = append(uind, j)
+			}
+		}
+		if norm == 0 {
+			return nil, fmt.Errorf("precond: zero row %d", i)
+		}
+		tol := dropTol * norm
+
+		// Eliminate the elements in the lower triangular part in increasing
+		// order of their column index.
+		lind = lind[:0]
+		for lower.Len() > 0 {
+			k := heap.Pop(&lower).(int)
+			wk := w[k] / udiag[k]
+			if math.Abs(wk) < tol {
+				w[k] = 0
+				continue
+			}
+			w[k] = wk
+			lind = append(lind, k)
+			uk, uv := u.row(k)
+			for q, j := range uk {
+				if !nz[j] {
+					nz[j] = true
+					w[j] = 0
+					touched = append(touched, j)
+					switch {
+					case j < i:
+						heap.Push(&lower, j)
+					case j > i:
+						uind = append(uind, j)
+					}
+				}
+				w[j] -= wk * uv[q]
+			}
+		}
+
+		// Drop small elements of U and keep the largest elements of L and U.
+		pruned = pruned[:0]
+		for _, j := range uind {
+			if math.Abs(w[j]) >= tol && w[j] != 0 {
+				pruned = append(pruned, j)
+			}
+		}
+		kept := keepLargest(pruned, w, fill)
+		uvals = uvals[:0]
+		for _, j := range kept {
+			uvals = append(uvals, w[j])
+		}
+		u.appendRow(kept, uvals)
+
+		kept = keepLargest(lind, w, fill)
+		lvals = lvals[:0]
+		for _, j := range kept {
+			lvals = append(lvals, w[j])
+		}
+		l.appendRow(kept, lvals)
+
+		pivot := w[i]
+		if pivot == 0 {
+			pivot = (1e-4 + dropTol) * norm
+		}
+		udiag[i] = pivot
+
+		// Reset the working row.
+		for _, j := range touched {
+			w[j] = 0
+			nz[j] = false
+		}
+		touched = touched[:0]
+		uind = uind[:0]
+	}
+	return &ILU{
+		l: newTriangular(l.csr(), true, nil),
+		u: newTriangular(u.csr(), false, udiag),
+	}, nil
+}
+
+// PreconSolve solves M * dst = rhs or Mᵀ * dst = rhs if trans is true, where
+// M is the incomplete LU factorization L * U. If dst is empty, it will be
+// resized to the length of rhs.
+func (f *ILU) PreconSolve(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+	solveInPlace(dst, rhs, f.l.n, func(x []float64) {
+		if trans {
+			f.u.solve(true, x)
+			f.l.solve(true, x)
+			return
+		}
+		f.l.solve(false, x)
+		f.u.solve(false, x)
+	})
+	return nil
+}
+
+// keepLargest returns the column indices in ind of at most max elements of w
+// with the largest magnitude, sorted in increasing order. The elements of ind
+// may be reordered.
+func keepLargest(ind []int, w []float64, max int) []int {
+	if len(ind) > max {
+		sort.Slice(ind, func(k, l int) bool {
+			return math.Abs(w[ind[k]]) > math.Abs(w[ind[l]])
+		})
+		ind = ind[:max]
+	}
+	sort.Ints(ind)
+	return ind
+}
+
+// indexHeap is a min-heap of column indices.
+type indexHeap []int
+
+func (h indexHeap) Len() int           { return len(h) }
+func (h indexHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h indexHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *indexHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *indexHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,185 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package precond
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/exp/linsolve"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Jacobi is the point Jacobi preconditioner
+//
+//	M = D,
+//
+// where D is the diagonal of a square matrix A.
+//
+// Jacobi also implements the linsolve.Method interface as the Jacobi iteration
+// with the sweep
+//
+//	x_{k+1,i} = x_{k,i} + (b_i - Σ_j a_ij * x_{k,j}) / a_ii
+//
+// over the rows of A. Because the sweep uses only the previous iterate, it is
+// computed from the residual of x_k that is needed for the convergence check
+// anyway. As a Method, Jacobi can be used only for solving systems with the
+// matrix it has been constructed from. The iteration converges for example if
+// A is strictly diagonally dominant.
+type Jacobi struct {
+	diag []float64
+
+	// Data for use as linsolve.Method.
+	stationary
+}
+
+// NewJacobi returns the Jacobi preconditioner for the n×n matrix a. It returns
+// an error if a diagonal element of a is zero.
+func NewJacobi(a mat.Matrix) (*Jacobi, error) {
+	m := newCSR(a)
+	d := diagonal(m)
+	for i, v := range d {
+		if v == 0 {
+			return nil, fmt.Errorf("precond: zero diagonal element in row %d", i)
+		}
+	}
+	return &Jacobi{diag: d, stationary: stationary{a: m}}, nil
+}
+
+// PreconSolve solves M * dst = rhs. Since M is diagonal, the value of trans is
+// ignored. If dst is empty, it will be resized to the length of rhs.
+func (p *Jacobi) PreconSolve(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+	solveInPlace(dst, rhs, len(p.diag), func(x []float64) {
+		for i, d := range p.diag {
+			x[i] /= d
+		}
+	})
+	return nil
+}
+
+// Init initializes the data for a linear solve. See the linsolve.Method
+// interface for more details.
+func (p *Jacobi) Init(x, residual *mat.VecDense) {
+	p.init(x, residual)
+}
+
+// Iterate performs an iteration of the linear solve. See the linsolve.Method
+// interface for more details.
+//
+// Jacobi will command the following operations:
+//
+//	MulVec
+//	ComputeResidual
+//	CheckResidualNorm
+//	MajorIteration
+func (p *Jacobi) Iterate(ctx *linsolve.Context) (linsolve.Operation, error) {
+	return p.iterate(ctx, func(x, b, r []float64) {
+		for i, d := range p.diag {
+			x[i] += r[i] / d
+		}
+	})
+}
+
+// BlockJacobi is the block Jacobi preconditioner
+//
+//	M = diag(A_11, A_22, ..., A_kk),
+//
+// where A_ii are the diagonal blocks of a square matrix A with respect to a
+// partition of its row and column indices.
+type BlockJacobi struct {
+	n      int
+	blocks [][]int
+	lu     []mat.LU
+
+	work []mat.VecDense
+}
+
+// NewBlockJacobi returns the block Jacobi preconditioner for the n×n matrix a.
+// The diagonal blocks are given by blocks, the elements of which are the row
+// and column indices of a that belong to each block. Every index from 0 to n-1
+// must appear in exactly one block, otherwise NewBlockJacobi will panic. The
+// indices within a block do not need to be contiguous or sorted.
+//
+// NewBlockJacobi computes the LU factorization of every diagonal block and
+// returns an error if any of the blocks is singular.
+func NewBlockJacobi(a mat.Matrix, blocks [][]int) (*BlockJacobi, error) {
+	m := newCSR(a)
+	n := rows(m)
+
+	// block[i] is the index of the block that contains the index i and pos[i]
+	// is the position of i within the block.
+	block := make([]int, n)
+	pos := make([]int, n)
+	for i := range block {
+		block[i] = -1
+	}
+	for b, ind := range blocks {
+		if len(ind) == 0 {
+			panic("precond: empty block")
+		}
+		for k, i := range ind {
+			if i < 0 || n <= i {
+				panic("precond: block index out of range")
+			}
+			if block[i] >= 0 {
+				panic("precond: index in more than one block")
+			}
+			block[i] = b
+			pos[i] = k
+		}
+	}
+	for _, b := range block {
+		if b < 0 {
+			panic("precond: index not in any block")
+		}
+	}
+
+	p := BlockJacobi{
+		n:      n,
+		blocks: make([][]int, len(blocks)),
+		lu:     make([]mat.LU, len(blocks)),
+		work:   make([]mat.VecDense, len(blocks)),
+	}
+	for b, ind := range blocks {
+		p.blocks[b] = append([]int(nil), ind...)
+		bs := len(ind)
+		ab := mat.NewDense(bs, bs, nil)
+		for k, i := range ind {
+			rind, rval := row(m, i)
+			for q, j := range rind {
+				if block[j] == b {
+					ab.Set(k, pos[j], rval[q])
+				}
+			}
+		}
+		p.lu[b].Factorize(ab)
+		if math.IsInf(p.lu[b].Cond(), 1) {
+			return nil, fmt.Errorf("precond: singular diagonal block %d", b)
+		}
+		p.work[b].ReuseAsVec(bs)
+	}
+	return &p, nil
+}
+
+// PreconSolve solves M * dst = rhs or Mᵀ * dst = rhs if trans is true. If dst
+// is empty, it will be resized to the length of rhs.
+func (p *BlockJacobi) PreconSolve(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+	solveInPlace(dst, rhs, p.n, func(x []float64) {
+		for b, ind := range p.blocks {
+			w := &p.work[b]
+			for k, i := range ind {
+				w.SetVec(k, x[i])
+			}
+			// The blocks are not singular, so the only possible error is
+			// a mat.Condition which does not prevent the block from being
+			// used in a preconditioner.
+			_ = p.lu[b].SolveVecTo(w, trans, w)
+			for k, i := range ind {
+				x[i] = w.AtVec(k)
+			}
+		}
+	})
+	return nil
+}
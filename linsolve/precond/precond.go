@@ -0,0 +1,308 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package precond provides preconditioners for the iterative methods in the
+// linsolve package.
+//
+// The preconditioners are constructed from a square matrix A given as a
+// mat.Matrix and store it as a sparse.CSR matrix. If the matrix implements
+// mat.RowNonZeroDoer or mat.NonZeroDoer, its non-zero elements are retrieved
+// via these interfaces, otherwise every element is queried with At. Each
+// preconditioner type has a PreconSolve method with the signature of
+// linsolve.Settings.PreconSolve, so it can be used directly as
+//
+//	settings.PreconSolve = p.PreconSolve
+//
+// Jacobi, SOR, SSOR and AMG also implement the linsolve.Method interface as
+// stationary iterative methods, so they can be passed to linsolve.Iterative
+// for solving systems with the matrix they have been constructed from, for
+// example as baselines or for smoothing. Jacobi, SOR and SSOR update the
+// iterate with sweeps over the stored rows of A and AMG with a V-cycle. These
+// are applied by the methods themselves, so Settings.PreconSolve is not used.
+// The residual for the convergence check is computed by linsolve.Iterative.
+// Before the first iteration, the methods compare a product of the matrix of
+// the linear solve with a test vector to the stored matrix and return
+// ErrMatrixMismatch if they differ.
+package precond
+
+import (
+	"slices"
+	"sort"
+
+	"gonum.org/v1/exp/linsolve/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+// newCSR returns a copy of the non-zero elements of the square matrix a in
+// compressed sparse row format. Duplicate elements are summed.
+func newCSR(a mat.Matrix) *sparse.CSR {
+	r, c := a.Dims()
+	if r != c {
+		panic("precond: matrix not square")
+	}
+	if m, ok := a.(*sparse.CSR); ok {
+		indptr, ind, data := m.RawCSR()
+		return sparse.NewCSR(r, c, slices.Clone(indptr), slices.Clone(ind), slices.Clone(data))
+	}
+	coo := sparse.NewCOO(r, c)
+	switch a := a.(type) {
+	case mat.RowNonZeroDoer:
+		for i := 0; i < r; i++ {
+			a.DoRowNonZero(i, coo.Append)
+		}
+	case mat.NonZeroDoer:
+		a.DoNonZero(coo.Append)
+	default:
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				coo.Append(i, j, a.At(i, j))
+			}
+		}
+	}
+	return coo.ToCSR()
+}
+
+// rowBuilder builds an r×c sparse matrix in compressed sparse row format row
+// by row.
+type rowBuilder struct {
+	r, c   int
+	indptr []int
+	ind    []int
+	data   []float64
+}
+
+// newRowBuilder returns a rowBuilder for an r×c matrix without any rows.
+func newRowBuilder(r, c int) *rowBuilder {
+	return &rowBuilder{
+		r:      r,
+		c:      c,
+		indptr: make([]int, 1, r+1),
+	}
+}
+
+// appendRow appends a row with the given column indices and values. The
+// column indices must be sorted in increasing order.
+func (b *rowBuilder) appendRow(ind []int, val []float64) {
+	b.ind = append(b.ind, ind...)
+	b.data = append(b.data, val...)
+	b.indptr = append(b.indptr, len(b.ind))
+}
+
+// row returns the column indices and values of the i-th row that has already
+// been appended.
+func (b *rowBuilder) row(i int) ([]int, []float64) {
+	return b.ind[b.indptr[i]:b.indptr[i+1]], b.data[b.indptr[i]:b.indptr[i+1]]
+}
+
+// csr returns the matrix after all rows have been appended.
+func (b *rowBuilder) csr() *sparse.CSR {
+	return sparse.NewCSR(b.r, b.c, b.indptr, b.ind, b.data)
+}
+
+// row returns the column indices and values of the i-th row of m.
+func row(m *sparse.CSR, i int) ([]int, []float64) {
+	indptr, ind, data := m.RawCSR()
+	return ind[indptr[i]:indptr[i+1]], data[indptr[i]:indptr[i+1]]
+}
+
+// rows returns the number of rows of m.
+func rows(m *sparse.CSR) int {
+	r, _ := m.Dims()
+	return r
+}
+
+// diagonal returns the diagonal elements of m.
+func diagonal(m *sparse.CSR) []float64 {
+	d := make([]float64, rows(m))
+	for i := range d {
+		ind, val := row(m, i)
+		k := sort.SearchInts(ind, i)
+		if k < len(ind) && ind[k] == i {
+			d[i] = val[k]
+		}
+	}
+	return d
+}
+
+// mulVec computes dst = m * x.
+func mulVec(m *sparse.CSR, dst, x []float64) {
+	for i := range dst {
+		ind, val := row(m, i)
+		var v float64
+		for k, j := range ind {
+			v += val[k] * x[j]
+		}
+		dst[i] = v
+	}
+}
+
+// transpose returns the transpose of m.
+func transpose(m *sparse.CSR) *sparse.CSR {
+	r, c := m.Dims()
+	indptr, ind, data := m.ToCSC().RawCSC()
+	return sparse.NewCSR(c, r, indptr, ind, data)
+}
+
+// mul returns the product m * b.
+func mul(m, b *sparse.CSR) *sparse.CSR {
+	r, c := m.Dims()
+	br, bc := b.Dims()
+	if c != br {
+		panic("precond: dimension mismatch")
+	}
+	p := newRowBuilder(r, bc)
+	// w is the dense working row and mark holds for each column the last row
+	// in which it was structurally non-zero.
+	w := make([]float64, bc)
+	mark := make([]int, bc)
+	for j := range mark {
+		mark[j] = -1
+	}
+	var (
+		cols []int
+		vals []float64
+	)
+	for i := 0; i < r; i++ {
+		cols = cols[:0]
+		ind, val := row(m, i)
+		for k, l := range ind {
+			bind, bval := row(b, l)
+			for q, j := range bind {
+				if mark[j] != i {
+					mark[j] = i
+					w[j] = 0
+					cols = append(cols, j)
+				}
+				w[j] += val[k] * bval[q]
+			}
+		}
+		sort.Ints(cols)
+		vals = vals[:0]
+		for _, j := range cols {
+			vals = append(vals, w[j])
+		}
+		p.appendRow(cols, vals)
+	}
+	return p.csr()
+}
+
+// split returns the strictly lower and the strictly upper triangular parts of m
+// and its diagonal.
+func split(m *sparse.CSR) (lower, upper *sparse.CSR, diag []float64) {
+	n := rows(m)
+	l := newRowBuilder(n, n)
+	u := newRowBuilder(n, n)
+	diag = make([]float64, n)
+	for i := 0; i < n; i++ {
+		ind, val := row(m, i)
+		d := sort.SearchInts(ind, i)
+		l.appendRow(ind[:d], val[:d])
+		if d < len(ind) && ind[d] == i {
+			diag[i] = val[d]
+			d++
+		}
+		u.appendRow(ind[d:], val[d:])
+	}
+	return l.csr(), u.csr(), diag
+}
+
+// triangular is a sparse triangular matrix in compressed sparse row format. The
+// diagonal is stored separately and the rows hold only the strictly
+// triangular part.
+type triangular struct {
+	m     *sparse.CSR
+	n     int
+	lower bool
+	// diag is the diagonal of the matrix. If diag is nil, the matrix has unit
+	// diagonal.
+	diag []float64
+}
+
+// newTriangular returns the triangular matrix with the strictly triangular
+// part m and the diagonal diag.
+func newTriangular(m *sparse.CSR, lower bool, diag []float64) triangular {
+	return triangular{m: m, n: rows(m), lower: lower, diag: diag}
+}
+
+// solve solves the system T * x = x or Tᵀ * x = x in place.
+func (t *triangular) solve(trans bool, x []float64) {
+	n := t.n
+	if t.lower != trans {
+		// Forward substitution with a lower triangular matrix, or with the
+		// transpose of an upper triangular matrix.
+		for i := 0; i < n; i++ {
+			ind, val := row(t.m, i)
+			if !trans {
+				xi := x[i]
+				for k, j := range ind {
+					xi -= val[k] * x[j]
+				}
+				if t.diag != nil {
+					xi /= t.diag[i]
+				}
+				x[i] = xi
+				continue
+			}
+			// The i-th row of T is the i-th column of Tᵀ.
+			if t.diag != nil {
+				x[i] /= t.diag[i]
+			}
+			xi := x[i]
+			for k, j := range ind {
+				x[j] -= val[k] * xi
+			}
+		}
+		return
+	}
+	// Backward substitution with an upper triangular matrix, or with the
+	// transpose of a lower triangular matrix.
+	for i := n - 1; i >= 0; i-- {
+		ind, val := row(t.m, i)
+		if !trans {
+			xi := x[i]
+			for k, j := range ind {
+				xi -= val[k] * x[j]
+			}
+			if t.diag != nil {
+				xi /= t.diag[i]
+			}
+			x[i] = xi
+			continue
+		}
+		if t.diag != nil {
+			x[i] /= t.diag[i]
+		}
+		xi := x[i]
+		for k, j := range ind {
+			x[j] -= val[k] * xi
+		}
+	}
+}
+
+// solveInPlace copies rhs into dst, allocating dst if it is empty, and calls
+// solve with the elements of dst.
+func solveInPlace(dst *mat.VecDense, rhs mat.Vector, n int, solve func(x []float64)) {
+	if rhs.Len() != n {
+		panic("precond: mismatched vector length")
+	}
+	if dst.IsEmpty() {
+		dst.ReuseAsVec(n)
+	} else if dst.Len() != n {
+		panic("precond: mismatched vector length")
+	}
+	dst.CopyVec(rhs)
+	raw := dst.RawVector()
+	if raw.Inc == 1 {
+		solve(raw.Data[:n])
+		return
+	}
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = dst.AtVec(i)
+	}
+	solve(x)
+	for i, v := range x {
+		dst.SetVec(i, v)
+	}
+}
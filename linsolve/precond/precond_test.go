@@ -0,0 +1,1016 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package precond
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/exp/linsolve"
+	"gonum.org/v1/exp/linsolve/sparse"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/mat"
+)
+
+type preconSolver interface {
+	PreconSolve(dst *mat.VecDense, trans bool, rhs mat.Vector) error
+}
+
+// newTridiag returns a random diagonally dominant n×n tridiagonal matrix. If
+// sym is true, the matrix is symmetric.
+func newTridiag(n int, sym bool, rnd *rand.Rand) *sparse.COO {
+	a := sparse.NewCOO(n, n)
+	for i := 0; i < n; i++ {
+		a.Append(i, i, 4+rnd.Float64())
+		if i > 0 {
+			v := -1 - rnd.Float64()
+			a.Append(i, i-1, v)
+			if sym {
+				a.Append(i-1, i, v)
+			} else {
+				a.Append(i-1, i, rnd.NormFloat64())
+			}
+		}
+	}
+	return a
+}
+
+// newRandomSparse returns a random diagonally dominant n×n matrix with about
+// nnz non-zero off-diagonal elements in each row.
+func newRandomSparse(n, nnz int, rnd *rand.Rand) *sparse.COO {
+	a := sparse.NewCOO(n, n)
+	for i := 0; i < n; i++ {
+		var sum float64
+		for k := 0; k < nnz; k++ {
+			j := rnd.Intn(n)
+			if j == i {
+				continue
+			}
+			v := rnd.NormFloat64()
+			// Duplicate elements are summed.
+			a.Append(i, j, v)
+			sum += 2 * math.Abs(v)
+		}
+		a.Append(i, i, 1+sum)
+	}
+	return a
+}
+
+// newPoisson2D returns the matrix of the 5-point finite difference
+// discretization of the negative Laplacian on an nx×ny grid.
+func newPoisson2D(nx, ny int) *sparse.COO {
+	n := nx * ny
+	a := sparse.NewCOO(n, n)
+	for i := 0; i < nx; i++ {
+		for j := 0; j < ny; j++ {
+			row := i*ny + j
+			a.Append(row, row, 4)
+			if i > 0 {
+				a.Append(row, row-ny, -1)
+			}
+			if i < nx-1 {
+				a.Append(row, row+ny, -1)
+			}
+			if j > 0 {
+				a.Append(row, row-1, -1)
+			}
+			if j < ny-1 {
+				a.Append(row, row+1, -1)
+			}
+		}
+	}
+	return a
+}
+
+// newConvectionDiffusion2D returns the matrix of the upwind finite difference
+// discretization of the convection-diffusion operator -Δu + c*(u_x + u_y) on an
+// nx×ny grid.
+func newConvectionDiffusion2D(nx, ny int, c float64) *sparse.COO {
+	n := nx * ny
+	h := 1 / float64(nx+1)
+	a := sparse.NewCOO(n, n)
+	for i := 0; i < nx; i++ {
+		for j := 0; j < ny; j++ {
+			row := i*ny + j
+			a.Append(row, row, 4+2*c*h)
+			if i > 0 {
+				a.Append(row, row-ny, -1-c*h)
+			}
+			if i < nx-1 {
+				a.Append(row, row+ny, -1)
+			}
+			if j > 0 {
+				a.Append(row, row-1, -1-c*h)
+			}
+			if j < ny-1 {
+				a.Append(row, row+1, -1)
+			}
+		}
+	}
+	return a
+}
+
+// newScaled returns the matrix D*A*D, where D is a random diagonal matrix with
+// elements between 1 and 100.
+func newScaled(a *sparse.COO, rnd *rand.Rand) *sparse.COO {
+	n, _ := a.Dims()
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = 1 + 99*rnd.Float64()
+	}
+	scaled := sparse.NewCOO(n, n)
+	a.DoNonZero(func(i, j int, v float64) {
+		scaled.Append(i, j, d[i]*v*d[j])
+	})
+	return scaled
+}
+
+// testExact checks that p solves the systems with a and aᵀ exactly.
+func testExact(t *testing.T, name string, a *sparse.COO, p preconSolver, transposed bool, rnd *rand.Rand) {
+	t.Helper()
+
+	n, _ := a.Dims()
+	want := make([]float64, n)
+	for i := range want {
+		want[i] = rnd.NormFloat64()
+	}
+	wantVec := mat.NewVecDense(n, want)
+	for _, trans := range []bool{false, true} {
+		if trans && !transposed {
+			continue
+		}
+		b := mat.NewVecDense(n, nil)
+		a.MulVecTo(b, trans, wantVec)
+		var dst mat.VecDense
+		err := p.PreconSolve(&dst, trans, b)
+		if err != nil {
+			t.Errorf("%v: trans=%v: unexpected error %v", name, trans, err)
+			continue
+		}
+		if !floats.EqualApprox(dst.RawVector().Data, want, 1e-12) {
+			t.Errorf("%v: trans=%v: unexpected solution\ngot  %v\nwant %v", name, trans, dst.RawVector().Data, want)
+		}
+	}
+}
+
+func TestILU0Tridiagonal(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 50} {
+		// ILU(0) of a tridiagonal matrix does not produce any fill-in, so it is
+		// equal to the complete LU factorization.
+		a := newTridiag(n, false, rnd)
+		p, err := NewILU0(a)
+		if err != nil {
+			t.Fatalf("n=%v: unexpected error %v", n, err)
+		}
+		testExact(t, fmt.Sprintf("n=%v", n), a, p, true, rnd)
+	}
+}
+
+func TestIC0Tridiagonal(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 50} {
+		a := newTridiag(n, true, rnd)
+		p, err := NewIC0(a)
+		if err != nil {
+			t.Fatalf("n=%v: unexpected error %v", n, err)
+		}
+		testExact(t, fmt.Sprintf("n=%v", n), a, p, true, rnd)
+	}
+}
+
+func TestILUTComplete(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 50} {
+		for _, nnz := range []int{1, 3, 10} {
+			// Without dropping, ILUT computes the complete LU factorization.
+			a := newRandomSparse(n, nnz, rnd)
+			p, err := NewILUT(a, n, 0)
+			if err != nil {
+				t.Fatalf("n=%v,nnz=%v: unexpected error %v", n, nnz, err)
+			}
+			testExact(t, fmt.Sprintf("n=%v,nnz=%v", n, nnz), a, p, true, rnd)
+		}
+	}
+}
+
+func TestILUTPattern(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	a := newRandomSparse(100, 5, rnd)
+	for _, fill := range []int{0, 1, 2, 5} {
+		p, err := NewILUT(a, fill, 1e-3)
+		if err != nil {
+			t.Fatalf("fill=%v: unexpected error %v", fill, err)
+		}
+		for i := 0; i < 100; i++ {
+			lind, _ := row(p.l.m, i)
+			uind, _ := row(p.u.m, i)
+			if len(lind) > fill || len(uind) > fill {
+				t.Errorf("fill=%v: row %v has %v elements in L and %v in U", fill, i, len(lind), len(uind))
+			}
+			for _, j := range lind {
+				if j >= i {
+					t.Errorf("fill=%v: element (%v,%v) in L", fill, i, j)
+				}
+			}
+			for _, j := range uind {
+				if j <= i {
+					t.Errorf("fill=%v: element (%v,%v) in U", fill, i, j)
+				}
+			}
+		}
+	}
+}
+
+func TestZeroPivot(t *testing.T) {
+	t.Parallel()
+
+	a := sparse.NewCOO(2, 2)
+	a.Append(0, 1, 1)
+	a.Append(1, 0, 1)
+	a.Append(1, 1, 1)
+	_, err := NewILU0(a)
+	if err == nil {
+		t.Error("ILU0: missing error for zero pivot")
+	}
+	_, err = NewIC0(a)
+	if err == nil {
+		t.Error("IC0: missing error for zero pivot")
+	}
+	// ILUT replaces the zero pivot.
+	_, err = NewILUT(a, 2, 0)
+	if err != nil {
+		t.Errorf("ILUT: unexpected error %v", err)
+	}
+
+	a.Append(1, 1, -2)
+	a.Append(0, 0, 1)
+	_, err = NewIC0(a)
+	if err == nil {
+		t.Error("IC0: missing error for indefinite matrix")
+	}
+}
+
+func TestDenseInput(t *testing.T) {
+	t.Parallel()
+
+	// A general mat.Matrix must give the same factors as a sparse one.
+	rnd := rand.New(rand.NewSource(1))
+	a := newRandomSparse(20, 4, rnd)
+	d := mat.NewDense(20, 20, nil)
+	for i := 0; i < 20; i++ {
+		for j := 0; j < 20; j++ {
+			d.Set(i, j, a.At(i, j))
+		}
+	}
+	p, err := NewILU0(d)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	q, err := NewILU0(a)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	b := mat.NewVecDense(20, nil)
+	for i := 0; i < 20; i++ {
+		b.SetVec(i, rnd.NormFloat64())
+	}
+	var got, want mat.VecDense
+	for _, trans := range []bool{false, true} {
+		p.PreconSolve(&got, trans, b)
+		q.PreconSolve(&want, trans, b)
+		if !mat.EqualApprox(&got, &want, 1e-14) {
+			t.Errorf("trans=%v: mismatched solution", trans)
+		}
+	}
+}
+
+func TestJacobi(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 20} {
+		a := sparse.NewCOO(n, n)
+		for i := 0; i < n; i++ {
+			a.Append(i, i, rnd.NormFloat64())
+		}
+		p, err := NewJacobi(a)
+		if err != nil {
+			t.Fatalf("n=%v: unexpected error %v", n, err)
+		}
+		testExact(t, fmt.Sprintf("n=%v", n), a, p, true, rnd)
+	}
+
+	a := sparse.NewCOO(2, 2)
+	a.Append(0, 0, 1)
+	a.Append(0, 1, 1)
+	a.Append(1, 0, 1)
+	_, err := NewJacobi(a)
+	if err == nil {
+		t.Error("missing error for zero diagonal element")
+	}
+}
+
+func TestBlockJacobi(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n     int
+		sizes []int
+	}{
+		{n: 1, sizes: []int{1}},
+		{n: 5, sizes: []int{5}},
+		{n: 5, sizes: []int{1, 1, 1, 1, 1}},
+		{n: 10, sizes: []int{3, 1, 4, 2}},
+		{n: 50, sizes: []int{10, 7, 13, 20}},
+	} {
+		// Assign randomly permuted indices to the blocks so that they are not
+		// contiguous.
+		perm := rnd.Perm(test.n)
+		var blocks [][]int
+		for _, size := range test.sizes {
+			blocks = append(blocks, perm[:size])
+			perm = perm[size:]
+		}
+		// Generate a block diagonal matrix for which block Jacobi is exact.
+		a := sparse.NewCOO(test.n, test.n)
+		for _, ind := range blocks {
+			for _, i := range ind {
+				for _, j := range ind {
+					v := rnd.NormFloat64()
+					if i == j {
+						v += float64(len(ind))
+					}
+					a.Append(i, j, v)
+				}
+			}
+		}
+		name := fmt.Sprintf("n=%v,sizes=%v", test.n, test.sizes)
+		p, err := NewBlockJacobi(a, blocks)
+		if err != nil {
+			t.Fatalf("%v: unexpected error %v", name, err)
+		}
+		testExact(t, name, a, p, true, rnd)
+
+		if len(blocks) == 1 {
+			continue
+		}
+		// Elements outside of the diagonal blocks must be ignored.
+		a.Append(blocks[0][0], blocks[len(blocks)-1][0], 10)
+		q, err := NewBlockJacobi(a, blocks)
+		if err != nil {
+			t.Fatalf("%v: unexpected error %v", name, err)
+		}
+		b := mat.NewVecDense(test.n, nil)
+		for i := 0; i < test.n; i++ {
+			b.SetVec(i, rnd.NormFloat64())
+		}
+		var got, want mat.VecDense
+		for _, trans := range []bool{false, true} {
+			p.PreconSolve(&want, trans, b)
+			q.PreconSolve(&got, trans, b)
+			if !mat.EqualApprox(&got, &want, 1e-14) {
+				t.Errorf("%v: trans=%v: mismatched solution", name, trans)
+			}
+		}
+	}
+
+	a := newTridiag(4, false, rnd)
+	for _, blocks := range [][][]int{
+		{{0, 1}, {2}},
+		{{0, 1}, {1, 2, 3}},
+		{{0, 1}, {}, {2, 3}},
+		{{0, 1, 2, 3, 4}},
+	} {
+		if !panics(func() { NewBlockJacobi(a, blocks) }) {
+			t.Errorf("blocks=%v: missing panic for invalid partition", blocks)
+		}
+	}
+}
+
+func TestSSOR(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 20} {
+		a := newRandomSparse(n, 4, rnd)
+		for _, omega := range []float64{0.5, 1, 1.5} {
+			name := fmt.Sprintf("n=%v,omega=%v", n, omega)
+			p, err := NewSSOR(a, omega)
+			if err != nil {
+				t.Fatalf("%v: unexpected error %v", name, err)
+			}
+
+			// Form the preconditioning matrix explicitly.
+			l := mat.NewDense(n, n, nil)
+			u := mat.NewDense(n, n, nil)
+			dinv := mat.NewDiagDense(n, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					v := a.At(i, j)
+					switch {
+					case i > j:
+						l.Set(i, j, v)
+					case i < j:
+						u.Set(i, j, v)
+					default:
+						l.Set(i, i, v/omega)
+						u.Set(i, i, v/omega)
+						dinv.SetDiag(i, omega/v)
+					}
+				}
+			}
+			var m mat.Dense
+			m.Product(l, dinv, u)
+			m.Scale(omega/(2-omega), &m)
+
+			b := mat.NewVecDense(n, nil)
+			for i := 0; i < n; i++ {
+				b.SetVec(i, rnd.NormFloat64())
+			}
+			for _, trans := range []bool{false, true} {
+				var x, got mat.VecDense
+				p.PreconSolve(&x, trans, b)
+				if trans {
+					got.MulVec(m.T(), &x)
+				} else {
+					got.MulVec(&m, &x)
+				}
+				if !mat.EqualApprox(&got, b, 1e-12) {
+					t.Errorf("%v: trans=%v: M*x != b", name, trans)
+				}
+			}
+		}
+	}
+}
+
+func TestSOR(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 20} {
+		a := newRandomSparse(n, 4, rnd)
+		for _, omega := range []float64{0.5, 1, 1.5} {
+			name := fmt.Sprintf("n=%v,omega=%v", n, omega)
+			p, err := NewSOR(a, omega)
+			if err != nil {
+				t.Fatalf("%v: unexpected error %v", name, err)
+			}
+
+			// Form the preconditioning matrix explicitly.
+			m := mat.NewDense(n, n, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j <= i; j++ {
+					v := a.At(i, j)
+					if i == j {
+						v /= omega
+					}
+					m.Set(i, j, v)
+				}
+			}
+
+			b := mat.NewVecDense(n, nil)
+			for i := 0; i < n; i++ {
+				b.SetVec(i, rnd.NormFloat64())
+			}
+			for _, trans := range []bool{false, true} {
+				var x, got mat.VecDense
+				p.PreconSolve(&x, trans, b)
+				if trans {
+					got.MulVec(m.T(), &x)
+				} else {
+					got.MulVec(m, &x)
+				}
+				if !mat.EqualApprox(&got, b, 1e-12) {
+					t.Errorf("%v: trans=%v: M*x != b", name, trans)
+				}
+			}
+		}
+	}
+
+	if !panics(func() { NewSOR(newPoisson2D(2, 2), 2) }) {
+		t.Errorf("missing panic for invalid relaxation parameter")
+	}
+}
+
+func TestStationary(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	spd := newPoisson2D(10, 10)
+	nonsym := newRandomSparse(100, 4, rnd)
+	for _, test := range []struct {
+		name   string
+		a      *sparse.COO
+		method func(a mat.Matrix) (linsolve.Method, error)
+	}{
+		{
+			name: "Jacobi", a: spd,
+			method: func(a mat.Matrix) (linsolve.Method, error) { return NewJacobi(a) },
+		},
+		{
+			name: "GaussSeidel", a: spd,
+			method: func(a mat.Matrix) (linsolve.Method, error) { return NewGaussSeidel(a) },
+		},
+		{
+			name: "SOR", a: spd,
+			method: func(a mat.Matrix) (linsolve.Method, error) { return NewSOR(a, 1.5) },
+		},
+		{
+			name: "SSOR", a: spd,
+			method: func(a mat.Matrix) (linsolve.Method, error) { return NewSSOR(a, 1.5) },
+		},
+		{
+			name: "Jacobi nonsym", a: nonsym,
+			method: func(a mat.Matrix) (linsolve.Method, error) { return NewJacobi(a) },
+		},
+		{
+			name: "GaussSeidel nonsym", a: nonsym,
+			method: func(a mat.Matrix) (linsolve.Method, error) { return NewGaussSeidel(a) },
+		},
+		{
+			name: "SSOR nonsym", a: nonsym,
+			method: func(a mat.Matrix) (linsolve.Method, error) { return NewSSOR(a, 1) },
+		},
+	} {
+		m, err := test.method(test.a)
+		if err != nil {
+			t.Fatalf("%v: unexpected error %v", test.name, err)
+		}
+		n, _ := test.a.Dims()
+		want := make([]float64, n)
+		for i := range want {
+			want[i] = rnd.NormFloat64()
+		}
+		b := mat.NewVecDense(n, nil)
+		test.a.MulVecTo(b, false, mat.NewVecDense(n, want))
+
+		res, err := linsolve.Iterative(test.a, b, m, &linsolve.Settings{
+			Tolerance:     1e-10,
+			MaxIterations: 5000,
+		})
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.name, err)
+			continue
+		}
+		if !floats.EqualApprox(res.X.RawVector().Data, want, 1e-7) {
+			t.Errorf("%v: unexpected solution", test.name)
+		}
+		// Each iteration computes the residual of the new iterate and the
+		// matrix is checked once with a test vector.
+		if res.Stats.MulVec != res.Stats.Iterations+1 || res.Stats.PreconSolve != 0 {
+			t.Errorf("%v: unexpected statistics %+v", test.name, res.Stats)
+		}
+
+		// The reported residual norm is the norm of the residual of the
+		// returned iterate also if the iteration limit is reached.
+		m, _ = test.method(test.a)
+		res, err = linsolve.Iterative(test.a, b, m, &linsolve.Settings{
+			MaxIterations:   5,
+			ResidualHistory: true,
+		})
+		if err != linsolve.ErrIterationLimit {
+			t.Errorf("%v: unexpected error %v, want %v", test.name, err, linsolve.ErrIterationLimit)
+		}
+		r := mat.NewVecDense(n, nil)
+		test.a.MulVecTo(r, false, res.X)
+		r.SubVec(b, r)
+		if rNorm := mat.Norm(r, 2); math.Abs(rNorm-res.ResidualNorm) > 1e-12*rNorm {
+			t.Errorf("%v: residual norm mismatch: reported %v, true %v", test.name, res.ResidualNorm, rNorm)
+		}
+		hist := res.ResidualHistory
+		if len(hist) != 6 || hist[5] != res.ResidualNorm {
+			t.Errorf("%v: unexpected residual history %v", test.name, hist)
+		}
+	}
+
+	// Gauss-Seidel solves a diagonal system exactly in one iteration.
+	diag := sparse.NewCOO(10, 10)
+	for i := 0; i < 10; i++ {
+		diag.Append(i, i, float64(i+1))
+	}
+	gs, _ := NewGaussSeidel(diag)
+	b := mat.NewVecDense(10, nil)
+	for i := 0; i < 10; i++ {
+		b.SetVec(i, rnd.NormFloat64())
+	}
+	res, err := linsolve.Iterative(diag, b, gs, &linsolve.Settings{MaxIterations: 1})
+	if err != nil || res.Stats.Iterations != 1 || res.ResidualNorm > 1e-14 {
+		t.Errorf("GaussSeidel diagonal: unexpected result %v, %+v, error %v", res.ResidualNorm, res.Stats, err)
+	}
+
+	// The methods detect that they are used with a different matrix.
+	other := newPoisson2D(10, 10)
+	other.Append(3, 7, 1e-3)
+	for _, newMethod := range []func(a mat.Matrix) (linsolve.Method, error){
+		func(a mat.Matrix) (linsolve.Method, error) { return NewJacobi(a) },
+		func(a mat.Matrix) (linsolve.Method, error) { return NewSOR(a, 1.5) },
+		func(a mat.Matrix) (linsolve.Method, error) { return NewSSOR(a, 1.5) },
+		func(a mat.Matrix) (linsolve.Method, error) { return NewAMG(a, nil) },
+	} {
+		m, err := newMethod(spd)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		b := mat.NewVecDense(100, nil)
+		b.SetVec(0, 1)
+		_, err = linsolve.Iterative(other, b, m, nil)
+		if err != ErrMatrixMismatch {
+			t.Errorf("%T: unexpected error %v, want %v", m, err, ErrMatrixMismatch)
+		}
+		_, err = linsolve.Iterative(spd, b, m, nil)
+		if err != nil {
+			t.Errorf("%T: unexpected error %v after mismatch", m, err)
+		}
+	}
+
+	// Gauss-Seidel converges twice as fast as Jacobi for consistently
+	// ordered matrices and SOR with a good relaxation parameter is much
+	// faster.
+	var iters []int
+	for _, newMethod := range []func(a mat.Matrix) (linsolve.Method, error){
+		func(a mat.Matrix) (linsolve.Method, error) { return NewJacobi(a) },
+		func(a mat.Matrix) (linsolve.Method, error) { return NewGaussSeidel(a) },
+		func(a mat.Matrix) (linsolve.Method, error) { return NewSOR(a, 1.5) },
+	} {
+		m, _ := newMethod(spd)
+		b := mat.NewVecDense(100, nil)
+		b.SetVec(0, 1)
+		res, err := linsolve.Iterative(spd, b, m, &linsolve.Settings{MaxIterations: 5000})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		iters = append(iters, res.Stats.Iterations)
+	}
+	if 2*iters[1] > iters[0]+iters[0]/10 || 2*iters[2] > iters[1] {
+		t.Errorf("unexpected number of iterations for Jacobi, Gauss-Seidel and SOR: %v", iters)
+	}
+}
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return false
+}
+
+func TestIterative(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	spd := newPoisson2D(20, 20)
+	scaled := newScaled(spd, rnd)
+	nonsym := newConvectionDiffusion2D(20, 20, 50)
+
+	// blocks partitions the grid into its columns.
+	var blocks [][]int
+	for i := 0; i < 400; i += 20 {
+		var b []int
+		for j := i; j < i+20; j++ {
+			b = append(b, j)
+		}
+		blocks = append(blocks, b)
+	}
+
+	for _, test := range []struct {
+		name   string
+		a      *sparse.COO
+		method linsolve.Method
+		newP   func(a mat.Matrix) (preconSolver, error)
+	}{
+		{
+			name: "CG+IC0", a: spd, method: &linsolve.CG{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewIC0(a) },
+		},
+		{
+			name: "GMRES+ILU0", a: nonsym, method: &linsolve.GMRES{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewILU0(a) },
+		},
+		{
+			name: "BiCG+ILU0", a: nonsym, method: &linsolve.BiCG{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewILU0(a) },
+		},
+		{
+			name: "BiCGStab+ILUT", a: nonsym, method: &linsolve.BiCGStab{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewILUT(a, 10, 1e-4) },
+		},
+		{
+			name: "BiCG+ILUT", a: nonsym, method: &linsolve.BiCG{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewILUT(a, 10, 1e-4) },
+		},
+		{
+			name: "CG+Jacobi", a: scaled, method: &linsolve.CG{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewJacobi(a) },
+		},
+		{
+			name: "CG+BlockJacobi", a: spd, method: &linsolve.CG{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewBlockJacobi(a, blocks) },
+		},
+		{
+			name: "BiCG+BlockJacobi", a: nonsym, method: &linsolve.BiCG{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewBlockJacobi(a, blocks) },
+		},
+		{
+			name: "CG+SSOR", a: spd, method: &linsolve.CG{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewSSOR(a, 1.5) },
+		},
+		{
+			name: "BiCG+SSOR", a: nonsym, method: &linsolve.BiCG{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewSSOR(a, 1.2) },
+		},
+		{
+			name: "GMRES+SSOR", a: nonsym, method: &linsolve.GMRES{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewSSOR(a, 1) },
+		},
+		{
+			name: "CG+AMG", a: spd, method: &linsolve.CG{},
+			newP: func(a mat.Matrix) (preconSolver, error) { return NewAMG(a, nil) },
+		},
+		{
+			name: "BiCG+AMG", a: nonsym, method: &linsolve.BiCG{},
+			newP: func(a mat.Matrix) (preconSolver, error) {
+				return NewAMG(a, &AMGSettings{Smoother: GaussSeidelSmoother})
+			},
+		},
+	} {
+		p, err := test.newP(test.a)
+		if err != nil {
+			t.Fatalf("%v: unexpected error %v", test.name, err)
+		}
+		n, _ := test.a.Dims()
+		want := make([]float64, n)
+		for i := range want {
+			want[i] = 1
+		}
+		b := mat.NewVecDense(n, nil)
+		test.a.MulVecTo(b, false, mat.NewVecDense(n, want))
+
+		plain, err := linsolve.Iterative(test.a, b, test.method, &linsolve.Settings{Tolerance: 1e-10})
+		if err != nil {
+			t.Fatalf("%v: unpreconditioned solve failed: %v", test.name, err)
+		}
+		res, err := linsolve.Iterative(test.a, b, test.method, &linsolve.Settings{
+			Tolerance:   1e-10,
+			PreconSolve: p.PreconSolve,
+		})
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.name, err)
+			continue
+		}
+		if !floats.EqualApprox(res.X.RawVector().Data, want, 1e-7) {
+			t.Errorf("%v: unexpected solution", test.name)
+		}
+		if res.Stats.MulVec >= plain.Stats.MulVec {
+			t.Errorf("%v: preconditioner did not reduce the number of MulVec operations: %v >= %v",
+				test.name, res.Stats.MulVec, plain.Stats.MulVec)
+		}
+	}
+}
+
+func TestAMG(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, smoother := range []Smoother{JacobiSmoother, GaussSeidelSmoother} {
+		var iters []int
+		for _, nx := range []int{16, 32, 64} {
+			name := fmt.Sprintf("smoother=%v,nx=%v", smoother, nx)
+			a := newPoisson2D(nx, nx)
+			n := nx * nx
+			amg, err := NewAMG(a, &AMGSettings{Smoother: smoother})
+			if err != nil {
+				t.Fatalf("%v: unexpected error %v", name, err)
+			}
+			if len(amg.levels) < 2 {
+				t.Errorf("%v: no coarse levels", name)
+			}
+			want := make([]float64, n)
+			for i := range want {
+				want[i] = rnd.NormFloat64()
+			}
+			b := mat.NewVecDense(n, nil)
+			a.MulVecTo(b, false, mat.NewVecDense(n, want))
+
+			res, err := linsolve.Iterative(a, b, &linsolve.CG{}, &linsolve.Settings{
+				Tolerance:   1e-10,
+				PreconSolve: amg.PreconSolve,
+			})
+			if err != nil {
+				t.Errorf("%v: CG: unexpected error %v", name, err)
+				continue
+			}
+			if !floats.EqualApprox(res.X.RawVector().Data, want, 1e-7) {
+				t.Errorf("%v: CG: unexpected solution", name)
+			}
+			iters = append(iters, res.Stats.Iterations)
+
+			// AMG as a standalone stationary method.
+			res, err = linsolve.Iterative(a, b, amg, &linsolve.Settings{Tolerance: 1e-10})
+			if err != nil {
+				t.Errorf("%v: AMG: unexpected error %v", name, err)
+				continue
+			}
+			if !floats.EqualApprox(res.X.RawVector().Data, want, 1e-7) {
+				t.Errorf("%v: AMG: unexpected solution", name)
+			}
+		}
+		// The number of iterations must be nearly independent of the mesh
+		// size, unlike for unpreconditioned CG where it doubles.
+		if iters[len(iters)-1] > iters[0]+iters[0]/2 {
+			t.Errorf("smoother=%v: number of iterations grows with mesh size: %v", smoother, iters)
+		}
+	}
+}
+
+func TestAMGTranspose(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name string
+		a    *sparse.COO
+		sym  bool
+	}{
+		{name: "Poisson", a: newPoisson2D(20, 20), sym: true},
+		{name: "ConvectionDiffusion", a: newConvectionDiffusion2D(20, 20, 50)},
+	} {
+		for _, settings := range []AMGSettings{
+			{Smoother: JacobiSmoother},
+			{Smoother: GaussSeidelSmoother},
+			{Smoother: GaussSeidelSmoother, PreSmooth: 2, PostSmooth: 1},
+			{Smoother: JacobiSmoother, MaxCoarse: 10, StrengthThreshold: 0.25},
+		} {
+			name := fmt.Sprintf("%v,%+v", test.name, settings)
+			amg, err := NewAMG(test.a, &settings)
+			if err != nil {
+				t.Fatalf("%v: unexpected error %v", name, err)
+			}
+			n, _ := test.a.Dims()
+			x := mat.NewVecDense(n, nil)
+			y := mat.NewVecDense(n, nil)
+			for i := 0; i < n; i++ {
+				x.SetVec(i, rnd.NormFloat64())
+				y.SetVec(i, rnd.NormFloat64())
+			}
+			var bx, bty mat.VecDense
+			amg.PreconSolve(&bx, false, x)
+			amg.PreconSolve(&bty, true, y)
+			// <B*x, y> must be equal to <x, Bᵀ*y>.
+			got, want := mat.Dot(&bx, y), mat.Dot(x, &bty)
+			if !scalar.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+				t.Errorf("%v: transposed cycle mismatch: %v != %v", name, got, want)
+			}
+			if !test.sym || settings.PreSmooth != settings.PostSmooth {
+				continue
+			}
+			// The cycle must be symmetric for a symmetric matrix.
+			var by mat.VecDense
+			amg.PreconSolve(&by, false, y)
+			got = mat.Dot(x, &by)
+			if !scalar.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+				t.Errorf("%v: cycle not symmetric: %v != %v", name, got, want)
+			}
+		}
+	}
+}
+
+func TestChebyshev(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	a := newPoisson2D(20, 20)
+	n, _ := a.Dims()
+	b := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		b.SetVec(i, rnd.NormFloat64())
+	}
+
+	// Estimate the extreme eigenvalues from the CG solve.
+	cg := &linsolve.CG{}
+	plain, err := linsolve.Iterative(a, b, cg, &linsolve.Settings{Tolerance: 1e-10})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	minEig, maxEig := cg.EigenvalueBounds()
+	// The exact eigenvalues of the Laplacian.
+	h := math.Pi / 21
+	wantMin, wantMax := 8*math.Pow(math.Sin(h/2), 2), 8*math.Pow(math.Cos(h/2), 2)
+	if !scalar.EqualWithinRel(minEig, wantMin, 1e-6) || !scalar.EqualWithinRel(maxEig, wantMax, 1e-6) {
+		t.Errorf("unexpected eigenvalue estimates: got [%v,%v], want [%v,%v]", minEig, maxEig, wantMin, wantMax)
+	}
+
+	// A higher degree gives a better approximation of the inverse.
+	want := plain.X
+	prevErr := math.Inf(1)
+	for _, degree := range []int{1, 2, 5, 10, 20, 40} {
+		p := NewChebyshev(a, degree, minEig, maxEig, nil)
+		var got mat.VecDense
+		err := p.PreconSolve(&got, false, b)
+		if err != nil {
+			t.Fatalf("degree=%v: unexpected error %v", degree, err)
+		}
+		var diff mat.VecDense
+		diff.SubVec(&got, want)
+		e := mat.Norm(&diff, 2) / mat.Norm(want, 2)
+		if e >= prevErr {
+			t.Errorf("degree=%v: error did not decrease: %v >= %v", degree, e, prevErr)
+		}
+		prevErr = e
+	}
+
+	// The preconditioner is symmetric and reduces the number of CG
+	// iterations.
+	p := NewChebyshev(a, 5, minEig, maxEig, nil)
+	u := mat.NewVecDense(n, nil)
+	v := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		u.SetVec(i, rnd.NormFloat64())
+		v.SetVec(i, rnd.NormFloat64())
+	}
+	var pu, pv mat.VecDense
+	_ = p.PreconSolve(&pu, false, u)
+	_ = p.PreconSolve(&pv, false, v)
+	if !scalar.EqualWithinAbsOrRel(mat.Dot(&pu, v), mat.Dot(&pv, u), 1e-12, 1e-12) {
+		t.Errorf("preconditioner not symmetric")
+	}
+	res, err := linsolve.Iterative(a, b, &linsolve.CG{}, &linsolve.Settings{
+		Tolerance:   1e-10,
+		PreconSolve: p.PreconSolve,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if res.Stats.Iterations >= plain.Stats.Iterations/2 {
+		t.Errorf("preconditioner did not reduce the number of iterations: %v, %v without", res.Stats.Iterations, plain.Stats.Iterations)
+	}
+	if !mat.EqualApprox(res.X, plain.X, 1e-8) {
+		t.Errorf("unexpected solution")
+	}
+}
+
+func TestChebyshevTranspose(t *testing.T) {
+	t.Parallel()
+
+	// The transposed preconditioner with an inner Jacobi preconditioner
+	// satisfies u · M^{-1} v = M^{-T} u · v.
+	rnd := rand.New(rand.NewSource(1))
+	a := newScaled(newConvectionDiffusion2D(10, 10, 20), rnd)
+	n, _ := a.Dims()
+	jacobi, err := NewJacobi(a)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	b := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		b.SetVec(i, rnd.NormFloat64())
+	}
+	gmres := &linsolve.GMRES{Restart: 30}
+	_, err = linsolve.Iterative(a, b, gmres, &linsolve.Settings{
+		MaxIterations: 1,
+		PreconSolve:   jacobi.PreconSolve,
+	})
+	if err != nil && err != linsolve.ErrIterationLimit {
+		t.Fatalf("unexpected error %v", err)
+	}
+	minEig, maxEig := gmres.EigenvalueBounds()
+	if minEig <= 0 || maxEig <= minEig {
+		t.Fatalf("unexpected eigenvalue estimates [%v,%v]", minEig, maxEig)
+	}
+
+	p := NewChebyshev(a, 4, minEig, 1.1*maxEig, jacobi.PreconSolve)
+	u := mat.NewVecDense(n, nil)
+	v := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		u.SetVec(i, rnd.NormFloat64())
+		v.SetVec(i, rnd.NormFloat64())
+	}
+	var pv, ptu mat.VecDense
+	_ = p.PreconSolve(&pv, false, v)
+	_ = p.PreconSolve(&ptu, true, u)
+	if !scalar.EqualWithinAbsOrRel(mat.Dot(u, &pv), mat.Dot(&ptu, v), 1e-12, 1e-12) {
+		t.Errorf("transposed preconditioner inconsistent: %v != %v", mat.Dot(u, &pv), mat.Dot(&ptu, v))
+	}
+
+	if !panics(func() { NewChebyshev(a, 0, 1, 2, nil) }) {
+		t.Errorf("expected panic for zero degree")
+	}
+	if !panics(func() { NewChebyshev(a, 1, 2, 1, nil) }) {
+		t.Errorf("expected panic for invalid eigenvalue bounds")
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package precond
+
+import (
+	"fmt"
+
+	"gonum.org/v1/exp/linsolve"
+	"gonum.org/v1/gonum/mat"
+)
+
+// SOR is the successive over-relaxation preconditioner
+//
+//	M = D/ω + L,
+//
+// where D and L are the diagonal and the strictly lower triangular part of a
+// square matrix A, and ω is the relaxation parameter. For ω equal to 1, SOR is
+// the Gauss-Seidel preconditioner.
+//
+// SOR also implements the linsolve.Method interface as the SOR iteration
+// which performs in-place forward sweeps
+//
+//	x_i = (1-ω) * x_i + ω * (b_i - Σ_{j≠i} a_ij * x_j) / a_ii
+//
+// over the rows of A. As a Method, SOR can be used only for solving systems with the matrix it has been constructed
+// from. The iteration converges for example if A is symmetric positive
+// definite and ω is in the interval (0,2), or for ω equal to 1 if A is strictly
+// diagonally dominant.
+//
+// References:
+//   - Saad, Y. (2003). Section 4.1 Jacobi, Gauss-Seidel, and SOR. In Iterative
+//     Methods for Sparse Linear Systems (2nd ed.) (pp. 105-112). Philadelphia,
+//     PA: SIAM.
+type SOR struct {
+	// diag holds the diagonal of A.
+	diag  []float64
+	omega float64
+	l     triangular
+
+	// Data for use as linsolve.Method.
+	stationary
+}
+
+// NewSOR returns the SOR preconditioner for the n×n matrix a with the
+// relaxation parameter omega. It returns an error if a diagonal element of a
+// is zero.
+//
+// NewSOR panics if omega is not in the interval (0,2).
+func NewSOR(a mat.Matrix, omega float64) (*SOR, error) {
+	if omega <= 0 || 2 <= omega {
+		panic("precond: relaxation parameter out of range")
+	}
+	m := newCSR(a)
+	l, _, d := split(m)
+	diag := make([]float64, len(d))
+	for i, v := range d {
+		if v == 0 {
+			return nil, fmt.Errorf("precond: zero diagonal element in row %d", i)
+		}
+		diag[i] = v
+		d[i] = v / omega
+	}
+	return &SOR{
+		diag:       diag,
+		omega:      omega,
+		l:          newTriangular(l, true, d),
+		stationary: stationary{a: m},
+	}, nil
+}
+
+// NewGaussSeidel returns the Gauss-Seidel preconditioner for the n×n matrix a,
+// that is, the SOR preconditioner with ω equal to 1. It returns an error if a
+// diagonal element of a is zero.
+func NewGaussSeidel(a mat.Matrix) (*SOR, error) {
+	return NewSOR(a, 1)
+}
+
+// PreconSolve solves M * dst = rhs or Mᵀ * dst = rhs if trans is true. If dst
+// is empty, it will be resized to the length of rhs.
+func (p *SOR) PreconSolve(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+	solveInPlace(dst, rhs, p.l.n, func(x []float64) {
+		p.l.solve(trans, x)
+	})
+	return nil
+}
+
+// Init initializes the data for a linear solve. See the linsolve.Method
+// interface for more details.
+func (p *SOR) Init(x, residual *mat.VecDense) {
+	p.init(x, residual)
+}
+
+// Iterate performs an iteration of the linear solve. See the linsolve.Method
+// interface for more details.
+//
+// SOR will command the following operations:
+//
+//	MulVec
+//	ComputeResidual
+//	CheckResidualNorm
+//	MajorIteration
+func (p *SOR) Iterate(ctx *linsolve.Context) (linsolve.Operation, error) {
+	return p.iterate(ctx, func(x, b, _ []float64) {
+		sorSweep(p.a, p.diag, p.omega, x, b, true)
+	})
+}
+
+// SSOR is the symmetric successive over-relaxation preconditioner
+//
+//	M = ω/(2-ω) * (D/ω + L) * (D/ω)^{-1} * (D/ω + U),
+//
+// where D, L and U are the diagonal, the strictly lower and the strictly upper
+// triangular parts of a square matrix A, and ω is the relaxation parameter.
+// If A is symmetric positive definite, M is also symmetric positive definite
+// and SSOR can be used with CG. For ω equal to 1, SSOR is the symmetric
+// Gauss-Seidel preconditioner.
+//
+// SSOR also implements the linsolve.Method interface as the SSOR iteration
+// which performs an in-place forward SOR sweep over the rows of A followed by
+// a backward sweep. As a Method, SSOR can be used only for solving systems with the matrix it has
+// been constructed from.
+//
+// References:
+//   - Saad, Y. (2003). Section 10.2 Jacobi, SOR, and SSOR Preconditioners. In
+//     Iterative Methods for Sparse Linear Systems (2nd ed.) (pp. 284-289).
+//     Philadelphia, PA: SIAM.
+type SSOR struct {
+	// diag holds the diagonal of A and dw the diagonal divided by ω.
+	diag  []float64
+	dw    []float64
+	omega float64
+	// scale is (2-ω)/ω.
+	scale float64
+	l     triangular
+	u     triangular
+
+	// Data for use as linsolve.Method.
+	stationary
+}
+
+// NewSSOR returns the SSOR preconditioner for the n×n matrix a with the
+// relaxation parameter omega. It returns an error if a diagonal element of a
+// is zero.
+//
+// NewSSOR panics if omega is not in the interval (0,2).
+func NewSSOR(a mat.Matrix, omega float64) (*SSOR, error) {
+	if omega <= 0 || 2 <= omega {
+		panic("precond: relaxation parameter out of range")
+	}
+	m := newCSR(a)
+	l, u, d := split(m)
+	diag := make([]float64, len(d))
+	for i, v := range d {
+		if v == 0 {
+			return nil, fmt.Errorf("precond: zero diagonal element in row %d", i)
+		}
+		diag[i] = v
+		d[i] = v / omega
+	}
+	return &SSOR{
+		diag:       diag,
+		dw:         d,
+		omega:      omega,
+		scale:      (2 - omega) / omega,
+		l:          newTriangular(l, true, d),
+		u:          newTriangular(u, false, d),
+		stationary: stationary{a: m},
+	}, nil
+}
+
+// PreconSolve solves M * dst = rhs or Mᵀ * dst = rhs if trans is true. If dst
+// is empty, it will be resized to the length of rhs.
+func (p *SSOR) PreconSolve(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+	solveInPlace(dst, rhs, len(p.dw), func(x []float64) {
+		// Mᵀ = ω/(2-ω) * (D/ω + Uᵀ) * (D/ω)^{-1} * (D/ω + Lᵀ), so the two
+		// triangular factors are applied in the reverse order.
+		first, second := &p.l, &p.u
+		if trans {
+			first, second = second, first
+		}
+		first.solve(trans, x)
+		for i, d := range p.dw {
+			x[i] *= p.scale * d
+		}
+		second.solve(trans, x)
+	})
+	return nil
+}
+
+// Init initializes the data for a linear solve. See the linsolve.Method
+// interface for more details.
+func (p *SSOR) Init(x, residual *mat.VecDense) {
+	p.init(x, residual)
+}
+
+// Iterate performs an iteration of the linear solve. See the linsolve.Method
+// interface for more details.
+//
+// SSOR will command the following operations:
+//
+//	MulVec
+//	ComputeResidual
+//	CheckResidualNorm
+//	MajorIteration
+func (p *SSOR) Iterate(ctx *linsolve.Context) (linsolve.Operation, error) {
+	return p.iterate(ctx, func(x, b, _ []float64) {
+		sorSweep(p.a, p.diag, p.omega, x, b, true)
+		sorSweep(p.a, p.diag, p.omega, x, b, false)
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package precond
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/exp/linsolve"
+	"gonum.org/v1/exp/linsolve/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ErrMatrixMismatch is returned by the Iterate methods of the stationary
+// iterative methods when the matrix passed to linsolve.Iterative is not the
+// matrix the method has been constructed from.
+var ErrMatrixMismatch = errors.New("precond: matrix does not match the matrix of the method")
+
+// mismatchTol is the relative tolerance for comparing the product of the matrix
+// of the linear solve and of the stored matrix with a test vector.
+const mismatchTol = 1e-10
+
+// stationary holds the data of a stationary iterative method for the matrix a
+// for use as linsolve.Method.
+type stationary struct {
+	a *sparse.CSR
+
+	x, b, r mat.VecDense
+	resume  int
+}
+
+// init initializes the data for a linear solve.
+func (s *stationary) init(x, residual *mat.VecDense) {
+	n := rows(s.a)
+	if x.Len() != n || residual.Len() != n {
+		panic("precond: vector length mismatch")
+	}
+	s.x.CloneFromVec(x)
+	s.r.CloneFromVec(residual)
+	s.b.Reset()
+	s.b.ReuseAsVec(n)
+	s.resume = 1
+}
+
+// iterate performs an iteration of the linear solve. sweep updates x in place
+// for the right-hand side b, where r is the residual b - A*x.
+func (s *stationary) iterate(ctx *linsolve.Context, sweep func(x, b, r []float64)) (linsolve.Operation, error) {
+	switch s.resume {
+	case 1:
+		// Multiply the matrix of the linear solve with a test vector to
+		// check that it is the stored matrix.
+		for i := 0; i < ctx.Src.Len(); i++ {
+			ctx.Src.SetVec(i, math.Sin(float64(i+1)))
+		}
+		s.resume = 2
+		return linsolve.MulVec, nil
+	case 2:
+		if !s.matches(ctx.Src, ctx.Dst) {
+			s.resume = 0
+			return linsolve.NoOperation, ErrMatrixMismatch
+		}
+		// The right-hand side is not available to Method, it is
+		// recovered from the initial residual as b = r_0 + A*x_0.
+		b := s.b.RawVector().Data
+		mulVec(s.a, b, s.x.RawVector().Data)
+		s.b.AddVec(&s.b, &s.r)
+		fallthrough
+	case 5:
+		sweep(s.x.RawVector().Data, s.b.RawVector().Data, s.r.RawVector().Data)
+		ctx.X.CopyVec(&s.x)
+		s.resume = 3
+		return linsolve.ComputeResidual, nil
+	case 3:
+		s.r.CopyVec(ctx.Dst)
+		ctx.ResidualNorm = mat.Norm(&s.r, 2)
+		s.resume = 4
+		return linsolve.CheckResidualNorm, nil
+	case 4:
+		if ctx.Converged {
+			s.resume = 0
+		} else {
+			s.resume = 5
+		}
+		return linsolve.MajorIteration, nil
+
+	default:
+		panic("precond: Init not called")
+	}
+}
+
+// matches reports whether ax is the product of the stored matrix with x up to
+// rounding errors.
+func (s *stationary) matches(x, ax *mat.VecDense) bool {
+	var diff, scale float64
+	for i := 0; i < x.Len(); i++ {
+		ind, val := row(s.a, i)
+		var v, abs float64
+		for k, j := range ind {
+			v += val[k] * x.AtVec(j)
+			abs += math.Abs(val[k] * x.AtVec(j))
+		}
+		diff = math.Max(diff, math.Abs(ax.AtVec(i)-v))
+		scale = math.Max(scale, abs)
+	}
+	return diff <= mismatchTol*scale
+}
+
+// sorSweep performs a forward or a backward SOR sweep
+//
+//	x_i = (1-ω) * x_i + ω * (b_i - Σ_{j≠i} a_ij * x_j) / a_ii
+//
+// over the rows of a with the relaxation parameter omega. diag holds the
+// diagonal of a.
+func sorSweep(a *sparse.CSR, diag []float64, omega float64, x, b []float64, forward bool) {
+	n := len(x)
+	for k := 0; k < n; k++ {
+		i := k
+		if !forward {
+			i = n - 1 - k
+		}
+		ind, val := row(a, i)
+		v := b[i]
+		for q, j := range ind {
+			if j != i {
+				v -= val[q] * x[j]
+			}
+		}
+		if omega == 1 {
+			x[i] = v / diag[i]
+		} else {
+			x[i] = (1-omega)*x[i] + omega*v/diag[i]
+		}
+	}
+}
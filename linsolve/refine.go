@@ -0,0 +1,223 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"errors"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// defaultInnerTolerance is the default tolerance of Iterative32 and of the
+// inner solve in Refine. It is well above the unit roundoff of single
+// precision.
+const defaultInnerTolerance = 1e-4
+
+// ErrStagnation is returned by Refine when a refinement step does not reduce
+// the residual norm.
+var ErrStagnation = errors.New("linsolve: iterative refinement stagnated")
+
+// RefineSettings holds settings for mixed-precision iterative refinement.
+type RefineSettings struct {
+	// InitX holds the initial guess. If it is nil or empty, the zero vector
+	// will be used, otherwise its length must be equal to the dimension of
+	// the system.
+	InitX *mat.VecDense
+
+	// Dst, if not nil, will be used for storing the approximate solution,
+	// otherwise a new vector will be allocated. In both cases the vector will
+	// also be returned in RefineResult. If Dst is not empty, its length must
+	// be equal to the dimension of the system.
+	Dst *mat.VecDense
+
+	// Tolerance specifies error tolerance for the final (approximate)
+	// solution. The refinement will be stopped when
+	//  |b - A*x_k| < Tolerance * |b|
+	// where the residual b - A*x_k is computed in double precision.
+	//
+	// If Tolerance is zero, a default value of 1e-8 will be used, otherwise
+	// it must be positive and less than 1.
+	Tolerance float64
+
+	// MaxRefinements is the limit on the number of refinement steps. If it
+	// is zero, a default value of 10 will be used.
+	MaxRefinements int
+
+	// Inner holds the settings for the single precision inner solves of
+	// the correction equation. Inner.InitX and Inner.Dst must be nil. Other
+	// fields are interpreted as described in the Settings32 documentation.
+	Inner Settings32
+}
+
+// RefineResult holds the result of mixed-precision iterative refinement.
+type RefineResult struct {
+	// X is the approximate solution.
+	X *mat.VecDense
+
+	// ResidualNorm is the norm of the final residual computed in double
+	// precision.
+	ResidualNorm float64
+
+	// Refinements is the number of refinement steps. Each step performs
+	// one inner solve and one multiplication with the double precision
+	// matrix.
+	Refinements int
+
+	// Stats holds statistics about the inner solves accumulated over all
+	// refinement steps.
+	Stats Stats
+}
+
+func defaultRefineSettings(s *RefineSettings, dim int) {
+	if s.InitX != nil && s.InitX.Len() == 0 {
+		s.InitX.ReuseAsVec(dim)
+	}
+	if s.Dst == nil {
+		s.Dst = mat.NewVecDense(dim, nil)
+	} else if s.Dst.Len() == 0 {
+		s.Dst.ReuseAsVec(dim)
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = defaultTolerance
+	}
+	if s.MaxRefinements == 0 {
+		s.MaxRefinements = 10
+	}
+	if s.Inner.Work == nil {
+		// Reuse the work context in all inner solves.
+		s.Inner.Work = NewContext32(dim)
+	}
+}
+
+func checkRefineSettings(s *RefineSettings, dim int) {
+	if s.InitX != nil && s.InitX.Len() != dim {
+		panic("linsolve: mismatched length of initial guess")
+	}
+	if s.Dst.Len() != dim {
+		panic("linsolve: mismatched destination length")
+	}
+	if s.Tolerance <= 0 || 1 <= s.Tolerance {
+		panic("linsolve: invalid tolerance")
+	}
+	if s.MaxRefinements <= 0 {
+		panic("linsolve: negative refinement limit")
+	}
+	if s.Inner.InitX != nil || s.Inner.Dst != nil {
+		panic("linsolve: initial guess or destination set for inner solve")
+	}
+}
+
+// Refine finds an approximate solution of the system of n linear equations
+//
+//	A*x = b
+//
+// by mixed-precision iterative refinement. Starting from the initial guess
+// x_0, each refinement step computes the residual r_k = b - A*x_k in double
+// precision using a, approximately solves the correction equation
+//
+//	A*d_k = r_k
+//
+// in single precision with Iterative32 using aLow and the method m, and
+// updates the solution x_{k+1} = x_k + d_k in double precision.
+//
+// aLow represents A in single precision, for example a sparse.CSR32 matrix.
+// The matrix-vector products, the preconditioner solves and all vector
+// operations of the inner solve are performed in single precision, which
+// halves the memory traffic compared to a double precision solve. Since the
+// inner solve needs to reduce the residual only by a moderate factor given by
+// settings.Inner.Tolerance, the reduced accuracy of the inner solve does not
+// limit the final accuracy, which is determined by the double precision
+// residual. Refinement converges if the inner solve reduces the error in every
+// step, that is, if A is not too ill-conditioned for single precision.
+//
+// If a refinement step does not decrease the residual norm, Refine returns
+// ErrStagnation and the approximate solution before that step. If the
+// tolerance is not reached in settings.MaxRefinements steps, Refine returns
+// ErrIterationLimit. An ErrIterationLimit from an inner solve is not an error,
+// the partial correction is used. Other errors from the inner solve are
+// returned.
+//
+// settings provide means for adjusting parameters of the refinement. See the
+// RefineSettings documentation for more information. Refine will not modify the
+// fields of RefineSettings. If settings is nil, default settings will be used.
+// If m is nil, default GMRES32 will be used for the inner solves.
+func Refine(a MulVecToer, aLow MulVecToer32, b *mat.VecDense, m Method32, settings *RefineSettings) (*RefineResult, error) {
+	n := b.Len()
+
+	var s RefineSettings
+	if settings != nil {
+		s = *settings
+	}
+	defaultRefineSettings(&s, n)
+	checkRefineSettings(&s, n)
+
+	if m == nil {
+		m = &GMRES32{}
+	}
+
+	bNorm := mat.Norm(b, 2)
+	if bNorm == 0 {
+		bNorm = 1
+	}
+
+	var discard Stats
+	x := s.Dst
+	r := mat.NewVecDense(n, nil)
+	if s.InitX != nil {
+		x.CopyVec(s.InitX)
+		computeResidual(r, a, b, x, &discard)
+	} else {
+		x.Zero()
+		r.CopyVec(b)
+	}
+	result := &RefineResult{
+		X:            x,
+		ResidualNorm: mat.Norm(r, 2),
+	}
+
+	inner := s.Inner
+	r32 := make([]float32, n)
+	d32 := make([]float32, n)
+	inner.Dst = d32
+	xNew := mat.NewVecDense(n, nil)
+	for {
+		if result.ResidualNorm < s.Tolerance*bNorm {
+			return result, nil
+		}
+		if result.Refinements == s.MaxRefinements {
+			return result, ErrIterationLimit
+		}
+
+		// Solve the correction equation A*d = r in single precision.
+		// The residual is scaled to unit norm to avoid underflow in
+		// single precision.
+		for i, v := range r.RawVector().Data {
+			r32[i] = float32(v / result.ResidualNorm)
+		}
+		res, err := Iterative32(aLow, r32, m, &inner)
+		result.Refinements++
+		result.Stats.Iterations += res.Stats.Iterations
+		result.Stats.MulVec += res.Stats.MulVec
+		result.Stats.PreconSolve += res.Stats.PreconSolve
+		if err != nil && err != ErrIterationLimit {
+			return result, err
+		}
+
+		// Update the solution and compute the residual in double
+		// precision.
+		xNew.CopyVec(x)
+		xd := xNew.RawVector().Data
+		for i, v := range d32 {
+			xd[i] += result.ResidualNorm * float64(v)
+		}
+		computeResidual(r, a, b, xNew, &discard)
+		rNorm := mat.Norm(r, 2)
+		if rNorm >= result.ResidualNorm {
+			return result, ErrStagnation
+		}
+		x.CopyVec(xNew)
+		result.ResidualNorm = rNorm
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+// MulVecToer32 represents a square matrix A by means of a matrix-vector
+// multiplication in single precision.
+type MulVecToer32 interface {
+	// MulVecTo32 computes A*x or Aᵀ*x and stores the result into dst.
+	// x and dst are vectors of length n.
+	MulVecTo32(dst []float32, trans bool, x []float32)
+}
+
+// Method32 is an iterative method that produces a sequence of single precision
+// vectors that converge to the solution of the system of linear equations
+//
+//	A * x = b,
+//
+// where A is non-singular n×n matrix, and x and b are vectors of dimension n.
+// All vectors and vector operations of Method32 are in single precision, so it
+// needs half the memory traffic of the corresponding Method.
+//
+// Method32 uses the same reverse-communication interface as Method. See the
+// documentation for Method, Operation and Context32 for more information.
+type Method32 interface {
+	// Init initializes the method for solving an n×n
+	// linear system with an initial estimate x and
+	// the corresponding residual vector.
+	//
+	// Method32 will not retain x or residual.
+	Init(x, residual []float32)
+
+	// Iterate performs a step in converging to the
+	// solution of a linear system.
+	//
+	// Iterate retrieves data from Context32, updates it,
+	// and returns the next operation. The caller must
+	// perform the Operation using data in Context32, and
+	// depending on the state call Iterate again.
+	Iterate(*Context32) (Operation, error)
+}
+
+// Context32 mediates the communication between the Method32 and the caller.
+// The caller must not modify Context32 apart from the commanded Operations.
+type Context32 struct {
+	// X will be set by Method32 to the current approximate
+	// solution when it commands ComputeResidual and MajorIteration.
+	X []float32
+
+	// ResidualNorm is (an estimate of) a norm of
+	// the residual. Method32 will set it to the current
+	// value when it commands CheckResidualNorm.
+	ResidualNorm float64
+
+	// Converged indicates to Method32 whether ResidualNorm
+	// satisfies a stopping criterion as a result of
+	// CheckResidualNorm operation.
+	Converged bool
+
+	// Src and Dst are the source and destination vectors
+	// for various Operations. Src will be set by Method32
+	// and the caller must store the result in Dst. See
+	// the Operation documentation for more information.
+	Src, Dst []float32
+}
+
+// NewContext32 returns a new Context32 for work on problems of dimension n.
+// NewContext32 will panic if n is not positive.
+func NewContext32(n int) *Context32 {
+	if n <= 0 {
+		panic("linsolve: context size is not positive")
+	}
+	return &Context32{
+		X:   make([]float32, n),
+		Src: make([]float32, n),
+		Dst: make([]float32, n),
+	}
+}
+
+// Reset reinitializes the Context32 for work on problems of dimension n.
+// Reset will panic if n is not positive.
+func (ctx *Context32) Reset(n int) {
+	if n <= 0 {
+		panic("linsolve: dimension not positive")
+	}
+	ctx.X = sresize(ctx.X, n)
+	ctx.Src = sresize(ctx.Src, n)
+	ctx.Dst = sresize(ctx.Dst, n)
+}
+
+// Settings32 holds settings for solving a linear system in single precision.
+type Settings32 struct {
+	// InitX holds the initial guess. If it is nil, the zero vector
+	// will be used, otherwise its length must be equal to the dimension of
+	// the system.
+	InitX []float32
+
+	// Dst, if not nil, will be used for storing the approximate solution,
+	// otherwise a new vector will be allocated. In both cases the vector will
+	// also be returned in Result32. If Dst is not nil, its length must be
+	// equal to the dimension of the system.
+	Dst []float32
+
+	// Tolerance specifies error tolerance for the final (approximate)
+	// solution produced by the iterative method. The iteration will be
+	// stopped when
+	//  |r_i| < Tolerance * |b|
+	// where r_i is the residual at i-th iteration.
+	//
+	// If Tolerance is zero, a default value of 1e-4 will be used, otherwise
+	// it must be positive and less than 1. Tolerances close to the unit
+	// roundoff of single precision may not be attainable.
+	Tolerance float64
+
+	// MaxIterations is the limit on the number of iterations. If it is
+	// zero, a default value of four times the dimension of the system will
+	// be used.
+	MaxIterations int
+
+	// PreconSolve describes a preconditioner solve that stores into dst the
+	// solution of the system
+	//  M  * dst = rhs, or
+	//  Mᵀ * dst = rhs,
+	// where M is the preconditioning matrix. If PreconSolve is nil, no
+	// preconditioning will be used (M is the identity).
+	PreconSolve func(dst []float32, trans bool, rhs []float32) error
+
+	// Work context can be provided to reduce memory allocation when solving
+	// multiple linear systems. If Work is not nil, the length of its vectors
+	// must be equal to the dimension of the system.
+	Work *Context32
+}
+
+// Result32 holds the result of an iterative solve in single precision.
+type Result32 struct {
+	// X is the approximate solution.
+	X []float32
+
+	// ResidualNorm is an approximation to the norm of the final residual.
+	ResidualNorm float64
+
+	// Stats holds statistics about the iterative solve.
+	Stats Stats
+}
+
+func defaultSettings32(s *Settings32, dim int) {
+	if s.Dst == nil {
+		s.Dst = make([]float32, dim)
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = defaultInnerTolerance
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 4 * dim
+	}
+	if s.PreconSolve == nil {
+		s.PreconSolve = NoPreconditioner32
+	}
+	if s.Work == nil {
+		s.Work = NewContext32(dim)
+	}
+}
+
+func checkSettings32(s *Settings32, dim int) {
+	if s.InitX != nil && len(s.InitX) != dim {
+		panic("linsolve: mismatched length of initial guess")
+	}
+	if len(s.Dst) != dim {
+		panic("linsolve: mismatched destination length")
+	}
+	if s.Tolerance <= 0 || 1 <= s.Tolerance {
+		panic("linsolve: invalid tolerance")
+	}
+	if s.MaxIterations <= 0 {
+		panic("linsolve: negative iteration limit")
+	}
+	w := s.Work
+	if len(w.X) != dim || len(w.Src) != dim || len(w.Dst) != dim {
+		panic("linsolve: mismatched work context length")
+	}
+}
+
+// Iterative32 finds an approximate solution of the system of n linear
+// equations
+//
+//	A*x = b,
+//
+// where A is a nonsingular square matrix of order n and b is the right-hand
+// side vector, using an iterative method m in single precision. If m is nil,
+// default GMRES32 will be used.
+//
+// Iterative32 is intended for the inner solves of mixed-precision iterative
+// refinement with Refine, which corrects the solution in double precision.
+//
+// settings provide means for adjusting parameters of the iterative process. See
+// the Settings32 documentation for more information. Iterative32 will not
+// modify the fields of Settings32. If settings is nil, default settings will be
+// used.
+func Iterative32(a MulVecToer32, b []float32, m Method32, settings *Settings32) (*Result32, error) {
+	n := len(b)
+
+	var s Settings32
+	if settings != nil {
+		s = *settings
+	}
+	defaultSettings32(&s, n)
+	checkSettings32(&s, n)
+
+	var stats Stats
+	work := s.Work
+	rInit := make([]float32, n)
+	if s.InitX != nil {
+		// Initial x is provided.
+		copy(work.X, s.InitX)
+		computeResidual32(rInit, a, b, work.X, &stats)
+	} else {
+		// Initial x is the zero vector.
+		clear(work.X)
+		// Residual b-A*x is then equal to b.
+		copy(rInit, b)
+	}
+
+	if m == nil {
+		m = &GMRES32{}
+	}
+
+	var err error
+	work.ResidualNorm = snrm2(rInit)
+	if work.ResidualNorm >= s.Tolerance {
+		err = iterate32(a, b, rInit, s, m, &stats)
+	} else {
+		copy(s.Dst, work.X)
+	}
+
+	return &Result32{
+		X:            s.Dst,
+		ResidualNorm: work.ResidualNorm,
+		Stats:        stats,
+	}, err
+}
+
+func iterate32(a MulVecToer32, b, initRes []float32, settings Settings32, method Method32, stats *Stats) error {
+	bNorm := snrm2(b)
+	if bNorm == 0 {
+		bNorm = 1
+	}
+
+	work := settings.Work
+	copy(settings.Dst, work.X)
+
+	method.Init(work.X, initRes)
+	for {
+		op, err := method.Iterate(work)
+		if err != nil {
+			return err
+		}
+		switch op {
+		case NoOperation:
+		case MulVec, MulVec | Trans:
+			stats.MulVec++
+			a.MulVecTo32(work.Dst, op&Trans == Trans, work.Src)
+		case PreconSolve, PreconSolve | Trans:
+			stats.PreconSolve++
+			err = settings.PreconSolve(work.Dst, op&Trans == Trans, work.Src)
+			if err != nil {
+				return err
+			}
+		case CheckResidualNorm:
+			work.Converged = work.ResidualNorm < settings.Tolerance*bNorm
+		case ComputeResidual:
+			computeResidual32(work.Dst, a, b, work.X, stats)
+		case MajorIteration:
+			stats.Iterations++
+			if work.Converged {
+				copy(settings.Dst, work.X)
+				return nil
+			}
+			if stats.Iterations == settings.MaxIterations {
+				copy(settings.Dst, work.X)
+				return ErrIterationLimit
+			}
+		default:
+			panic("linsolve: invalid operation")
+		}
+	}
+}
+
+// NoPreconditioner32 implements the identity preconditioner for systems in
+// single precision.
+func NoPreconditioner32(dst []float32, trans bool, rhs []float32) error {
+	if len(dst) != len(rhs) {
+		panic("linsolve: mismatched vector length")
+	}
+	copy(dst, rhs)
+	return nil
+}
+
+func computeResidual32(dst []float32, a MulVecToer32, b, x []float32, stats *Stats) {
+	stats.MulVec++
+	a.MulVecTo32(dst, false, x)
+	for i, v := range b {
+		dst[i] = v - dst[i]
+	}
+}
+
+func blas32Vec(x []float32) blas32.Vector {
+	return blas32.Vector{N: len(x), Inc: 1, Data: x}
+}
+
+// sdot returns xᵀ * y.
+func sdot(x, y []float32) float32 {
+	return blas32.Dot(blas32Vec(x), blas32Vec(y))
+}
+
+// snrm2 returns the Euclidean norm of x.
+func snrm2(x []float32) float64 {
+	return float64(blas32.Nrm2(blas32Vec(x)))
+}
+
+// saxpy computes y += alpha * x.
+func saxpy(alpha float32, x, y []float32) {
+	blas32.Axpy(alpha, blas32Vec(x), blas32Vec(y))
+}
+
+// sresize returns s resized to length n reusing its storage if possible. The
+// elements of the returned slice are zero.
+func sresize(s []float32, n int) []float32 {
+	if cap(s) < n {
+		return make([]float32, n)
+	}
+	s = s[:n]
+	clear(s)
+	return s
+}
+
+// sscal computes x *= alpha.
+func sscal(alpha float32, x []float32) {
+	blas32.Scal(alpha, blas32Vec(x))
+}
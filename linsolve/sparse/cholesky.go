@@ -0,0 +1,349 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// SymbolicCholesky is the symbolic analysis of the sparse Cholesky
+// factorization of a symmetric matrix. It consists of the fill-reducing
+// permutation, the elimination tree and the number of elements in each column
+// of the Cholesky factor. The symbolic analysis depends only on the pattern of
+// the matrix, so it can be computed once and reused for factorizing several
+// matrices with the same pattern.
+type SymbolicCholesky struct {
+	n int
+	// perm[k] is the row and column of A that is the k-th row and column
+	// of P*A*Pᵀ, iperm is the inverse of perm.
+	perm, iperm []int
+	// parent is the elimination tree of P*A*Pᵀ.
+	parent []int
+	// colptr holds the column pointers of L.
+	colptr []int
+}
+
+// NewSymbolicCholesky returns the symbolic analysis of the Cholesky
+// factorization of the symmetric n×n matrix a with the fill-reducing ordering
+// ord. Only the pattern of the upper triangular part of a is referenced.
+// NewSymbolicCholesky panics if a is not square.
+func NewSymbolicCholesky(a mat.Matrix, ord Ordering) *SymbolicCholesky {
+	c := columns(a)
+	n := c.major
+	perm := ord.permutation(&c)
+	iperm := inversePerm(perm)
+	u := permuteUpper(&c, iperm)
+	parent := etree(&u)
+
+	// Count the elements in each column of L by computing the pattern of
+	// each of its rows.
+	colptr := make([]int, n+1)
+	s := make([]int, n)
+	mark := make([]int, n)
+	for k := range mark {
+		mark[k] = -1
+	}
+	for k := 0; k < n; k++ {
+		colptr[k+1]++ // The diagonal element.
+		for _, j := range s[ereach(&u, k, parent, s, mark):] {
+			colptr[j+1]++
+		}
+	}
+	for k := 0; k < n; k++ {
+		colptr[k+1] += colptr[k]
+	}
+
+	return &SymbolicCholesky{
+		n:      n,
+		perm:   perm,
+		iperm:  iperm,
+		parent: parent,
+		colptr: colptr,
+	}
+}
+
+// NNZ returns the number of elements in the Cholesky factor L.
+func (s *SymbolicCholesky) NNZ() int {
+	return s.colptr[s.n]
+}
+
+// permuteUpper returns the upper triangular part of P*A*Pᵀ in compressed
+// sparse column format, where the symmetric matrix A is given by the upper
+// triangular part of a in compressed sparse column format and P by the
+// inverse permutation iperm. Unlike in other compressed matrices, the row
+// indices within a column of the result are not sorted.
+func permuteUpper(a *compressed, iperm []int) compressed {
+	n := a.major
+	indptr := make([]int, n+1)
+	for j := 0; j < n; j++ {
+		for p := a.indptr[j]; p < a.indptr[j+1]; p++ {
+			if i := a.ind[p]; i <= j {
+				indptr[max(iperm[i], iperm[j])+1]++
+			}
+		}
+	}
+	for j := 0; j < n; j++ {
+		indptr[j+1] += indptr[j]
+	}
+	next := make([]int, n)
+	copy(next, indptr)
+	ind := make([]int, indptr[n])
+	data := make([]float64, indptr[n])
+	for j := 0; j < n; j++ {
+		for p := a.indptr[j]; p < a.indptr[j+1]; p++ {
+			i := a.ind[p]
+			if i > j {
+				continue
+			}
+			pi, pj := iperm[i], iperm[j]
+			if pi > pj {
+				pi, pj = pj, pi
+			}
+			q := next[pj]
+			next[pj]++
+			ind[q] = pi
+			data[q] = a.data[p]
+		}
+	}
+	return compressed{
+		major:  n,
+		minor:  n,
+		indptr: indptr,
+		ind:    ind,
+		data:   data,
+	}
+}
+
+// etree returns the elimination tree of the symmetric matrix given by its upper
+// triangular part u in compressed sparse column format. parent[j] is the parent
+// of the node j, or -1 if j is a root.
+func etree(u *compressed) []int {
+	n := u.major
+	parent := make([]int, n)
+	// ancestor holds the path-compressed ancestors of the nodes.
+	ancestor := make([]int, n)
+	for k := 0; k < n; k++ {
+		parent[k] = -1
+		ancestor[k] = -1
+		for p := u.indptr[k]; p < u.indptr[k+1]; p++ {
+			for i := u.ind[p]; i != -1 && i < k; {
+				next := ancestor[i]
+				ancestor[i] = k
+				if next == -1 {
+					parent[i] = k
+				}
+				i = next
+			}
+		}
+	}
+	return parent
+}
+
+// ereach computes the pattern of the k-th row of the Cholesky factor L of the
+// symmetric matrix given by its upper triangular part u, that is, the columns
+// j < k for which L[k,j] is not zero. The pattern is stored in s[top:] in
+// topological order of the elimination tree and top is returned. mark must not
+// hold k for any node, it is set to k for the nodes in the pattern and for k.
+//
+// ereach panics if the pattern of u is not consistent with the elimination
+// tree given by parent.
+func ereach(u *compressed, k int, parent, s, mark []int) (top int) {
+	top = len(s)
+	mark[k] = k
+	for p := u.indptr[k]; p < u.indptr[k+1]; p++ {
+		// Follow the path from i to the root of the row subtree and
+		// stop at a marked node.
+		var n int
+		for i := u.ind[p]; ; i = parent[i] {
+			if i == -1 || i > k {
+				panic("sparse: matrix pattern does not match symbolic analysis")
+			}
+			if mark[i] == k {
+				break
+			}
+			s[n] = i
+			n++
+			mark[i] = k
+		}
+		for n > 0 {
+			top--
+			n--
+			s[top] = s[n]
+		}
+	}
+	return top
+}
+
+// Cholesky is the sparse Cholesky factorization
+//
+//	P*A*Pᵀ = L*Lᵀ
+//
+// of a symmetric positive definite matrix A, where P is a fill-reducing
+// permutation and L is lower triangular.
+//
+// The factorization is split into two phases. The symbolic analysis computed
+// by NewSymbolicCholesky depends only on the pattern of A and determines the
+// pattern of L. The numeric factorization computed by Factorize uses the
+// symbolic analysis, so factorizing a sequence of matrices with the same
+// pattern, for example in time stepping or in Newton's method, requires only
+// one symbolic analysis.
+//
+// A factorization can be reused for many solves with SolveVecTo. Its
+// PreconSolve method has the signature of linsolve.Settings.PreconSolve, so the
+// factorization of a nearby matrix, for example from a previous time step, can
+// be used as the preconditioner of an iterative method.
+//
+// References:
+//   - Davis, T. A. (2006). Chapter 4 Cholesky factorization. In Direct Methods
+//     for Sparse Linear Systems (pp. 37-68). Philadelphia, PA: SIAM.
+type Cholesky struct {
+	sym *SymbolicCholesky
+	// l is the factor L in compressed sparse column format with the
+	// diagonal element stored first in each column.
+	l compressed
+
+	work, y []float64
+}
+
+// Factorize computes the Cholesky factorization of the symmetric n×n matrix a
+// using the symbolic analysis sym. Only the upper triangular part of a is
+// referenced. If sym is nil, the symbolic analysis with the AMD ordering will
+// be computed. The pattern of a must be the same as or a subset of the pattern
+// of the matrix that sym has been computed from, otherwise Factorize may panic.
+//
+// Factorize returns ErrNotPositiveDefinite if a is not positive definite. In
+// that case, or if Factorize panics, the receiver does not hold a valid
+// factorization.
+func (c *Cholesky) Factorize(a mat.Matrix, sym *SymbolicCholesky) error {
+	c.sym = nil
+	if sym == nil {
+		sym = NewSymbolicCholesky(a, AMD)
+	}
+	n := sym.n
+	if r, _ := a.Dims(); r != n {
+		panic("sparse: dimension mismatch")
+	}
+	ac := columns(a)
+	u := permuteUpper(&ac, sym.iperm)
+
+	nnz := sym.colptr[n]
+	l := &c.l
+	l.major, l.minor = n, n
+	l.indptr = append(l.indptr[:0], sym.colptr...)
+	l.ind = reuseInts(l.ind, nnz)
+	l.data = reuseFloats(l.data, nnz)
+	// next[j] is the position of the next element in the j-th column.
+	next := make([]int, n)
+	copy(next, l.indptr)
+
+	x := reuseFloats(c.work, n)
+	for i := range x {
+		x[i] = 0
+	}
+	s := make([]int, n)
+	mark := make([]int, n)
+	for k := range mark {
+		mark[k] = -1
+	}
+	for k := 0; k < n; k++ {
+		// Solve the triangular system L[:k,:k] * y = A[:k,k] for the
+		// k-th row y of L, whose pattern is given by ereach.
+		top := ereach(&u, k, sym.parent, s, mark)
+		for p := u.indptr[k]; p < u.indptr[k+1]; p++ {
+			x[u.ind[p]] = u.data[p]
+		}
+		d := x[k]
+		x[k] = 0
+		for _, i := range s[top:] {
+			lki := x[i] / l.data[l.indptr[i]]
+			x[i] = 0
+			for p := l.indptr[i] + 1; p < next[i]; p++ {
+				x[l.ind[p]] -= l.data[p] * lki
+			}
+			d -= lki * lki
+			p := next[i]
+			if p == l.indptr[i+1] {
+				panic("sparse: matrix pattern does not match symbolic analysis")
+			}
+			next[i]++
+			l.ind[p] = k
+			l.data[p] = lki
+		}
+		if d <= 0 || math.IsNaN(d) {
+			return ErrNotPositiveDefinite
+		}
+		p := next[k]
+		next[k]++
+		l.ind[p] = k
+		l.data[p] = math.Sqrt(d)
+	}
+
+	// If the pattern of a is a proper subset of the analyzed pattern, some
+	// columns of L have fewer elements than reserved.
+	var q int
+	for j := 0; j < n; j++ {
+		start := l.indptr[j]
+		l.indptr[j] = q
+		q += copy(l.ind[q:], l.ind[start:next[j]])
+		copy(l.data[l.indptr[j]:], l.data[start:next[j]])
+	}
+	l.indptr[n] = q
+	l.ind = l.ind[:q]
+	l.data = l.data[:q]
+
+	c.work = x
+	c.y = reuseFloats(c.y, n)
+	c.sym = sym
+	return nil
+}
+
+// SolveVecTo solves A * dst = b. If dst is empty, it will be resized to the
+// length of b. SolveVecTo panics if the receiver does not hold a valid
+// factorization.
+func (c *Cholesky) SolveVecTo(dst *mat.VecDense, b mat.Vector) {
+	if c.sym == nil {
+		panic("sparse: factorization not computed")
+	}
+	perm := c.sym.perm
+	solveVec(dst, b, c.sym.n, c.work, func(x []float64) {
+		// P*A*Pᵀ * P*dst = P*b
+		y := c.y
+		for k, i := range perm {
+			y[k] = x[i]
+		}
+		lowerSolve(&c.l, y)
+		lowerTransSolve(&c.l, y)
+		for k, i := range perm {
+			x[i] = y[k]
+		}
+	})
+}
+
+// PreconSolve solves A * dst = rhs. Since A is symmetric, the value of trans
+// is ignored. If dst is empty, it will be resized to the length of rhs.
+func (c *Cholesky) PreconSolve(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+	c.SolveVecTo(dst, rhs)
+	return nil
+}
+
+// reuseInts returns a slice of length n that reuses the backing array of s if
+// its capacity is sufficient.
+func reuseInts(s []int, n int) []int {
+	if cap(s) < n {
+		return make([]int, n)
+	}
+	return s[:n]
+}
+
+// reuseFloats returns a slice of length n that reuses the backing array of s
+// if its capacity is sufficient.
+func reuseFloats(s []float64, n int) []float64 {
+	if cap(s) < n {
+		return make([]float64, n)
+	}
+	return s[:n]
+}
@@ -0,0 +1,208 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import "gonum.org/v1/gonum/mat"
+
+var (
+	_ mat.Matrix      = (*COO)(nil)
+	_ mat.NonZeroDoer = (*COO)(nil)
+)
+
+// COO is a sparse matrix in coordinate format, also known as the triplet
+// format. It stores a list of elements with their row and column indices and
+// is used mainly for assembling a matrix. The list may contain several
+// elements at the same position, their values are then summed.
+type COO struct {
+	r, c int
+	row  []int
+	col  []int
+	data []float64
+}
+
+// NewCOO returns a new r×c sparse matrix in coordinate format without any
+// stored elements. NewCOO panics if r or c is not positive.
+func NewCOO(r, c int) *COO {
+	if r <= 0 || c <= 0 {
+		panic("sparse: invalid shape")
+	}
+	return &COO{r: r, c: c}
+}
+
+// Dims returns the dimensions of the matrix.
+func (m *COO) Dims() (r, c int) {
+	return m.r, m.c
+}
+
+// At returns the element of m at row i and column j. At sums the values of
+// all stored elements at the position and its cost is thus proportional to
+// the number of stored elements.
+func (m *COO) At(i, j int) float64 {
+	if i < 0 || m.r <= i {
+		panic(mat.ErrRowAccess)
+	}
+	if j < 0 || m.c <= j {
+		panic(mat.ErrColAccess)
+	}
+	var v float64
+	for k, v2 := range m.data {
+		if m.row[k] == i && m.col[k] == j {
+			v += v2
+		}
+	}
+	return v
+}
+
+// T returns the transpose of m.
+func (m *COO) T() mat.Matrix {
+	return mat.Transpose{Matrix: m}
+}
+
+// NNZ returns the number of stored elements of m, including multiple elements
+// at the same position.
+func (m *COO) NNZ() int {
+	return len(m.data)
+}
+
+// Append appends an element with the value v at row i and column j to the
+// list of elements of m. If there already is an element at the position, v
+// will be added to its value. Zero values are not stored.
+func (m *COO) Append(i, j int, v float64) {
+	if i < 0 || m.r <= i {
+		panic(mat.ErrRowAccess)
+	}
+	if j < 0 || m.c <= j {
+		panic(mat.ErrColAccess)
+	}
+	if v == 0 {
+		return
+	}
+	m.row = append(m.row, i)
+	m.col = append(m.col, j)
+	m.data = append(m.data, v)
+}
+
+// DoNonZero calls the function fn for each of the stored elements of m in
+// the order in which they were appended.
+func (m *COO) DoNonZero(fn func(i, j int, v float64)) {
+	for k, v := range m.data {
+		fn(m.row[k], m.col[k], v)
+	}
+}
+
+// MulVecTo computes A*x or Aᵀ*x and stores the result into dst. If dst is
+// empty, it will be resized to the correct length. dst must not be x.
+func (m *COO) MulVecTo(dst *mat.VecDense, trans bool, x mat.Vector) {
+	r, c := m.r, m.c
+	row, col := m.row, m.col
+	if trans {
+		r, c = c, r
+		row, col = col, row
+	}
+	if x.Len() != c {
+		panic("sparse: dimension mismatch")
+	}
+	if dst.IsEmpty() {
+		dst.ReuseAsVec(r)
+	} else if dst.Len() != r {
+		panic("sparse: dimension mismatch")
+	}
+	if xv, ok := x.(*mat.VecDense); ok && xv == dst {
+		panic("sparse: destination aliases x")
+	}
+	xd, xinc := vectorData(x)
+	dv := dst.RawVector()
+	for i := 0; i < r; i++ {
+		dv.Data[i*dv.Inc] = 0
+	}
+	for k, v := range m.data {
+		dv.Data[row[k]*dv.Inc] += v * xd[col[k]*xinc]
+	}
+}
+
+// ToCSR returns m in compressed sparse row format. Elements at the same
+// position are summed and elements whose sum is zero are not stored.
+func (m *COO) ToCSR() *CSR {
+	return &CSR{s: compress(m.r, m.c, m.row, m.col, m.data)}
+}
+
+// ToCSC returns m in compressed sparse column format. Elements at the same
+// position are summed and elements whose sum is zero are not stored.
+func (m *COO) ToCSC() *CSC {
+	return &CSC{s: compress(m.c, m.r, m.col, m.row, m.data)}
+}
+
+// compress returns the compressed representation of the elements with the
+// given major and minor indices. Elements at the same position are summed
+// and elements whose sum is zero are dropped.
+func compress(nmajor, nminor int, major, minor []int, data []float64) compressed {
+	nnz := len(data)
+
+	// Sort the elements by the minor index using counting sort.
+	ptr := make([]int, nminor+1)
+	for _, l := range minor {
+		ptr[l+1]++
+	}
+	for l := 0; l < nminor; l++ {
+		ptr[l+1] += ptr[l]
+	}
+	byMinor := make([]int, nnz)
+	for k, l := range minor {
+		byMinor[ptr[l]] = k
+		ptr[l]++
+	}
+
+	// Stable counting sort by the major index. Within each major vector the
+	// elements are then sorted by the minor index.
+	indptr := make([]int, nmajor+1)
+	for _, k := range major {
+		indptr[k+1]++
+	}
+	for k := 0; k < nmajor; k++ {
+		indptr[k+1] += indptr[k]
+	}
+	next := make([]int, nmajor)
+	copy(next, indptr)
+	order := make([]int, nnz)
+	for _, e := range byMinor {
+		k := major[e]
+		order[next[k]] = e
+		next[k]++
+	}
+
+	// Sum the elements at the same position.
+	m := compressed{
+		major:  nmajor,
+		minor:  nminor,
+		indptr: make([]int, nmajor+1),
+		ind:    make([]int, 0, nnz),
+		data:   make([]float64, 0, nnz),
+	}
+	for k := 0; k < nmajor; k++ {
+		start := len(m.ind)
+		for p := indptr[k]; p < indptr[k+1]; p++ {
+			e := order[p]
+			if len(m.ind) > start && m.ind[len(m.ind)-1] == minor[e] {
+				m.data[len(m.data)-1] += data[e]
+				continue
+			}
+			m.ind = append(m.ind, minor[e])
+			m.data = append(m.data, data[e])
+		}
+		// Drop the elements that summed to zero.
+		q := start
+		for p := start; p < len(m.ind); p++ {
+			if m.data[p] != 0 {
+				m.ind[q] = m.ind[p]
+				m.data[q] = m.data[p]
+				q++
+			}
+		}
+		m.ind = m.ind[:q]
+		m.data = m.data[:q]
+		m.indptr[k+1] = q
+	}
+	return m
+}
@@ -0,0 +1,100 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import "gonum.org/v1/gonum/mat"
+
+var (
+	_ mat.Matrix         = (*CSC)(nil)
+	_ mat.NonZeroDoer    = (*CSC)(nil)
+	_ mat.ColNonZeroDoer = (*CSC)(nil)
+)
+
+// CSC is a sparse matrix in compressed sparse column format.
+type CSC struct {
+	// s holds the transpose of the matrix in compressed row format.
+	s compressed
+}
+
+// NewCSC returns a new r×c sparse matrix in compressed sparse column format.
+// The row indices of the elements in the j-th column are stored in
+// ind[indptr[j]:indptr[j+1]] and the corresponding values in
+// data[indptr[j]:indptr[j+1]]. The length of indptr must be c+1 with
+// indptr[0] equal to zero, and the row indices within each column must be
+// strictly increasing. NewCSC panics if any of these conditions is violated.
+//
+// The slices are used as the backing data of the matrix, so changes to their
+// elements will be reflected in the matrix and vice versa.
+func NewCSC(r, c int, indptr, ind []int, data []float64) *CSC {
+	return &CSC{s: newCompressed(c, r, indptr, ind, data)}
+}
+
+// Dims returns the dimensions of the matrix.
+func (m *CSC) Dims() (r, c int) {
+	return m.s.minor, m.s.major
+}
+
+// At returns the element of m at row i and column j. The cost of At is
+// logarithmic in the number of stored elements in the j-th column.
+func (m *CSC) At(i, j int) float64 {
+	if i < 0 || m.s.minor <= i {
+		panic(mat.ErrRowAccess)
+	}
+	if j < 0 || m.s.major <= j {
+		panic(mat.ErrColAccess)
+	}
+	return m.s.at(j, i)
+}
+
+// T returns the transpose of m as a CSR matrix which shares the backing data
+// with m.
+func (m *CSC) T() mat.Matrix {
+	return &CSR{s: m.s}
+}
+
+// NNZ returns the number of stored elements of m.
+func (m *CSC) NNZ() int {
+	return len(m.s.data)
+}
+
+// RawCSC returns the backing data of m. See NewCSC for the description of the
+// returned slices.
+func (m *CSC) RawCSC() (indptr, ind []int, data []float64) {
+	return m.s.indptr, m.s.ind, m.s.data
+}
+
+// DoNonZero calls the function fn for each of the stored elements of m in
+// column-major order.
+func (m *CSC) DoNonZero(fn func(i, j int, v float64)) {
+	for j := 0; j < m.s.major; j++ {
+		m.s.doMajor(j, func(j, i int, v float64) { fn(i, j, v) })
+	}
+}
+
+// DoColNonZero calls the function fn for each of the stored elements of the
+// j-th column of m in increasing order of the row index.
+func (m *CSC) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if j < 0 || m.s.major <= j {
+		panic(mat.ErrColAccess)
+	}
+	m.s.doMajor(j, func(j, i int, v float64) { fn(i, j, v) })
+}
+
+// MulVecTo computes A*x or Aᵀ*x and stores the result into dst. If dst is
+// empty, it will be resized to the correct length. dst must not be x.
+func (m *CSC) MulVecTo(dst *mat.VecDense, trans bool, x mat.Vector) {
+	m.s.mulVec(dst, !trans, x)
+}
+
+// MulMatTo computes A*x or Aᵀ*x and stores the result into dst. If dst is
+// empty, it will be resized to the correct size. dst must not be x.
+func (m *CSC) MulMatTo(dst *mat.Dense, trans bool, x mat.Matrix) {
+	m.s.mulMat(dst, !trans, x)
+}
+
+// ToCSR returns a copy of m in compressed sparse row format.
+func (m *CSC) ToCSR() *CSR {
+	return &CSR{s: m.s.transpose()}
+}
@@ -0,0 +1,99 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import "gonum.org/v1/gonum/mat"
+
+var (
+	_ mat.Matrix         = (*CSR)(nil)
+	_ mat.NonZeroDoer    = (*CSR)(nil)
+	_ mat.RowNonZeroDoer = (*CSR)(nil)
+)
+
+// CSR is a sparse matrix in compressed sparse row format.
+type CSR struct {
+	s compressed
+}
+
+// NewCSR returns a new r×c sparse matrix in compressed sparse row format. The
+// column indices of the elements in the i-th row are stored in
+// ind[indptr[i]:indptr[i+1]] and the corresponding values in
+// data[indptr[i]:indptr[i+1]]. The length of indptr must be r+1 with
+// indptr[0] equal to zero, and the column indices within each row must be
+// strictly increasing. NewCSR panics if any of these conditions is violated.
+//
+// The slices are used as the backing data of the matrix, so changes to their
+// elements will be reflected in the matrix and vice versa.
+func NewCSR(r, c int, indptr, ind []int, data []float64) *CSR {
+	return &CSR{s: newCompressed(r, c, indptr, ind, data)}
+}
+
+// Dims returns the dimensions of the matrix.
+func (m *CSR) Dims() (r, c int) {
+	return m.s.major, m.s.minor
+}
+
+// At returns the element of m at row i and column j. The cost of At is
+// logarithmic in the number of stored elements in the i-th row.
+func (m *CSR) At(i, j int) float64 {
+	if i < 0 || m.s.major <= i {
+		panic(mat.ErrRowAccess)
+	}
+	if j < 0 || m.s.minor <= j {
+		panic(mat.ErrColAccess)
+	}
+	return m.s.at(i, j)
+}
+
+// T returns the transpose of m as a CSC matrix which shares the backing data
+// with m.
+func (m *CSR) T() mat.Matrix {
+	return &CSC{s: m.s}
+}
+
+// NNZ returns the number of stored elements of m.
+func (m *CSR) NNZ() int {
+	return len(m.s.data)
+}
+
+// RawCSR returns the backing data of m. See NewCSR for the description of the
+// returned slices.
+func (m *CSR) RawCSR() (indptr, ind []int, data []float64) {
+	return m.s.indptr, m.s.ind, m.s.data
+}
+
+// DoNonZero calls the function fn for each of the stored elements of m in
+// row-major order.
+func (m *CSR) DoNonZero(fn func(i, j int, v float64)) {
+	for i := 0; i < m.s.major; i++ {
+		m.s.doMajor(i, fn)
+	}
+}
+
+// DoRowNonZero calls the function fn for each of the stored elements of the
+// i-th row of m in increasing order of the column index.
+func (m *CSR) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if i < 0 || m.s.major <= i {
+		panic(mat.ErrRowAccess)
+	}
+	m.s.doMajor(i, fn)
+}
+
+// MulVecTo computes A*x or Aᵀ*x and stores the result into dst. If dst is
+// empty, it will be resized to the correct length. dst must not be x.
+func (m *CSR) MulVecTo(dst *mat.VecDense, trans bool, x mat.Vector) {
+	m.s.mulVec(dst, trans, x)
+}
+
+// MulMatTo computes A*x or Aᵀ*x and stores the result into dst. If dst is
+// empty, it will be resized to the correct size. dst must not be x.
+func (m *CSR) MulMatTo(dst *mat.Dense, trans bool, x mat.Matrix) {
+	m.s.mulMat(dst, trans, x)
+}
+
+// ToCSC returns a copy of m in compressed sparse column format.
+func (m *CSR) ToCSC() *CSC {
+	return &CSC{s: m.s.transpose()}
+}
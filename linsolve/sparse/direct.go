@@ -0,0 +1,134 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"errors"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	// ErrNotPositiveDefinite is returned by Cholesky.Factorize when the
+	// matrix is not positive definite.
+	ErrNotPositiveDefinite = errors.New("sparse: matrix not positive definite")
+
+	// ErrSingular is returned by LU.Factorize when the matrix is
+	// structurally or numerically singular.
+	ErrSingular = errors.New("sparse: matrix singular")
+)
+
+// columns returns the square matrix a in compressed sparse column format. The
+// returned matrix may share the backing data with a. Zero elements of a matrix
+// that is queried with At are not stored.
+func columns(a mat.Matrix) compressed {
+	r, c := a.Dims()
+	if r != c {
+		panic("sparse: matrix not square")
+	}
+	switch a := a.(type) {
+	case *CSC:
+		return a.s
+	case *CSR:
+		return a.s.transpose()
+	case *ParallelCSR:
+		return a.m.s.transpose()
+	case *COO:
+		return a.ToCSC().s
+	case mat.NonZeroDoer:
+		coo := NewCOO(r, c)
+		a.DoNonZero(coo.Append)
+		return coo.ToCSC().s
+	}
+	coo := NewCOO(r, c)
+	for j := 0; j < c; j++ {
+		for i := 0; i < r; i++ {
+			if v := a.At(i, j); v != 0 {
+				coo.Append(i, j, v)
+			}
+		}
+	}
+	return coo.ToCSC().s
+}
+
+// inversePerm returns the inverse of the permutation perm.
+func inversePerm(perm []int) []int {
+	inv := make([]int, len(perm))
+	for k, i := range perm {
+		inv[i] = k
+	}
+	return inv
+}
+
+// solveVec copies b into dst, allocating dst if it is empty, and calls solve
+// with a contiguous copy of the elements of dst.
+func solveVec(dst *mat.VecDense, b mat.Vector, n int, work []float64, solve func(x []float64)) {
+	if b.Len() != n {
+		panic("sparse: dimension mismatch")
+	}
+	if dst.IsEmpty() {
+		dst.ReuseAsVec(n)
+	} else if dst.Len() != n {
+		panic("sparse: dimension mismatch")
+	}
+	for i := range work {
+		work[i] = b.AtVec(i)
+	}
+	solve(work)
+	for i, v := range work {
+		dst.SetVec(i, v)
+	}
+}
+
+// lowerSolve solves L * x = x in place, where the n×n lower triangular matrix
+// L is given in compressed sparse column format with the diagonal element
+// stored first in each column.
+func lowerSolve(l *compressed, x []float64) {
+	for j := 0; j < l.major; j++ {
+		p := l.indptr[j]
+		x[j] /= l.data[p]
+		xj := x[j]
+		for p++; p < l.indptr[j+1]; p++ {
+			x[l.ind[p]] -= l.data[p] * xj
+		}
+	}
+}
+
+// lowerTransSolve solves Lᵀ * x = x in place, where L is as in lowerSolve.
+func lowerTransSolve(l *compressed, x []float64) {
+	for j := l.major - 1; j >= 0; j-- {
+		xj := x[j]
+		for p := l.indptr[j] + 1; p < l.indptr[j+1]; p++ {
+			xj -= l.data[p] * x[l.ind[p]]
+		}
+		x[j] = xj / l.data[l.indptr[j]]
+	}
+}
+
+// upperSolve solves U * x = x in place, where the n×n upper triangular matrix
+// U is given in compressed sparse column format with the diagonal element
+// stored last in each column.
+func upperSolve(u *compressed, x []float64) {
+	for j := u.major - 1; j >= 0; j-- {
+		p := u.indptr[j+1] - 1
+		x[j] /= u.data[p]
+		xj := x[j]
+		for q := u.indptr[j]; q < p; q++ {
+			x[u.ind[q]] -= u.data[q] * xj
+		}
+	}
+}
+
+// upperTransSolve solves Uᵀ * x = x in place, where U is as in upperSolve.
+func upperTransSolve(u *compressed, x []float64) {
+	for j := 0; j < u.major; j++ {
+		p := u.indptr[j+1] - 1
+		xj := x[j]
+		for q := u.indptr[j]; q < p; q++ {
+			xj -= u.data[q] * x[u.ind[q]]
+		}
+		x[j] = xj / u.data[p]
+	}
+}
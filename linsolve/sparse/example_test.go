@@ -0,0 +1,50 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse_test
+
+import (
+	"fmt"
+
+	"gonum.org/v1/exp/linsolve"
+	"gonum.org/v1/exp/linsolve/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+func ExampleCOO() {
+	// Assemble the matrix of the finite difference discretization of the 1D
+	// Poisson equation element by element. Contributions of adjacent elements
+	// to the same matrix entry are summed.
+	const n = 6
+	a := sparse.NewCOO(n, n)
+	for e := -1; e < n; e++ {
+		for _, i := range []int{e, e + 1} {
+			for _, j := range []int{e, e + 1} {
+				if i < 0 || n <= i || j < 0 || n <= j {
+					continue
+				}
+				v := -1.0
+				if i == j {
+					v = 1
+				}
+				a.Append(i, j, v)
+			}
+		}
+	}
+	// Convert the matrix to the CSR format for efficient multiplication.
+	m := a.ToCSR()
+	fmt.Printf("stored elements: COO %v, CSR %v\n", a.NNZ(), m.NNZ())
+
+	b := mat.NewVecDense(n, []float64{1, 1, 1, 1, 1, 1})
+	result, err := linsolve.Iterative(m, b, &linsolve.CG{}, nil)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Printf("x = %.4g\n", mat.Formatted(result.X.T()))
+
+	// Output:
+	// stored elements: COO 22, CSR 16
+	// x = [3  5  6  6  5  3]
+}
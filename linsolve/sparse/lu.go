@@ -0,0 +1,303 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// pivotThreshold is the threshold of the partial pivoting in LU. The diagonal
+// element is chosen as the pivot if its magnitude is at least pivotThreshold
+// times the largest magnitude of the candidate pivots in its column.
+const pivotThreshold = 0.1
+
+// SymbolicLU is the symbolic analysis of the sparse LU factorization of a
+// square matrix. It consists of the fill-reducing column permutation, which
+// depends only on the pattern of the matrix, so it can be computed once and
+// reused for factorizing several matrices with the same pattern.
+type SymbolicLU struct {
+	n int
+	// q[k] is the column of A that is eliminated in the k-th step.
+	q []int
+	// nnz is an estimate of the number of elements in L and U that is
+	// used for preallocation.
+	nnz int
+}
+
+// NewSymbolicLU returns the symbolic analysis of the LU factorization of the
+// n×n matrix a with the fill-reducing ordering ord which is computed from the
+// pattern of a + aᵀ. NewSymbolicLU panics if a is not square.
+func NewSymbolicLU(a mat.Matrix, ord Ordering) *SymbolicLU {
+	c := columns(a)
+	return &SymbolicLU{
+		n:   c.major,
+		q:   ord.permutation(&c),
+		nnz: 4*len(c.ind) + c.major,
+	}
+}
+
+// LU is the sparse LU factorization
+//
+//	P*A*Q = L*U
+//
+// of a square matrix A, where Q is a fill-reducing column permutation, P is a
+// row permutation that results from partial pivoting, L is unit lower
+// triangular and U is upper triangular.
+//
+// The factorization is split into two phases. The symbolic analysis computed by
+// NewSymbolicLU depends only on the pattern of A and determines the column
+// permutation Q. Because the same permutation is applied to the rows, the
+// pivots are preferably chosen from the diagonal, which preserves the effect
+// of the ordering; an off-diagonal element is chosen only if the magnitude of
+// the diagonal element is less than 0.1 times the largest magnitude in its
+// column. The numeric factorization computed by Factorize uses the symbolic
+// analysis, so factorizing a sequence of matrices with the same pattern, for
+// example in time stepping or in Newton's method, requires only one symbolic
+// analysis.
+//
+// A factorization can be reused for many solves with SolveVecTo. Its
+// PreconSolve method has the signature of linsolve.Settings.PreconSolve, so the
+// factorization of a nearby matrix, for example from a previous time step, can
+// be used as the preconditioner of an iterative method.
+//
+// References:
+//   - Gilbert, J. R., and Peierls, T. (1988). Sparse partial pivoting in time
+//     proportional to arithmetic operations. SIAM Journal on Scientific and
+//     Statistical Computing, 9(5), 862-874. doi:10.1137/0909058
+//   - Davis, T. A. (2006). Chapter 6 LU factorization. In Direct Methods for
+//     Sparse Linear Systems (pp. 83-98). Philadelphia, PA: SIAM.
+type LU struct {
+	sym *SymbolicLU
+	// pinv[i] is the row of P*A*Q that is the i-th row of A.
+	pinv []int
+	// l and u are the factors L and U in compressed sparse column format.
+	// The diagonal element of L is stored first and the diagonal element of
+	// U is stored last in each column.
+	l, u compressed
+
+	work, y []float64
+}
+
+// Factorize computes the LU factorization of the n×n matrix a using the
+// symbolic analysis sym. If sym is nil, the symbolic analysis with the AMD
+// ordering will be computed.
+//
+// Factorize returns ErrSingular if a is singular. In that case the receiver
+// does not hold a valid factorization.
+func (f *LU) Factorize(a mat.Matrix, sym *SymbolicLU) error {
+	f.sym = nil
+	if sym == nil {
+		sym = NewSymbolicLU(a, AMD)
+	}
+	n := sym.n
+	if r, _ := a.Dims(); r != n {
+		panic("sparse: dimension mismatch")
+	}
+	ac := columns(a)
+
+	l, u := &f.l, &f.u
+	for _, m := range []*compressed{l, u} {
+		m.major, m.minor = n, n
+		m.indptr = reuseInts(m.indptr, n+1)
+		m.ind = m.ind[:0]
+		m.data = m.data[:0]
+		if cap(m.ind) < sym.nnz/2 {
+			m.ind = make([]int, 0, sym.nnz/2)
+			m.data = make([]float64, 0, sym.nnz/2)
+		}
+	}
+	pinv := reuseInts(f.pinv, n)
+	for i := range pinv {
+		pinv[i] = -1
+	}
+	f.pinv = pinv
+	x := reuseFloats(f.work, n)
+	for i := range x {
+		x[i] = 0
+	}
+	// xi holds the pattern of the solution of the triangular systems in
+	// xi[top:n] and the stack of the depth-first search in xi[n:].
+	xi := make([]int, 2*n)
+	marked := make([]bool, n)
+
+	for k := 0; k < n; k++ {
+		l.indptr[k] = len(l.ind)
+		u.indptr[k] = len(u.ind)
+
+		// Solve the triangular system L[:,:k] * x = A[:,q[k]].
+		col := sym.q[k]
+		top := f.reach(&ac, col, xi, marked)
+		for p := ac.indptr[col]; p < ac.indptr[col+1]; p++ {
+			x[ac.ind[p]] = ac.data[p]
+		}
+		for _, j := range xi[top:n] {
+			jj := pinv[j]
+			if jj < 0 {
+				continue
+			}
+			// The diagonal element of L is one.
+			xj := x[j]
+			for p := l.indptr[jj] + 1; p < l.indptr[jj+1]; p++ {
+				x[l.ind[p]] -= l.data[p] * xj
+			}
+		}
+
+		// The elements in pivotal rows belong to U, the largest element
+		// in the other rows is the pivot candidate.
+		ipiv := -1
+		amax := -1.0
+		for _, i := range xi[top:n] {
+			if pinv[i] < 0 {
+				if v := math.Abs(x[i]); v > amax {
+					amax = v
+					ipiv = i
+				}
+			} else {
+				u.ind = append(u.ind, pinv[i])
+				u.data = append(u.data, x[i])
+			}
+		}
+		if ipiv == -1 || amax == 0 || math.IsNaN(amax) {
+			for _, i := range xi[top:n] {
+				x[i] = 0
+			}
+			return ErrSingular
+		}
+		if pinv[col] < 0 && math.Abs(x[col]) >= pivotThreshold*amax {
+			// Prefer the diagonal element.
+			ipiv = col
+		}
+
+		pivot := x[ipiv]
+		u.ind = append(u.ind, k)
+		u.data = append(u.data, pivot)
+		pinv[ipiv] = k
+		l.ind = append(l.ind, ipiv)
+		l.data = append(l.data, 1)
+		for _, i := range xi[top:n] {
+			if pinv[i] < 0 {
+				l.ind = append(l.ind, i)
+				l.data = append(l.data, x[i]/pivot)
+			}
+			x[i] = 0
+		}
+	}
+	l.indptr[n] = len(l.ind)
+	u.indptr[n] = len(u.ind)
+	// Renumber the rows of L by the pivot order.
+	for p, i := range l.ind {
+		l.ind[p] = pinv[i]
+	}
+
+	f.work = x
+	f.y = reuseFloats(f.y, n)
+	f.sym = sym
+	return nil
+}
+
+// reach computes the pattern of the solution of the triangular system
+// L[:,:k] * x = A[:,col], the rows reachable from the non-zero rows of the
+// column col of a in the graph of the first k columns of L. The pattern is
+// stored in xi[top:n] in topological order and top is returned. The rows of
+// L are still numbered as the rows of A and pinv maps them to the columns of L.
+func (f *LU) reach(a *compressed, col int, xi []int, marked []bool) (top int) {
+	n := a.major
+	top = n
+	for p := a.indptr[col]; p < a.indptr[col+1]; p++ {
+		if i := a.ind[p]; !marked[i] {
+			top = f.dfs(i, top, xi, marked)
+		}
+	}
+	for _, i := range xi[top:n] {
+		marked[i] = false
+	}
+	return top
+}
+
+// dfs performs a non-recursive depth-first search from the row j in the graph
+// of L and stores the reached rows in xi[:top] in reverse topological order
+// ending at top-1. The new top is returned.
+func (f *LU) dfs(j, top int, xi []int, marked []bool) int {
+	n := len(marked)
+	l := &f.l
+	pinv := f.pinv
+	stack, pstack := xi[:n], xi[n:]
+	head := 0
+	stack[0] = j
+	for head >= 0 {
+		j = stack[head]
+		jj := pinv[j]
+		if !marked[j] {
+			marked[j] = true
+			if jj >= 0 {
+				pstack[head] = l.indptr[jj]
+			}
+		}
+		done := true
+		if jj >= 0 {
+			end := l.indptr[jj+1]
+			for p := pstack[head]; p < end; p++ {
+				i := l.ind[p]
+				if marked[i] {
+					continue
+				}
+				pstack[head] = p + 1
+				head++
+				stack[head] = i
+				done = false
+				break
+			}
+		}
+		if done {
+			head--
+			top--
+			xi[top] = j
+		}
+	}
+	return top
+}
+
+// SolveVecTo solves A * dst = b or Aᵀ * dst = b if trans is true. If dst is
+// empty, it will be resized to the length of b. SolveVecTo panics if the
+// receiver does not hold a valid factorization.
+func (f *LU) SolveVecTo(dst *mat.VecDense, trans bool, b mat.Vector) {
+	if f.sym == nil {
+		panic("sparse: factorization not computed")
+	}
+	q := f.sym.q
+	solveVec(dst, b, f.sym.n, f.work, func(x []float64) {
+		y := f.y
+		if !trans {
+			// L*U * Qᵀ*dst = P*b
+			for i, k := range f.pinv {
+				y[k] = x[i]
+			}
+			lowerSolve(&f.l, y)
+			upperSolve(&f.u, y)
+			for k, j := range q {
+				x[j] = y[k]
+			}
+			return
+		}
+		// Uᵀ*Lᵀ * P*dst = Qᵀ*b
+		for k, j := range q {
+			y[k] = x[j]
+		}
+		upperTransSolve(&f.u, y)
+		lowerTransSolve(&f.l, y)
+		for i, k := range f.pinv {
+			x[i] = y[k]
+		}
+	})
+}
+
+// PreconSolve solves A * dst = rhs or Aᵀ * dst = rhs if trans is true. If dst
+// is empty, it will be resized to the length of rhs.
+func (f *LU) PreconSolve(dst *mat.VecDense, trans bool, rhs mat.Vector) error {
+	f.SolveVecTo(dst, trans, rhs)
+	return nil
+}
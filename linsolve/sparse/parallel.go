@@ -0,0 +1,145 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"sort"
+	"sync"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+var _ mat.Matrix = (*ParallelCSR)(nil)
+
+// ParallelCSR is a sparse matrix in compressed sparse row format that computes
+// matrix-vector products with several goroutines. It shares the storage with
+// the CSR matrix it was created from.
+//
+// The product A*x is computed by splitting the rows into contiguous ranges
+// with about the same number of stored elements. The product Aᵀ*x is computed
+// in the same way from a copy of A in compressed sparse column format that is
+// created by the first multiplication with the transpose, so the elements of
+// A must not be changed after that. Each element of the result is computed by
+// one goroutine in the same order as by CSR.MulVecTo, so the results are
+// identical to those of CSR.MulVecTo and do not depend on the number of
+// workers.
+type ParallelCSR struct {
+	m       *CSR
+	workers int
+
+	// t holds the transpose of m with the columns of m as the major
+	// vectors. It is created once by the first multiplication with the
+	// transpose.
+	tOnce sync.Once
+	t     compressed
+}
+
+// NewParallelCSR returns a new ParallelCSR that multiplies m with vectors using
+// the given number of goroutines. NewParallelCSR panics if workers is not
+// positive.
+func NewParallelCSR(m *CSR, workers int) *ParallelCSR {
+	if workers <= 0 {
+		panic("sparse: number of workers not positive")
+	}
+	return &ParallelCSR{m: m, workers: workers}
+}
+
+// Dims returns the dimensions of the matrix.
+func (m *ParallelCSR) Dims() (r, c int) {
+	return m.m.Dims()
+}
+
+// At returns the element at row i and column j.
+func (m *ParallelCSR) At(i, j int) float64 {
+	return m.m.At(i, j)
+}
+
+// T returns an implicit transpose of m.
+func (m *ParallelCSR) T() mat.Matrix {
+	return mat.Transpose{Matrix: m}
+}
+
+// NNZ returns the number of stored elements of m.
+func (m *ParallelCSR) NNZ() int {
+	return m.m.NNZ()
+}
+
+// MulVecTo computes A*x or Aᵀ*x and stores the result into dst. If dst is
+// empty, it will be resized to the correct length. dst must not be x.
+// MulVecTo is safe for concurrent use.
+func (m *ParallelCSR) MulVecTo(dst *mat.VecDense, trans bool, x mat.Vector) {
+	if !trans {
+		m.m.s.mulVecParallel(dst, x, m.workers, false)
+		return
+	}
+	m.tOnce.Do(func() {
+		m.t = m.m.s.transpose()
+	})
+	m.t.mulVecParallel(dst, x, m.workers, true)
+}
+
+// mulVecParallel computes dst = M * x, where M is the major×minor matrix whose
+// rows are the major vectors, using the given number of goroutines. If
+// skipZero is true, the products with zero elements of x are skipped as in
+// mulVec with trans, so that the result for the transpose of a compressed
+// matrix is identical to that of mulVec.
+func (m *compressed) mulVecParallel(dst *mat.VecDense, x mat.Vector, workers int, skipZero bool) {
+	if x.Len() != m.minor {
+		panic("sparse: dimension mismatch")
+	}
+	if dst.IsEmpty() {
+		dst.ReuseAsVec(m.major)
+	} else if dst.Len() != m.major {
+		panic("sparse: dimension mismatch")
+	}
+	if xv, ok := x.(*mat.VecDense); ok && xv == dst {
+		panic("sparse: destination aliases x")
+	}
+
+	// Split the major vectors into ranges with about the same number of
+	// stored elements.
+	w := min(workers, m.major)
+	bounds := make([]int, w+1)
+	nnz := len(m.data)
+	for i := 1; i < w; i++ {
+		bounds[i] = sort.SearchInts(m.indptr, i*nnz/w)
+	}
+	bounds[w] = m.major
+
+	xd, xinc := vectorData(x)
+	dv := dst.RawVector()
+	dd, dinc := dv.Data, dv.Inc
+	parallel(w, func(i int) {
+		for k := bounds[i]; k < bounds[i+1]; k++ {
+			var v float64
+			for p := m.indptr[k]; p < m.indptr[k+1]; p++ {
+				xl := xd[m.ind[p]*xinc]
+				if skipZero && xl == 0 {
+					continue
+				}
+				v += m.data[p] * xl
+			}
+			dd[k*dinc] = v
+		}
+	})
+}
+
+// parallel calls fn(i) for i in [0, n) concurrently and waits until all calls
+// have returned.
+func parallel(n int, fn func(i int)) {
+	if n == 1 {
+		fn(0)
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,259 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sparse provides sparse matrix types for use with the linsolve
+// package.
+//
+// A sparse matrix is usually assembled in the coordinate format COO by
+// appending its elements in arbitrary order and then converted to the
+// compressed sparse row format CSR or the compressed sparse column format CSC
+// which allow efficient matrix-vector multiplication. All three types
+// implement the mat.Matrix interface and the linsolve.MulVecToer interface. CSR
+// and CSC also implement the linsolve.MulMatToer interface for multiplying
+// several vectors at once. CSR32 is a single precision copy of a CSR matrix
+// for use in mixed-precision solvers, and ParallelCSR computes the
+// matrix-vector products of a CSR matrix with several goroutines.
+//
+// Cholesky and LU are sparse direct factorizations of symmetric positive
+// definite and general square matrices. They permute the matrix with a
+// fill-reducing Ordering and are split into a symbolic analysis of the pattern,
+// which can be reused for matrices with the same pattern, and a numeric
+// factorization. Their PreconSolve methods allow using the factorization of a
+// nearby matrix as a preconditioner.
+package sparse
+
+import (
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+// compressed holds a sparse matrix in compressed format. Depending on the
+// orientation, the major dimension are rows (CSR) or columns (CSC). The minor
+// indices of the elements in the k-th major vector are stored in
+// ind[indptr[k]:indptr[k+1]] in increasing order and the corresponding values
+// are stored in data[indptr[k]:indptr[k+1]].
+type compressed struct {
+	major, minor int
+	indptr       []int
+	ind          []int
+	data         []float64
+}
+
+// newCompressed returns a new compressed matrix after checking the validity of
+// the arguments.
+func newCompressed(major, minor int, indptr, ind []int, data []float64) compressed {
+	if major <= 0 || minor <= 0 {
+		panic("sparse: invalid shape")
+	}
+	if len(indptr) != major+1 || indptr[0] != 0 {
+		panic("sparse: invalid index pointer")
+	}
+	if len(ind) != len(data) || indptr[major] != len(ind) {
+		panic("sparse: mismatched index and data length")
+	}
+	for k := 0; k < major; k++ {
+		if indptr[k+1] < indptr[k] {
+			panic("sparse: invalid index pointer")
+		}
+		for p := indptr[k]; p < indptr[k+1]; p++ {
+			if ind[p] < 0 || minor <= ind[p] {
+				panic("sparse: index out of range")
+			}
+			if p > indptr[k] && ind[p] <= ind[p-1] {
+				panic("sparse: indices not sorted or duplicate")
+			}
+		}
+	}
+	return compressed{
+		major:  major,
+		minor:  minor,
+		indptr: indptr,
+		ind:    ind,
+		data:   data,
+	}
+}
+
+// at returns the element in the k-th major and l-th minor vector.
+func (m *compressed) at(k, l int) float64 {
+	ind := m.ind[m.indptr[k]:m.indptr[k+1]]
+	// Binary search for l.
+	lo, hi := 0, len(ind)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if ind[mid] < l {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo < len(ind) && ind[lo] == l {
+		return m.data[m.indptr[k]+lo]
+	}
+	return 0
+}
+
+// doMajor calls fn with the major and minor index and the value of each
+// stored element of the k-th major vector.
+func (m *compressed) doMajor(k int, fn func(k, l int, v float64)) {
+	for p := m.indptr[k]; p < m.indptr[k+1]; p++ {
+		fn(k, m.ind[p], m.data[p])
+	}
+}
+
+// mulVec computes dst = M * x if trans is false and dst = Mᵀ * x if trans is
+// true, where M is the major×minor matrix whose rows are the major vectors.
+func (m *compressed) mulVec(dst *mat.VecDense, trans bool, x mat.Vector) {
+	r, c := m.major, m.minor
+	if trans {
+		r, c = c, r
+	}
+	if x.Len() != c {
+		panic("sparse: dimension mismatch")
+	}
+	if dst.IsEmpty() {
+		dst.ReuseAsVec(r)
+	} else if dst.Len() != r {
+		panic("sparse: dimension mismatch")
+	}
+	if xv, ok := x.(*mat.VecDense); ok && xv == dst {
+		panic("sparse: destination aliases x")
+	}
+
+	xd, xinc := vectorData(x)
+	dv := dst.RawVector()
+	dd, dinc := dv.Data, dv.Inc
+	if !trans {
+		for k := 0; k < m.major; k++ {
+			var v float64
+			for p := m.indptr[k]; p < m.indptr[k+1]; p++ {
+				v += m.data[p] * xd[m.ind[p]*xinc]
+			}
+			dd[k*dinc] = v
+		}
+		return
+	}
+	for l := 0; l < m.minor; l++ {
+		dd[l*dinc] = 0
+	}
+	for k := 0; k < m.major; k++ {
+		xk := xd[k*xinc]
+		if xk == 0 {
+			continue
+		}
+		for p := m.indptr[k]; p < m.indptr[k+1]; p++ {
+			dd[m.ind[p]*dinc] += m.data[p] * xk
+		}
+	}
+}
+
+// mulMat computes dst = M * x if trans is false and dst = Mᵀ * x if trans is
+// true, where M is the major×minor matrix whose rows are the major vectors.
+// Each stored element of M is read once for all columns of x.
+func (m *compressed) mulMat(dst *mat.Dense, trans bool, x mat.Matrix) {
+	r, c := m.major, m.minor
+	if trans {
+		r, c = c, r
+	}
+	xr, xc := x.Dims()
+	if xr != c {
+		panic("sparse: dimension mismatch")
+	}
+	if dst.IsEmpty() {
+		dst.ReuseAs(r, xc)
+	} else if dr, dc := dst.Dims(); dr != r || dc != xc {
+		panic("sparse: dimension mismatch")
+	}
+	if xd, ok := x.(*mat.Dense); ok && xd == dst {
+		panic("sparse: destination aliases x")
+	}
+
+	xm := matrixData(x)
+	dm := dst.RawMatrix()
+	if !trans {
+		for k := 0; k < m.major; k++ {
+			dk := dm.Data[k*dm.Stride : k*dm.Stride+xc]
+			for j := range dk {
+				dk[j] = 0
+			}
+			for p := m.indptr[k]; p < m.indptr[k+1]; p++ {
+				v := m.data[p]
+				l := m.ind[p]
+				for j, xv := range xm.Data[l*xm.Stride : l*xm.Stride+xc] {
+					dk[j] += v * xv
+				}
+			}
+		}
+		return
+	}
+	for l := 0; l < m.minor; l++ {
+		dl := dm.Data[l*dm.Stride : l*dm.Stride+xc]
+		for j := range dl {
+			dl[j] = 0
+		}
+	}
+	for k := 0; k < m.major; k++ {
+		xk := xm.Data[k*xm.Stride : k*xm.Stride+xc]
+		for p := m.indptr[k]; p < m.indptr[k+1]; p++ {
+			v := m.data[p]
+			l := m.ind[p]
+			for j, xv := range xk {
+				dm.Data[l*dm.Stride+j] += v * xv
+			}
+		}
+	}
+}
+
+// matrixData returns the elements of x in row-major storage. If x does not
+// provide access to its raw data, its elements are copied.
+func matrixData(x mat.Matrix) blas64.General {
+	if rm, ok := x.(mat.RawMatrixer); ok {
+		return rm.RawMatrix()
+	}
+	return mat.DenseCopyOf(x).RawMatrix()
+}
+
+// vectorData returns the elements of x as a slice with an increment. If x
+// does not provide access to its raw data, its elements are copied.
+func vectorData(x mat.Vector) ([]float64, int) {
+	if rv, ok := x.(mat.RawVectorer); ok {
+		raw := rv.RawVector()
+		return raw.Data, raw.Inc
+	}
+	d := make([]float64, x.Len())
+	for i := range d {
+		d[i] = x.AtVec(i)
+	}
+	return d, 1
+}
+
+// transpose returns a copy of m with the roles of the major and minor
+// dimension exchanged.
+func (m *compressed) transpose() compressed {
+	t := compressed{
+		major:  m.minor,
+		minor:  m.major,
+		indptr: make([]int, m.minor+1),
+		ind:    make([]int, len(m.ind)),
+		data:   make([]float64, len(m.data)),
+	}
+	for _, l := range m.ind {
+		t.indptr[l+1]++
+	}
+	for l := 0; l < m.minor; l++ {
+		t.indptr[l+1] += t.indptr[l]
+	}
+	next := make([]int, m.minor)
+	copy(next, t.indptr)
+	// Iterating over the major vectors of m in increasing order ensures that
+	// the minor indices of t are sorted.
+	for k := 0; k < m.major; k++ {
+		for p := m.indptr[k]; p < m.indptr[k+1]; p++ {
+			l := m.ind[p]
+			t.ind[next[l]] = k
+			t.data[next[l]] = m.data[p]
+			next[l]++
+		}
+	}
+	return t
+}
@@ -0,0 +1,792 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// newRandomCOO returns a random r×c COO matrix with about nnz elements,
+// including duplicates and elements that sum to zero, and its dense
+// equivalent.
+func newRandomCOO(r, c, nnz int, rnd *rand.Rand) (*COO, *mat.Dense) {
+	m := NewCOO(r, c)
+	d := mat.NewDense(r, c, nil)
+	for k := 0; k < nnz; k++ {
+		i := rnd.Intn(r)
+		j := rnd.Intn(c)
+		v := rnd.NormFloat64()
+		m.Append(i, j, v)
+		d.Set(i, j, d.At(i, j)+v)
+		switch rnd.Intn(4) {
+		case 0:
+			// Duplicate element.
+			m.Append(i, j, 1)
+			d.Set(i, j, d.At(i, j)+1)
+		case 1:
+			// Cancel the element.
+			m.Append(i, j, -d.At(i, j))
+			d.Set(i, j, 0)
+		}
+	}
+	return m, d
+}
+
+func TestConversion(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c, nnz int
+	}{
+		{1, 1, 0},
+		{1, 1, 3},
+		{1, 5, 4},
+		{5, 1, 4},
+		{10, 10, 30},
+		{20, 7, 50},
+		{7, 20, 50},
+		{50, 50, 1000},
+	} {
+		name := fmt.Sprintf("r=%v,c=%v,nnz=%v", test.r, test.c, test.nnz)
+		coo, want := newRandomCOO(test.r, test.c, test.nnz, rnd)
+		csr := coo.ToCSR()
+		csc := coo.ToCSC()
+		for _, m := range []struct {
+			name string
+			mat  mat.Matrix
+		}{
+			{"COO", coo},
+			{"CSR", csr},
+			{"CSC", csc},
+			{"CSR.ToCSC", csr.ToCSC()},
+			{"CSC.ToCSR", csc.ToCSR()},
+		} {
+			if !mat.Equal(m.mat, want) {
+				t.Errorf("%v: %v: unexpected matrix", name, m.name)
+			}
+			if !mat.Equal(m.mat.T(), want.T()) {
+				t.Errorf("%v: %v: unexpected transpose", name, m.name)
+			}
+		}
+
+		// Compressed formats must not store zero elements.
+		var nnz int
+		for i := 0; i < test.r; i++ {
+			for j := 0; j < test.c; j++ {
+				if want.At(i, j) != 0 {
+					nnz++
+				}
+			}
+		}
+		if csr.NNZ() != nnz || csc.NNZ() != nnz {
+			t.Errorf("%v: unexpected number of stored elements: CSR=%v, CSC=%v, want %v", name, csr.NNZ(), csc.NNZ(), nnz)
+		}
+		csr.DoNonZero(func(i, j int, v float64) {
+			if v == 0 || v != want.At(i, j) {
+				t.Errorf("%v: CSR: unexpected element (%v,%v)=%v", name, i, j, v)
+			}
+		})
+		csc.DoNonZero(func(i, j int, v float64) {
+			if v == 0 || v != want.At(i, j) {
+				t.Errorf("%v: CSC: unexpected element (%v,%v)=%v", name, i, j, v)
+			}
+		})
+		for i := 0; i < test.r; i++ {
+			last := -1
+			csr.DoRowNonZero(i, func(i2, j int, v float64) {
+				if i2 != i || j <= last {
+					t.Errorf("%v: CSR: unexpected element (%v,%v) in row %v", name, i2, j, i)
+				}
+				last = j
+			})
+		}
+		for j := 0; j < test.c; j++ {
+			last := -1
+			csc.DoColNonZero(j, func(i, j2 int, v float64) {
+				if j2 != j || i <= last {
+					t.Errorf("%v: CSC: unexpected element (%v,%v) in column %v", name, i, j2, j)
+				}
+				last = i
+			})
+		}
+
+		// NewCSR and NewCSC must accept the raw data.
+		indptr, ind, data := csr.RawCSR()
+		if !mat.Equal(NewCSR(test.r, test.c, indptr, ind, data), want) {
+			t.Errorf("%v: NewCSR: unexpected matrix", name)
+		}
+		indptr, ind, data = csc.RawCSC()
+		if !mat.Equal(NewCSC(test.r, test.c, indptr, ind, data), want) {
+			t.Errorf("%v: NewCSC: unexpected matrix", name)
+		}
+	}
+}
+
+func TestMulVecTo(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c, nnz int
+	}{
+		{1, 1, 1},
+		{1, 5, 4},
+		{5, 1, 4},
+		{10, 10, 30},
+		{20, 7, 50},
+		{7, 20, 50},
+	} {
+		coo, d := newRandomCOO(test.r, test.c, test.nnz, rnd)
+		for _, m := range []struct {
+			name string
+			mat  interface {
+				MulVecTo(*mat.VecDense, bool, mat.Vector)
+			}
+		}{
+			{"COO", coo},
+			{"CSR", coo.ToCSR()},
+			{"CSC", coo.ToCSC()},
+		} {
+			for _, trans := range []bool{false, true} {
+				for _, inc := range []int{1, 3} {
+					name := fmt.Sprintf("r=%v,c=%v,%v,trans=%v,inc=%v", test.r, test.c, m.name, trans, inc)
+					r, c := test.r, test.c
+					var a mat.Matrix = d
+					if trans {
+						r, c = c, r
+						a = d.T()
+					}
+					x := mat.NewVecDense(c*inc, nil)
+					for i := 0; i < c*inc; i++ {
+						x.SetVec(i, rnd.NormFloat64())
+					}
+					xv := x.SliceVec(0, c*inc)
+					if inc != 1 {
+						xv = mat.NewDense(c, inc, x.RawVector().Data).ColView(0)
+					}
+					var want mat.VecDense
+					want.MulVec(a, xv)
+
+					// Non-empty destination with non-unit increment filled
+					// with NaN.
+					dst := mat.NewDense(r, inc, make([]float64, r*inc)).ColView(0).(*mat.VecDense)
+					for i := 0; i < r; i++ {
+						dst.SetVec(i, math.NaN())
+					}
+					m.mat.MulVecTo(dst, trans, xv)
+					if !mat.EqualApprox(dst, &want, 1e-14) {
+						t.Errorf("%v: unexpected result", name)
+					}
+
+					// Empty destination.
+					var got mat.VecDense
+					m.mat.MulVecTo(&got, trans, xv)
+					if !mat.EqualApprox(&got, &want, 1e-14) {
+						t.Errorf("%v: unexpected result with empty destination", name)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMulMatTo(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c, nnz int
+	}{
+		{1, 1, 1},
+		{5, 1, 4},
+		{10, 10, 30},
+		{20, 7, 50},
+		{7, 20, 50},
+	} {
+		coo, d := newRandomCOO(test.r, test.c, test.nnz, rnd)
+		for _, m := range []struct {
+			name string
+			mat  interface {
+				MulMatTo(*mat.Dense, bool, mat.Matrix)
+			}
+		}{
+			{"CSR", coo.ToCSR()},
+			{"CSC", coo.ToCSC()},
+		} {
+			for _, trans := range []bool{false, true} {
+				for _, k := range []int{1, 3} {
+					name := fmt.Sprintf("r=%v,c=%v,%v,trans=%v,k=%v", test.r, test.c, m.name, trans, k)
+					r, c := test.r, test.c
+					var a mat.Matrix = d
+					if trans {
+						r, c = c, r
+						a = d.T()
+					}
+					x := mat.NewDense(c, k, nil)
+					for i := 0; i < c; i++ {
+						for j := 0; j < k; j++ {
+							x.Set(i, j, rnd.NormFloat64())
+						}
+					}
+					var want mat.Dense
+					want.Mul(a, x)
+
+					// Non-empty destination view with a stride larger than
+					// the number of columns filled with NaN.
+					dst := mat.NewDense(r, k+2, nil)
+					for i := 0; i < r; i++ {
+						for j := 0; j < k+2; j++ {
+							dst.Set(i, j, math.NaN())
+						}
+					}
+					view := dst.Slice(0, r, 1, k+1).(*mat.Dense)
+					m.mat.MulMatTo(view, trans, x)
+					if !mat.EqualApprox(view, &want, 1e-14) {
+						t.Errorf("%v: unexpected result", name)
+					}
+					for i := 0; i < r; i++ {
+						if !math.IsNaN(dst.At(i, 0)) || !math.IsNaN(dst.At(i, k+1)) {
+							t.Errorf("%v: unexpected modification outside destination", name)
+							break
+						}
+					}
+
+					// Empty destination and x without raw data access.
+					var got mat.Dense
+					m.mat.MulMatTo(&got, trans, struct{ mat.Matrix }{x})
+					if !mat.EqualApprox(&got, &want, 1e-14) {
+						t.Errorf("%v: unexpected result with empty destination", name)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCSR32(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c, nnz int
+	}{
+		{1, 1, 1},
+		{5, 1, 4},
+		{10, 10, 30},
+		{20, 7, 50},
+		{7, 20, 50},
+	} {
+		coo, d := newRandomCOO(test.r, test.c, test.nnz, rnd)
+		m := NewCSR32(coo.ToCSR())
+		if r, c := m.Dims(); r != test.r || c != test.c {
+			t.Errorf("r=%v,c=%v: unexpected dimensions %v×%v", test.r, test.c, r, c)
+		}
+		if m.NNZ() != coo.ToCSR().NNZ() {
+			t.Errorf("r=%v,c=%v: unexpected number of stored elements", test.r, test.c)
+		}
+		for _, trans := range []bool{false, true} {
+			name := fmt.Sprintf("r=%v,c=%v,trans=%v", test.r, test.c, trans)
+			r, c := test.r, test.c
+			var a mat.Matrix = d
+			if trans {
+				r, c = c, r
+				a = d.T()
+			}
+			x := mat.NewVecDense(c, nil)
+			for i := 0; i < c; i++ {
+				x.SetVec(i, rnd.NormFloat64())
+			}
+			var want mat.VecDense
+			want.MulVec(a, x)
+
+			got := mat.NewVecDense(r, nil)
+			m.MulVecTo(got, trans, x)
+			// The result is accurate to single precision.
+			if !mat.EqualApprox(got, &want, 1e-5) {
+				t.Errorf("%v: unexpected result", name)
+			}
+			if mat.Equal(got, &want) && test.nnz >= 30 {
+				t.Errorf("%v: result not computed in single precision", name)
+			}
+			// Repeated multiplication gives the same result.
+			again := mat.NewVecDense(r, nil)
+			m.MulVecTo(again, trans, x)
+			if !mat.Equal(again, got) {
+				t.Errorf("%v: unexpected result of repeated multiplication", name)
+			}
+			// The single precision product gives the same result.
+			x32 := make([]float32, c)
+			for i := range x32 {
+				x32[i] = float32(x.AtVec(i))
+			}
+			got32 := make([]float32, r)
+			m.MulVecTo32(got32, trans, x32)
+			for i, v := range got32 {
+				if float64(v) != got.AtVec(i) {
+					t.Errorf("%v: unexpected result of single precision product", name)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestParallelCSR(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c, nnz int
+	}{
+		{1, 1, 1},
+		{5, 1, 4},
+		{10, 10, 30},
+		{20, 7, 50},
+		{7, 20, 50},
+		{200, 150, 2000},
+	} {
+		coo, d := newRandomCOO(test.r, test.c, test.nnz, rnd)
+		csr := coo.ToCSR()
+		for _, workers := range []int{1, 2, 3, 8} {
+			m := NewParallelCSR(csr, workers)
+			if !mat.Equal(m, d) {
+				t.Errorf("r=%v,c=%v,workers=%v: unexpected matrix", test.r, test.c, workers)
+			}
+			for _, trans := range []bool{false, true} {
+				name := fmt.Sprintf("r=%v,c=%v,workers=%v,trans=%v", test.r, test.c, workers, trans)
+				r, c := test.r, test.c
+				if trans {
+					r, c = c, r
+				}
+				x := mat.NewVecDense(c, nil)
+				for i := 0; i < c; i++ {
+					if i%4 != 1 {
+						x.SetVec(i, rnd.NormFloat64())
+					}
+				}
+				var want mat.VecDense
+				csr.MulVecTo(&want, trans, x)
+
+				// The result is identical to the serial product
+				// for any number of workers.
+				got := mat.NewVecDense(r, nil)
+				m.MulVecTo(got, trans, x)
+				if !mat.Equal(got, &want) {
+					t.Errorf("%v: result differs from serial product", name)
+				}
+
+				again := mat.NewVecDense(r, nil)
+				m.MulVecTo(again, trans, x)
+				if !mat.Equal(got, again) {
+					t.Errorf("%v: result not deterministic", name)
+				}
+			}
+		}
+	}
+
+	// Concurrent multiplications with the transpose give the same result.
+	coo, _ := newRandomCOO(200, 150, 2000, rnd)
+	m := NewParallelCSR(coo.ToCSR(), 4)
+	x := mat.NewVecDense(200, nil)
+	for i := 0; i < 200; i++ {
+		x.SetVec(i, rnd.NormFloat64())
+	}
+	var want mat.VecDense
+	coo.ToCSR().MulVecTo(&want, true, x)
+	var wg sync.WaitGroup
+	results := make([]mat.VecDense, 4)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.MulVecTo(&results[i], true, x)
+		}()
+	}
+	wg.Wait()
+	for i := range results {
+		if !mat.Equal(&results[i], &want) {
+			t.Errorf("unexpected result of concurrent multiplication %d", i)
+		}
+	}
+
+	if !panics(func() { NewParallelCSR(NewCOO(1, 1).ToCSR(), 0) }) {
+		t.Errorf("expected panic for zero workers")
+	}
+}
+
+// newPoisson2D returns the matrix of the 5-point finite difference
+// discretization of the negative Laplacian on an nx×ny grid with the unknowns
+// numbered by perm, or in natural order if perm is nil.
+func newPoisson2D(nx, ny int, perm []int) *COO {
+	n := nx * ny
+	if perm == nil {
+		perm = make([]int, n)
+		for i := range perm {
+			perm[i] = i
+		}
+	}
+	a := NewCOO(n, n)
+	for i := 0; i < nx; i++ {
+		for j := 0; j < ny; j++ {
+			row := perm[i*ny+j]
+			a.Append(row, row, 4)
+			if i > 0 {
+				a.Append(row, perm[(i-1)*ny+j], -1)
+			}
+			if i < nx-1 {
+				a.Append(row, perm[(i+1)*ny+j], -1)
+			}
+			if j > 0 {
+				a.Append(row, perm[i*ny+j-1], -1)
+			}
+			if j < ny-1 {
+				a.Append(row, perm[i*ny+j+1], -1)
+			}
+		}
+	}
+	return a
+}
+
+// newRandomSPD returns a random symmetric diagonally dominant n×n matrix with
+// positive diagonal and about nnz off-diagonal elements.
+func newRandomSPD(n, nnz int, rnd *rand.Rand) *COO {
+	a := NewCOO(n, n)
+	diag := make([]float64, n)
+	for k := 0; k < nnz; k++ {
+		i := rnd.Intn(n)
+		j := rnd.Intn(n)
+		if i == j {
+			continue
+		}
+		v := rnd.NormFloat64()
+		a.Append(i, j, v)
+		a.Append(j, i, v)
+		diag[i] += math.Abs(v)
+		diag[j] += math.Abs(v)
+	}
+	for i, d := range diag {
+		a.Append(i, i, 1+d)
+	}
+	return a
+}
+
+// directResidual returns the relative residual |b - op(A)*x| / |b| of the
+// solution of the system with the matrix a.
+func directResidual(a mat.Matrix, trans bool, x, b *mat.VecDense) float64 {
+	var r mat.VecDense
+	if trans {
+		r.MulVec(a.T(), x)
+	} else {
+		r.MulVec(a, x)
+	}
+	r.SubVec(b, &r)
+	return mat.Norm(&r, 2) / mat.Norm(b, 2)
+}
+
+func randomVec(n int, rnd *rand.Rand) *mat.VecDense {
+	v := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		v.SetVec(i, rnd.NormFloat64())
+	}
+	return v
+}
+
+func TestAMD(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, nnz int
+	}{
+		{1, 0},
+		{2, 0},
+		{10, 5},
+		{50, 200},
+		{200, 600},
+	} {
+		coo, _ := newRandomCOO(test.n, test.n, test.nnz, rnd)
+		c := coo.ToCSC()
+		perm := AMD.permutation(&c.s)
+		seen := make([]bool, test.n)
+		for _, i := range perm {
+			if i < 0 || test.n <= i || seen[i] {
+				t.Fatalf("n=%v,nnz=%v: invalid permutation %v", test.n, test.nnz, perm)
+			}
+			seen[i] = true
+		}
+		if len(perm) != test.n {
+			t.Errorf("n=%v,nnz=%v: invalid permutation length %v", test.n, test.nnz, len(perm))
+		}
+	}
+
+	// AMD reduces the fill-in for a grid in natural and in random order.
+	const nx = 30
+	for _, perm := range [][]int{nil, rnd.Perm(nx * nx)} {
+		a := newPoisson2D(nx, nx, perm)
+		natural := NewSymbolicCholesky(a, Natural).NNZ()
+		amd := NewSymbolicCholesky(a, AMD).NNZ()
+		if amd >= natural*3/4 {
+			t.Errorf("random=%v: AMD does not reduce fill-in: nnz(L)=%v, natural %v", perm != nil, amd, natural)
+		}
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name string
+		a    *COO
+	}{
+		{"n=1", newRandomSPD(1, 0, rnd)},
+		{"diagonal", newRandomSPD(10, 0, rnd)},
+		{"random", newRandomSPD(100, 300, rnd)},
+		{"Poisson", newPoisson2D(15, 15, nil)},
+		{"Poisson random order", newPoisson2D(15, 15, rnd.Perm(225))},
+	} {
+		n, _ := test.a.Dims()
+		csr := test.a.ToCSR()
+		dense := mat.DenseCopyOf(test.a)
+		for _, ord := range []Ordering{AMD, Natural} {
+			nnz := NewSymbolicCholesky(test.a, ord).NNZ()
+			for _, m := range []struct {
+				name string
+				mat  mat.Matrix
+			}{
+				{"COO", test.a},
+				{"CSR", csr},
+				{"CSC", test.a.ToCSC()},
+				{"Dense", dense},
+			} {
+				name := fmt.Sprintf("%v,ord=%v,%v", test.name, ord, m.name)
+				sym := NewSymbolicCholesky(m.mat, ord)
+				// Zero elements of a dense matrix are not part of
+				// the pattern.
+				if sym.NNZ() != nnz {
+					t.Errorf("%v: unexpected number of elements in L: %v, want %v", name, sym.NNZ(), nnz)
+				}
+				var chol Cholesky
+				err := chol.Factorize(m.mat, sym)
+				if err != nil {
+					t.Errorf("%v: unexpected error %v", name, err)
+					continue
+				}
+				if len(chol.l.ind) != sym.NNZ() {
+					t.Errorf("%v: mismatched number of elements in L: %v, want %v", name, len(chol.l.ind), sym.NNZ())
+				}
+				b := randomVec(n, rnd)
+				var x mat.VecDense
+				chol.SolveVecTo(&x, b)
+				if res := directResidual(dense, false, &x, b); res > 1e-12 {
+					t.Errorf("%v: unexpected residual %v", name, res)
+				}
+			}
+		}
+
+		// Reuse the symbolic analysis for a matrix with the same
+		// pattern and for a matrix with a subset of the pattern.
+		sym := NewSymbolicCholesky(csr, AMD)
+		shifted := NewCOO(n, n)
+		diag := NewCOO(n, n)
+		test.a.DoNonZero(func(i, j int, v float64) {
+			shifted.Append(i, j, 2*v)
+			if i == j {
+				diag.Append(i, j, v)
+			}
+		})
+		for _, a := range []*COO{shifted, diag} {
+			var chol Cholesky
+			err := chol.Factorize(a, sym)
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", test.name, err)
+				continue
+			}
+			b := randomVec(n, rnd)
+			var x mat.VecDense
+			err = chol.PreconSolve(&x, false, b)
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", test.name, err)
+			}
+			if res := directResidual(mat.DenseCopyOf(a), false, &x, b); res > 1e-12 {
+				t.Errorf("%v: unexpected residual %v after refactorization", test.name, res)
+			}
+		}
+	}
+
+	// An indefinite matrix.
+	a := newPoisson2D(5, 5, nil)
+	a.Append(12, 12, -10)
+	var chol Cholesky
+	if err := chol.Factorize(a, nil); err != ErrNotPositiveDefinite {
+		t.Errorf("unexpected error for indefinite matrix: got %v, want %v", err, ErrNotPositiveDefinite)
+	}
+	if !panics(func() { chol.SolveVecTo(mat.NewVecDense(25, nil), mat.NewVecDense(25, nil)) }) {
+		t.Errorf("expected panic for failed factorization")
+	}
+	if !panics(func() { NewSymbolicCholesky(NewCOO(2, 3), AMD) }) {
+		t.Errorf("expected panic for non-square matrix")
+	}
+}
+
+func TestLU(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1))
+	// newPivoting returns a random sparse n×n matrix whose largest element
+	// in each column is in a random row, so that pivoting is necessary.
+	newPivoting := func(n, nnz int) *COO {
+		a, _ := newRandomCOO(n, n, nnz, rnd)
+		for j, i := range rnd.Perm(n) {
+			a.Append(i, j, 10)
+		}
+		return a
+	}
+	// newDominant returns a random diagonally dominant n×n matrix.
+	newDominant := func(n, nnz int) *COO {
+		a, _ := newRandomCOO(n, n, nnz, rnd)
+		for i := 0; i < n; i++ {
+			a.Append(i, i, 10)
+		}
+		return a
+	}
+	for _, test := range []struct {
+		name string
+		a    *COO
+	}{
+		{"n=1", newDominant(1, 0)},
+		{"dominant", newDominant(100, 300)},
+		{"pivoting small", newPivoting(5, 5)},
+		{"pivoting", newPivoting(100, 300)},
+		{"Poisson random order", newPoisson2D(15, 15, rnd.Perm(225))},
+	} {
+		n, _ := test.a.Dims()
+		dense := mat.DenseCopyOf(test.a)
+		for _, ord := range []Ordering{AMD, Natural} {
+			for _, m := range []struct {
+				name string
+				mat  mat.Matrix
+			}{
+				{"COO", test.a},
+				{"CSR", test.a.ToCSR()},
+				{"CSC", test.a.ToCSC()},
+				{"Dense", dense},
+			} {
+				name := fmt.Sprintf("%v,ord=%v,%v", test.name, ord, m.name)
+				var lu LU
+				err := lu.Factorize(m.mat, NewSymbolicLU(m.mat, ord))
+				if err != nil {
+					t.Errorf("%v: unexpected error %v", name, err)
+					continue
+				}
+				for _, trans := range []bool{false, true} {
+					b := randomVec(n, rnd)
+					var x mat.VecDense
+					lu.SolveVecTo(&x, trans, b)
+					if res := directResidual(dense, trans, &x, b); res > 1e-12 {
+						t.Errorf("%v,trans=%v: unexpected residual %v", name, trans, res)
+					}
+				}
+			}
+		}
+	}
+
+	// The factorization of a nearby matrix is a good preconditioner for
+	// the stationary iteration x += M^{-1} * (b - A*x).
+	a0 := newDominant(200, 800)
+	sym := NewSymbolicLU(a0, AMD)
+	var lu LU
+	if err := lu.Factorize(a0, sym); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	a1 := NewCOO(200, 200)
+	a0.DoNonZero(func(i, j int, v float64) {
+		a1.Append(i, j, v*(1+0.01*rnd.NormFloat64()))
+	})
+	dense := mat.DenseCopyOf(a1)
+	b := randomVec(200, rnd)
+	x := mat.NewVecDense(200, nil)
+	var r, dx mat.VecDense
+	for k := 0; k < 10; k++ {
+		r.MulVec(dense, x)
+		r.SubVec(b, &r)
+		lu.PreconSolve(&dx, false, &r)
+		x.AddVec(x, &dx)
+	}
+	if res := directResidual(dense, false, x, b); res > 1e-12 {
+		t.Errorf("unexpected residual %v with factorization of nearby matrix", res)
+	}
+	// Refactorize with the same symbolic analysis.
+	if err := lu.Factorize(a1, sym); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	lu.SolveVecTo(x, false, b)
+	if res := directResidual(dense, false, x, b); res > 1e-12 {
+		t.Errorf("unexpected residual %v after refactorization", res)
+	}
+
+	// A structurally singular matrix with a zero column and a numerically
+	// singular matrix.
+	zeroCol := NewCOO(3, 3)
+	zeroCol.Append(0, 0, 1)
+	zeroCol.Append(1, 0, 1)
+	zeroCol.Append(2, 2, 1)
+	rankOne := NewCOO(2, 2)
+	for _, k := range []int{0, 1, 2, 3} {
+		rankOne.Append(k/2, k%2, 1)
+	}
+	for _, a := range []*COO{zeroCol, rankOne} {
+		if err := lu.Factorize(a, nil); err != ErrSingular {
+			t.Errorf("unexpected error for singular matrix: got %v, want %v", err, ErrSingular)
+		}
+	}
+	if !panics(func() { lu.SolveVecTo(mat.NewVecDense(2, nil), false, mat.NewVecDense(2, nil)) }) {
+		t.Errorf("expected panic for failed factorization")
+	}
+}
+
+func TestNewCompressedPanics(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name   string
+		r, c   int
+		indptr []int
+		ind    []int
+		data   []float64
+	}{
+		{name: "shape", r: 0, c: 2, indptr: []int{0}},
+		{name: "indptr length", r: 2, c: 2, indptr: []int{0, 1}, ind: []int{0}, data: []float64{1}},
+		{name: "indptr start", r: 1, c: 2, indptr: []int{1, 1}},
+		{name: "indptr decreasing", r: 2, c: 2, indptr: []int{0, 2, 1}, ind: []int{0}, data: []float64{1}},
+		{name: "data length", r: 1, c: 2, indptr: []int{0, 2}, ind: []int{0, 1}, data: []float64{1}},
+		{name: "index range", r: 1, c: 2, indptr: []int{0, 1}, ind: []int{2}, data: []float64{1}},
+		{name: "unsorted", r: 1, c: 3, indptr: []int{0, 2}, ind: []int{2, 1}, data: []float64{1, 2}},
+		{name: "duplicate", r: 1, c: 3, indptr: []int{0, 2}, ind: []int{1, 1}, data: []float64{1, 2}},
+	} {
+		if !panics(func() { NewCSR(test.r, test.c, test.indptr, test.ind, test.data) }) {
+			t.Errorf("%v: NewCSR did not panic", test.name)
+		}
+		if !panics(func() { NewCSC(test.c, test.r, test.indptr, test.ind, test.data) }) {
+			t.Errorf("%v: NewCSC did not panic", test.name)
+		}
+	}
+}
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return false
+}
@@ -0,0 +1,241 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// StoppingCriterion determines whether an iterative process has converged.
+type StoppingCriterion interface {
+	// Init initializes the criterion before the iterative process
+	// starts. bNorm is the norm of the right-hand side and resNorm
+	// is the norm of the initial residual.
+	Init(bNorm, resNorm float64)
+
+	// Converged is called when Method commands CheckResidualNorm
+	// and reports whether the current state satisfies the
+	// criterion. It is also called once before the iterative
+	// process starts to check the initial guess. ctx.ResidualNorm
+	// holds (an estimate of) the norm of the current residual.
+	// ctx.X holds the approximate solution from the current or a
+	// previous iteration. Converged must not modify ctx.
+	Converged(ctx *Context) bool
+}
+
+// RelativeResidual is a stopping criterion that is satisfied when
+//
+//	|r_i| < Tolerance * |b|,
+//
+// where r_i is the residual at i-th iteration and b is the right-hand side. If
+// b is the zero vector, |b| is taken to be 1. It is the default criterion used
+// by Iterative with the tolerance given by Settings.Tolerance.
+type RelativeResidual struct {
+	// Tolerance must be positive and less than 1.
+	Tolerance float64
+
+	bNorm float64
+}
+
+// Init initializes the criterion. See the StoppingCriterion interface for more details.
+func (c *RelativeResidual) Init(bNorm, resNorm float64) {
+	checkTolerance(c.Tolerance)
+	c.bNorm = bNorm
+	if c.bNorm == 0 {
+		c.bNorm = 1
+	}
+}
+
+// Converged reports whether the criterion is satisfied. See the StoppingCriterion interface for more details.
+func (c *RelativeResidual) Converged(ctx *Context) bool {
+	return ctx.ResidualNorm < c.Tolerance*c.bNorm
+}
+
+// AbsoluteResidual is a stopping criterion that is satisfied when
+//
+//	|r_i| < Tolerance,
+//
+// where r_i is the residual at i-th iteration.
+type AbsoluteResidual struct {
+	// Tolerance must be positive.
+	Tolerance float64
+}
+
+// Init initializes the criterion. See the StoppingCriterion interface for more details.
+func (c *AbsoluteResidual) Init(bNorm, resNorm float64) {
+	if c.Tolerance <= 0 {
+		panic("linsolve: invalid tolerance")
+	}
+}
+
+// Converged reports whether the criterion is satisfied. See the StoppingCriterion interface for more details.
+func (c *AbsoluteResidual) Converged(ctx *Context) bool {
+	return ctx.ResidualNorm < c.Tolerance
+}
+
+// RelativeInitialResidual is a stopping criterion that is satisfied when
+//
+//	|r_i| < Tolerance * |r_0|,
+//
+// where r_i is the residual at i-th iteration and r_0 is the residual of the
+// initial guess.
+type RelativeInitialResidual struct {
+	// Tolerance must be positive and less than 1.
+	Tolerance float64
+
+	r0Norm float64
+}
+
+// Init initializes the criterion. See the StoppingCriterion interface for more details.
+func (c *RelativeInitialResidual) Init(bNorm, resNorm float64) {
+	checkTolerance(c.Tolerance)
+	c.r0Norm = resNorm
+}
+
+// Converged reports whether the criterion is satisfied. See the StoppingCriterion interface for more details.
+func (c *RelativeInitialResidual) Converged(ctx *Context) bool {
+	return ctx.ResidualNorm < c.Tolerance*c.r0Norm
+}
+
+// BackwardError is a stopping criterion that is satisfied when the normwise
+// backward error
+//
+//	|r_i| / (|A| * |x_i| + |b|)
+//
+// is less than Tolerance, where r_i is the residual and x_i the approximate
+// solution at i-th iteration. The backward error is the smallest relative
+// perturbation of A and b for which x_i is the exact solution.
+//
+// Because Context.X is not necessarily up to date when the residual norm is
+// checked, |x_i| is approximated by the norm of the latest available
+// approximate solution.
+//
+// References:
+//   - Rigal, J., and Gaches, J. (1967). On the compatibility of a given solution
+//     with the data of a linear system. J. ACM, 14(3), 543-548.
+//     doi:10.1145/321406.321416
+type BackwardError struct {
+	// ANorm is the norm of A or an estimate of it. It must be positive.
+	// The 2-norm or an upper bound such as the Frobenius norm can be used.
+	ANorm float64
+
+	// Tolerance must be positive and less than 1.
+	Tolerance float64
+
+	bNorm float64
+}
+
+// Init initializes the criterion. See the StoppingCriterion interface for more details.
+func (c *BackwardError) Init(bNorm, resNorm float64) {
+	checkTolerance(c.Tolerance)
+	if c.ANorm <= 0 {
+		panic("linsolve: invalid matrix norm")
+	}
+	c.bNorm = bNorm
+}
+
+// Converged reports whether the criterion is satisfied. See the StoppingCriterion interface for more details.
+func (c *BackwardError) Converged(ctx *Context) bool {
+	return ctx.ResidualNorm < c.Tolerance*(c.ANorm*mat.Norm(ctx.X, 2)+c.bNorm)
+}
+
+// EnergyNorm is a stopping criterion for methods for symmetric positive
+// definite matrices that is based on an estimate of the A-norm of the error
+//
+//	|x - x_i|_A = sqrt((x - x_i)ᵀ * A * (x - x_i)),
+//
+// which is the quantity minimized by CG. The criterion is satisfied when the
+// estimate of the A-norm of the error relative to the A-norm of the initial
+// error is less than Tolerance.
+//
+// The estimate is the Hestenes-Stiefel lower bound computed from the decrease
+// of the squared A-norm of the error in the last Delay iterations, as provided
+// by Context.EnergyDecrease. It estimates the error Delay iterations back, so
+// the criterion is satisfied after Delay additional iterations. EnergyNorm can
+// only be used with methods that set Context.EnergyDecrease such as CG and
+// DeflatedCG, otherwise it panics.
+//
+// References:
+//   - Strakoš, Z., and Tichý, P. (2002). On error estimation in the conjugate
+//     gradient method and why it works in finite precision computations.
+//     Electronic Transactions on Numerical Analysis, 13, 56-80.
+//   - Arioli, M. (2004). A stopping criterion for the conjugate gradient
+//     algorithm in a finite element method framework. Numerische Mathematik,
+//     97(1), 1-24. doi:10.1007/s00211-003-0500-y
+type EnergyNorm struct {
+	// Tolerance must be positive and less than 1.
+	Tolerance float64
+
+	// Delay is the number of iterations used for the estimate. It must
+	// not be negative. If Delay is 0, a default value of 4 will be used.
+	Delay int
+
+	// terms holds the decrease of the squared A-norm of the error in the
+	// last delay iterations as a circular buffer.
+	terms []float64
+	next  int
+	// sum is the sum of all decreases which estimates the squared A-norm
+	// of the initial error.
+	sum float64
+}
+
+// Init initializes the criterion. See the StoppingCriterion interface for more details.
+func (c *EnergyNorm) Init(bNorm, resNorm float64) {
+	checkTolerance(c.Tolerance)
+	if c.Delay < 0 {
+		panic("linsolve: negative delay")
+	}
+	d := c.Delay
+	if d == 0 {
+		d = 4
+	}
+	if cap(c.terms) < d {
+		c.terms = make([]float64, 0, d)
+	} else {
+		c.terms = c.terms[:0:d]
+	}
+	c.next = 0
+	c.sum = 0
+}
+
+// Converged reports whether the criterion is satisfied. See the StoppingCriterion interface for more details.
+func (c *EnergyNorm) Converged(ctx *Context) bool {
+	if ctx.ResidualNorm == 0 {
+		return true
+	}
+	term := ctx.EnergyDecrease
+	if math.IsNaN(term) {
+		panic("linsolve: EnergyNorm used with a method that does not set Context.EnergyDecrease")
+	}
+	// The decrease is zero before the first iteration. It can also be zero,
+	// or slightly negative due to rounding, for example in the projection
+	// step of DeflatedCG if the recycled subspace is orthogonal to the
+	// residual.
+	term = math.Max(term, 0)
+	if c.sum == 0 && term == 0 {
+		// There is no decrease to estimate the error from.
+		return false
+	}
+	c.sum += term
+	if len(c.terms) < cap(c.terms) {
+		c.terms = append(c.terms, term)
+		return false
+	}
+	c.terms[c.next] = term
+	c.next = (c.next + 1) % len(c.terms)
+	var est float64
+	for _, v := range c.terms {
+		est += v
+	}
+	return math.Sqrt(est) < c.Tolerance*math.Sqrt(c.sum)
+}
+
+func checkTolerance(tol float64) {
+	if tol <= 0 || 1 <= tol {
+		panic("linsolve: invalid tolerance")
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// TFQMR implements the Transpose-Free Quasi-Minimal Residual method with right
+// preconditioning for solving systems of linear equations
+//
+//	A * x = b,
+//
+// where A is a nonsymmetric, nonsingular matrix. TFQMR is derived from CGS
+// but it replaces the CGS iterates by quasi-minimal residual approximations
+// which leads to much smoother convergence. It does not require
+// multiplication with Aᵀ.
+//
+// One iteration of TFQMR consists of two half-steps, each of which requires
+// one MulVec and one PreconSolve. Convergence is checked after each half-step.
+// The residual norm reported at CheckResidualNorm is the standard upper bound
+// on the norm of the residual that is available without extra computation.
+//
+// References:
+//   - Freund, R. (1993). A transpose-free quasi-minimal residual algorithm for
+//     non-Hermitian linear systems. SIAM J. Sci. Comput., 14(2), 470-482.
+//     doi:10.1137/0914029
+//   - Saad, Y. (2003). Section 7.4.3 Transpose-Free QMR (TFQMR). In Iterative
+//     Methods for Sparse Linear Systems (2nd ed.) (pp. 252-258). Philadelphia,
+//     PA: SIAM.
+type TFQMR struct {
+	x     mat.VecDense
+	rt    mat.VecDense
+	u     mat.VecDense
+	uNext mat.VecDense
+	// ut is M^{-1} * u.
+	ut mat.VecDense
+	// uhat is A * M^{-1} * u.
+	uhat mat.VecDense
+	v    mat.VecDense
+	w    mat.VecDense
+	// d is the search direction in the preconditioned space.
+	d mat.VecDense
+
+	rho, rhoPrev float64
+	alpha        float64
+	theta, eta   float64
+	tau          float64
+
+	k      int // Half-step counter.
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (t *TFQMR) Init(x, residual *mat.VecDense) {
+	dim := x.Len()
+	if residual.Len() != dim {
+		panic("tfqmr: vector length mismatch")
+	}
+
+	t.x.CloneFromVec(x)
+	t.rt.CloneFromVec(residual)
+	t.u.CloneFromVec(residual)
+	t.w.CloneFromVec(residual)
+
+	t.uNext.Reset()
+	t.uNext.ReuseAsVec(dim)
+	t.ut.Reset()
+	t.ut.ReuseAsVec(dim)
+	t.uhat.Reset()
+	t.uhat.ReuseAsVec(dim)
+	t.v.Reset()
+	t.v.ReuseAsVec(dim)
+	t.d.Reset()
+	t.d.ReuseAsVec(dim)
+
+	t.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// TFQMR will command the following operations:
+//
+//	MulVec
+//	PreconSolve
+//	CheckResidualNorm
+//	MajorIteration
+//	NoOperation
+func (t *TFQMR) Iterate(ctx *Context) (Operation, error) {
+	switch t.resume {
+	case 1:
+		// Solve M^{-1} * r_0.
+		ctx.Src.CopyVec(&t.u)
+		t.resume = 2
+		return PreconSolve, nil
+	case 2:
+		t.ut.CopyVec(ctx.Dst)
+		// Compute A * M^{-1} * r_0.
+		ctx.Src.CopyVec(&t.ut)
+		t.resume = 3
+		return MulVec, nil
+	case 3:
+		t.uhat.CopyVec(ctx.Dst)
+		t.v.CopyVec(&t.uhat)
+		t.d.Zero()
+		t.theta = 0
+		t.eta = 0
+		t.rho = mat.Dot(&t.rt, &t.u)
+		t.rhoPrev = t.rho
+		t.tau = math.Sqrt(t.rho)
+		t.k = 0
+		fallthrough
+	case 4:
+		if t.k%2 == 0 {
+			vr := mat.Dot(&t.rt, &t.v)
+			if math.Abs(vr) < breakdownTol {
+				t.resume = 0
+				return NoOperation, &BreakdownError{math.Abs(vr), breakdownTol}
+			}
+			t.alpha = t.rho / vr
+			t.uNext.AddScaledVec(&t.u, -t.alpha, &t.v)
+		}
+		t.w.AddScaledVec(&t.w, -t.alpha, &t.uhat)
+		// d = M^{-1} * u + (θ²/α) η d
+		t.d.AddScaledVec(&t.ut, t.theta*t.theta/t.alpha*t.eta, &t.d)
+		// Compute the quasi-minimal residual step.
+		t.theta = mat.Norm(&t.w, 2) / t.tau
+		c := 1 / math.Sqrt(1+t.theta*t.theta)
+		t.tau *= t.theta * c
+		t.eta = c * c * t.alpha
+		t.x.AddScaledVec(&t.x, t.eta, &t.d)
+		// Check the upper bound on the residual norm.
+		ctx.ResidualNorm = t.tau * math.Sqrt(float64(t.k+2))
+		t.resume = 5
+		return CheckResidualNorm, nil
+	case 5:
+		if ctx.Converged {
+			ctx.X.CopyVec(&t.x)
+			t.resume = 0
+			return MajorIteration, nil
+		}
+		t.resume = 6
+		if t.k%2 == 1 {
+			// A full step has been completed.
+			ctx.X.CopyVec(&t.x)
+			return MajorIteration, nil
+		}
+		return NoOperation, nil
+	case 6:
+		if t.k%2 == 1 {
+			rho := mat.Dot(&t.rt, &t.w)
+			if math.Abs(rho) < breakdownTol {
+				t.resume = 0
+				return NoOperation, &BreakdownError{math.Abs(rho), breakdownTol}
+			}
+			beta := rho / t.rhoPrev
+			t.rho = rho
+			// u = w + β u
+			t.u.AddScaledVec(&t.w, beta, &t.u)
+			// v = β (A * M^{-1} * u_old) + β² v, the rest is added below.
+			t.v.AddScaledVec(&t.uhat, beta, &t.v)
+			t.v.ScaleVec(beta, &t.v)
+		} else {
+			t.u.CopyVec(&t.uNext)
+			t.rhoPrev = t.rho
+		}
+		// Solve M^{-1} * u.
+		ctx.Src.CopyVec(&t.u)
+		t.resume = 7
+		return PreconSolve, nil
+	case 7:
+		t.ut.CopyVec(ctx.Dst)
+		// Compute A * M^{-1} * u.
+		ctx.Src.CopyVec(&t.ut)
+		t.resume = 8
+		return MulVec, nil
+	case 8:
+		t.uhat.CopyVec(ctx.Dst)
+		if t.k%2 == 1 {
+			t.v.AddVec(&t.v, &t.uhat)
+		}
+		t.k++
+		t.resume = 4
+		return NoOperation, nil
+
+	default:
+		panic("tfqmr: Init not called")
+	}
+}